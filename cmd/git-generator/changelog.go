@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nguyendkn/git-generator/internal/changelog"
+	"github.com/nguyendkn/git-generator/internal/ui"
+	versioning "github.com/nguyendkn/git-generator/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate a changelog from version tags and conventional commits",
+	Long: `Generate a Markdown changelog from the repository's version tags.
+
+Commits between consecutive version tags are grouped by conventional commit
+type, with scopes, breaking change callouts, commit links and optional author
+credits. Commits after the latest tag are listed as Unreleased.
+
+Two formats are supported:
+- keepachangelog: Added, Changed, Removed and Fixed sections (keepachangelog.com)
+- conventional:   Features, Bug Fixes, Performance Improvements and Reverts
+
+By default only releases missing from the existing changelog are prepended,
+so manual edits to older releases are kept. Use --full to regenerate it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		full, _ := cmd.Flags().GetBool("full")
+
+		config := appConfig.Changelog
+		if cmd.Flags().Changed("format") {
+			config.Format, _ = cmd.Flags().GetString("format")
+		}
+		if cmd.Flags().Changed("authors") {
+			config.Authors, _ = cmd.Flags().GetBool("authors")
+		}
+		if config.Format != changelog.FormatKeepAChangelog && config.Format != changelog.FormatConventional {
+			return fmt.Errorf("invalid changelog format: %s (must be one of: keepachangelog, conventional)", config.Format)
+		}
+		if output == "" {
+			output = config.File
+		}
+
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
+		if !gitService.IsGitRepository() {
+			ui.ShowErrorMessage("Không phải Git repository")
+			return fmt.Errorf("not in a Git repository")
+		}
+
+		versionService := versioning.NewService(gitService, nil, nil, *appConfig)
+		releases, err := changelog.NewService(gitService, versionService).Releases(nil)
+		if err != nil {
+			return fmt.Errorf("failed to collect releases: %w", err)
+		}
+		if len(releases) == 0 {
+			ui.ShowWarningMessage("Không có version tag hoặc commit nào để tạo changelog")
+			return fmt.Errorf("no releases found")
+		}
+
+		renderer := changelog.NewRenderer(config)
+		if output == "-" {
+			fmt.Print(renderer.Document(releases))
+			return nil
+		}
+
+		if err := updateChangelog(output, renderer, releases, full); err != nil {
+			return err
+		}
+		ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã cập nhật %s", output))
+		return nil
+	},
+}
+
+// updateChangelog writes releases to the changelog file at path, prepending
+// the releases it does not contain yet unless full regeneration is requested
+func updateChangelog(path string, renderer *changelog.Renderer, releases []changelog.Release, full bool) error {
+	var existing string
+	if !full {
+		content, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read changelog: %w", err)
+		}
+		existing = string(content)
+	}
+
+	if err := os.WriteFile(path, []byte(renderer.Prepend(existing, releases)), 0644); err != nil {
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	changelogCmd.Flags().StringP("output", "o", "", "Changelog file, or - for stdout (default: changelog.file in config)")
+	changelogCmd.Flags().String("format", "", "Changelog format: keepachangelog or conventional (default: changelog.format in config)")
+	changelogCmd.Flags().Bool("authors", false, "Credit commit authors (default: changelog.authors in config)")
+	changelogCmd.Flags().Bool("full", false, "Regenerate the whole changelog instead of prepending new releases")
+
+	rootCmd.AddCommand(changelogCmd)
+}
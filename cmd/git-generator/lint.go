@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nguyendkn/git-generator/internal/style"
+	"github.com/nguyendkn/git-generator/internal/ui"
+	"github.com/nguyendkn/git-generator/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [range]",
+	Short: "Check existing commit messages against the commit rules",
+	Long: `Parse commit messages and run every validation rule on them: subject
+length, trailing period, imperative mood, Conventional Commits format and
+allowed types, blank line after the subject, body line length and
+BREAKING CHANGE footers, plus the rules configured in the validation
+section of the configuration: forbidden words, ticket references, bodies
+required for some types, allowed scopes and custom regex rules.
+
+Diagnostics are printed as <commit>:<line>:<column>: <severity>: <message> [<rule>],
+or as JSON or SARIF with --format for editors and code-scanning dashboards. Each
+rule has a stable ID (GG101 to GG502) in these reports. The command exits
+with a non-zero code when errors are found (or warnings, with --strict), so
+it can run in CI or in a commit-msg hook:
+
+  git-generator lint                     # commits of the current branch
+  git-generator lint origin/main..HEAD   # an explicit range
+  git-generator lint --file "$1"         # in .git/hooks/commit-msg
+  echo "feat: add login" | git-generator lint --stdin
+  git-generator lint --format sarif > commits.sarif
+
+Merge, revert, fixup! and squash! commits are skipped. Without a range, the
+commits of the current branch since its merge base with the base branch are
+checked. Ranges accept A..B, A...B and A (for A..HEAD).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		stdin, _ := cmd.Flags().GetBool("stdin")
+		base, _ := cmd.Flags().GetString("base")
+		strict, _ := cmd.Flags().GetBool("strict")
+		format, err := validation.ParseFormat(outputFormat(cmd))
+		if err != nil {
+			return err
+		}
+		out := reportWriter(cmd, format)
+
+		sources := 0
+		for _, set := range []bool{len(args) == 1, file != "", stdin} {
+			if set {
+				sources++
+			}
+		}
+		if sources > 1 {
+			return fmt.Errorf("a range, --file and --stdin cannot be combined")
+		}
+
+		messages, err := lintMessages(args, file, stdin, base)
+		if err != nil {
+			return err
+		}
+		// Lint failures are not usage errors
+		cmd.SilenceUsage = true
+
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
+		// Rules are read from the repository root; messages can be linted outside a repository
+		rulesDir := "."
+		if root, err := gitService.GetRepositoryRoot(); err == nil {
+			rulesDir = root
+		}
+		config, source, err := validation.LoadConfig(rulesDir, appConfig.Output.MaxSubjectLength, appConfig.Output.Language)
+		if err != nil {
+			return err
+		}
+		if source != "" {
+			ui.ShowInfoMessage(fmt.Sprintf("Dùng quy tắc commitlint từ %s", source))
+		}
+		var files []string
+		if appConfig.Validation.DetectedScopes {
+			if files, err = gitService.ListFiles(); err != nil {
+				return err
+			}
+		}
+		styles, err := style.Builtin(appConfig.Output.Template)
+		if err != nil {
+			return fmt.Errorf("invalid output template: %w", err)
+		}
+		selected, err := styles.Lookup(appConfig.Output.Style)
+		if err != nil {
+			return err
+		}
+		selected.Configure(&config)
+		if err := config.ApplyRules(appConfig.Validation, files); err != nil {
+			return fmt.Errorf("invalid validation rules: %w", err)
+		}
+		config.RequireConventional = selected.Info().Conventional &&
+			config.Severity("conventional_format", validation.SeverityError) != validation.SeverityOff
+
+		validator := validation.NewValidator(config)
+		report := validation.NewReport(version)
+		for _, message := range messages {
+			if validation.IsIgnored(message.text) {
+				continue
+			}
+			report.Add(message.source, message.commit, message.text, validator.Lint(message.text))
+		}
+		if err := report.Write(out, format); err != nil {
+			return err
+		}
+
+		errorCount, warningCount, checked := report.Errors, report.Warnings, len(report.Messages)
+		if errorCount > 0 || (strict && warningCount > 0) {
+			ui.ShowErrorMessage(fmt.Sprintf("❌ %d lỗi, %d cảnh báo trong %d commit message", errorCount, warningCount, checked))
+			return fmt.Errorf("commit message lint failed")
+		}
+		if warningCount > 0 {
+			ui.ShowWarningMessage(fmt.Sprintf("⚠️ %d cảnh báo trong %d commit message", warningCount, checked))
+			return nil
+		}
+		ui.ShowSuccessMessage(fmt.Sprintf("✅ %d commit message hợp lệ", checked))
+		return nil
+	},
+}
+
+// lintMessage is a commit message to lint and where it comes from
+type lintMessage struct {
+	source string // Short commit hash, file path or "stdin"
+	commit string // Full commit hash, empty for files and stdin
+	text   string
+}
+
+// lintMessages reads the messages selected by the lint arguments
+func lintMessages(args []string, file string, stdin bool, base string) ([]lintMessage, error) {
+	switch {
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit message file: %w", err)
+		}
+		return []lintMessage{{source: file, text: string(content)}}, nil
+	case stdin:
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit message from stdin: %w", err)
+		}
+		return []lintMessage{{source: "stdin", text: string(content)}}, nil
+	}
+
+	gitService, err := newGitService()
+	if err != nil {
+		return nil, err
+	}
+	if !gitService.IsGitRepository() {
+		ui.ShowErrorMessage("Không phải Git repository")
+		return nil, fmt.Errorf("not in a Git repository")
+	}
+
+	var from, to string
+	if len(args) == 1 {
+		from, to, err = gitService.ResolveRange(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve range: %w", err)
+		}
+	} else {
+		if base == "" {
+			if base, err = gitService.DetectBaseBranch(); err != nil {
+				return nil, err
+			}
+		}
+		if from, err = gitService.GetMergeBase(base, "HEAD"); err != nil {
+			return nil, err
+		}
+		to = "HEAD"
+	}
+
+	commits, err := gitService.GetCommitsInRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	messages := make([]lintMessage, 0, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- { // Oldest first
+		text, err := gitService.GetCommitMessage(commits[i].Hash)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, lintMessage{source: commits[i].Hash[:7], commit: commits[i].Hash, text: text})
+	}
+	return messages, nil
+}
+
+func init() {
+	lintCmd.Flags().StringP("file", "f", "", "Lint the message in a file, e.g. the argument of a commit-msg hook")
+	lintCmd.Flags().Bool("stdin", false, "Lint a message read from standard input")
+	lintCmd.Flags().StringP("base", "b", "", "Base branch when no range is given (default: main, master or develop)")
+	lintCmd.Flags().Bool("strict", false, "Fail on warnings as well as errors")
+	lintCmd.Flags().String("format", validation.FormatText, "Output format: text, json or sarif")
+
+	rootCmd.AddCommand(lintCmd)
+}
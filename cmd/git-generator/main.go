@@ -2,18 +2,27 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/nguyendkn/git-generator/internal/ai"
+	"github.com/nguyendkn/git-generator/internal/apicompat"
+	"github.com/nguyendkn/git-generator/internal/changelog"
 	"github.com/nguyendkn/git-generator/internal/config"
 	"github.com/nguyendkn/git-generator/internal/diff"
 	"github.com/nguyendkn/git-generator/internal/generator"
 	"github.com/nguyendkn/git-generator/internal/git"
 	interfaces "github.com/nguyendkn/git-generator/internal/interface"
+	"github.com/nguyendkn/git-generator/internal/module"
 	"github.com/nguyendkn/git-generator/internal/ui"
+	"github.com/nguyendkn/git-generator/internal/validation"
 	versioning "github.com/nguyendkn/git-generator/internal/version"
+	"github.com/nguyendkn/git-generator/internal/versionfile"
 	"github.com/nguyendkn/git-generator/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -30,8 +39,10 @@ var (
 )
 
 func main() {
-	// Show banner when running without subcommands or with generate command
-	if len(os.Args) == 1 || (len(os.Args) > 1 && (os.Args[1] == "generate" || os.Args[1] == "gen" || os.Args[1] == "g")) {
+	// Show banner when running without subcommands or with generate command,
+	// unless generate writes a machine-readable report
+	isGenerate := len(os.Args) > 1 && (os.Args[1] == "generate" || os.Args[1] == "gen" || os.Args[1] == "g")
+	if len(os.Args) == 1 || (isGenerate && !isReportFormat(requestedFormat(os.Args[1:]))) {
 		ui.ShowBanner(version)
 		ui.ShowWelcomeMessage()
 	}
@@ -73,6 +84,9 @@ that follow best practices.`,
 
 		// Initialize interface manager for generate and interactive commands
 		if cmd.Name() == "generate" || cmd.Name() == "interactive" {
+			if err := requireAPIKey(); err != nil {
+				return err
+			}
 			interfaceMgr, err = interfaces.NewManager(appConfig, cfgManager, version)
 			if err != nil {
 				return fmt.Errorf("failed to initialize interface manager: %w", err)
@@ -83,6 +97,75 @@ that follow best practices.`,
 	},
 }
 
+// newGitService creates a Git service using the configured backend
+func newGitService() (*git.Service, error) {
+	gitService, err := git.NewServiceFromConfig(".", appConfig.Git)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize git service: %w", err)
+	}
+	return gitService, nil
+}
+
+// requestedFormat returns the value of the --format flag in the command line
+func requestedFormat(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, found := strings.CutPrefix(arg, "--format="); found {
+			return value
+		}
+		if arg == "--format" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// isReportFormat reports whether a --format value is a machine-readable report
+func isReportFormat(format string) bool {
+	return format != "" && format != validation.FormatText
+}
+
+// reportWriter returns the writer of the report of a command. Machine-readable
+// reports are the only output on standard output, messages go to standard error.
+func reportWriter(cmd *cobra.Command, format string) io.Writer {
+	if isReportFormat(format) {
+		ui.SetOutput(cmd.ErrOrStderr())
+	}
+	return cmd.OutOrStdout()
+}
+
+// outputFormat returns the --format flag of a command
+func outputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	return format
+}
+
+// requireAPIKey explains how to configure the Gemini API key when it is missing
+func requireAPIKey() error {
+	if appConfig.Gemini.APIKey == "" {
+		ui.ShowErrorMessage("Cần cấu hình Google Gemini API key để sử dụng tính năng này")
+		ui.ShowInfoMessage("Chạy 'git-generator init' hoặc đặt biến môi trường GEMINI_API_KEY")
+		return fmt.Errorf("gemini API key is required (set GEMINI_API_KEY environment variable or add to config file)")
+	}
+	return nil
+}
+
+// newAIClient creates a Gemini client, requiring a configured API key
+func newAIClient() (*ai.GeminiClient, error) {
+	if err := requireAPIKey(); err != nil {
+		return nil, err
+	}
+
+	aiClient, err := ai.NewGeminiClient(appConfig.Gemini)
+	if err != nil {
+		ui.ShowErrorMessage(fmt.Sprintf("Lỗi khởi tạo AI client: %v", err))
+		return nil, fmt.Errorf("failed to initialize AI client: %w", err)
+	}
+	return aiClient, nil
+}
+
 func init() {
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(interactiveCmd)
@@ -99,7 +182,11 @@ var generateCmd = &cobra.Command{
 	Short: "Generate a commit message for staged changes",
 	Long: `Generate an AI-powered commit message based on your staged changes.
 The tool analyzes your git diff and creates a conventional commit message.
-By default, it will automatically stage all changes (git add .) before generating the commit message.`,
+By default, it will automatically stage all changes (git add .) before generating the commit message.
+
+Use --range or --against to describe existing commits (for example a squash merge or a
+whole feature branch), or --include-untracked to preview unstaged work. These modes only
+show the generated message and never modify the index.`,
 	Aliases: []string{"gen", "g"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
@@ -108,11 +195,38 @@ By default, it will automatically stage all changes (git add .) before generatin
 		staged, _ := cmd.Flags().GetBool("staged")
 		multiple, _ := cmd.Flags().GetBool("multiple")
 		noAdd, _ := cmd.Flags().GetBool("no-add")
+		revRange, _ := cmd.Flags().GetString("range")
+		against, _ := cmd.Flags().GetString("against")
+		includeUntracked, _ := cmd.Flags().GetBool("include-untracked")
+		closes, _ := cmd.Flags().GetBool("closes")
+		signOff, _ := cmd.Flags().GetBool("signoff")
+		coAuthors, _ := cmd.Flags().GetStringSlice("co-author")
+		trailers, _ := cmd.Flags().GetStringArray("trailer")
 		verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
+		format, err := validation.ParseFormat(outputFormat(cmd))
+		if err != nil {
+			return err
+		}
+		if multiple && format != validation.FormatText {
+			return fmt.Errorf("--multiple cannot be combined with --format %s", format)
+		}
+		out := reportWriter(cmd, format)
+
+		// Ranges, branch comparisons and untracked previews never touch the index
+		previewOnly := revRange != "" || against != "" || includeUntracked
+		if previewOnly {
+			dryRun = true
+			if includeUntracked && revRange == "" && against == "" {
+				staged = false
+			}
+		}
 
 		// Auto-stage changes unless --no-add is specified
 		if !noAdd && !dryRun {
-			gitService := git.NewService(".")
+			gitService, err := newGitService()
+			if err != nil {
+				return err
+			}
 			if !gitService.IsGitRepository() {
 				ui.ShowErrorMessage("Không phải trong Git repository")
 				return fmt.Errorf("not in a Git repository")
@@ -145,18 +259,27 @@ By default, it will automatically stage all changes (git add .) before generatin
 			if noAdd {
 				ui.ShowInfoMessage("⏭️  Bỏ qua auto-staging (--no-add được chỉ định)")
 			}
-			if dryRun {
+			if previewOnly {
+				ui.ShowInfoMessage("👁️  Chỉ xem trước thay đổi, bỏ qua auto-staging")
+			} else if dryRun {
 				ui.ShowInfoMessage("👁️  Chế độ dry-run, bỏ qua auto-staging")
 			}
 		}
 
 		// Create generate request
 		req := interfaces.GenerateRequest{
-			Style:    style,
-			DryRun:   dryRun,
-			Staged:   staged,
-			Multiple: multiple,
-			Mode:     interfaces.ModeCLI,
+			Style:            style,
+			DryRun:           dryRun,
+			Staged:           staged,
+			Multiple:         multiple,
+			Mode:             interfaces.ModeCLI,
+			Range:            revRange,
+			Against:          against,
+			IncludeUntracked: includeUntracked,
+			Closes:           closes,
+			SignOff:          signOff,
+			CoAuthors:        coAuthors,
+			Trailers:         trailers,
 		}
 
 		// Use interface manager
@@ -167,7 +290,14 @@ By default, it will automatically stage all changes (git add .) before generatin
 		}
 
 		// Display result
-		if dryRun {
+		if format != validation.FormatText {
+			return writeGenerateReport(out, result, format)
+		}
+		if previewOnly {
+			diffOptions := git.DiffOptions{Range: revRange, Against: against, IncludeUntracked: includeUntracked}
+			ui.ShowInfoMessage(fmt.Sprintf("Xem trước commit message cho %s (không commit):", diffOptions.Description()))
+			fmt.Println(result.Preview)
+		} else if dryRun {
 			ui.ShowInfoMessage("Xem trước (chế độ dry-run):")
 			fmt.Println(result.Preview)
 		} else {
@@ -184,6 +314,36 @@ By default, it will automatically stage all changes (git add .) before generatin
 	},
 }
 
+// generateReport is the JSON output of generate: the validation report of the
+// generated message and whether it was committed
+type generateReport struct {
+	*validation.Report
+	Applied        bool `json:"applied"`
+	RepairAttempts int  `json:"repair_attempts"`
+}
+
+// writeGenerateReport writes the validation report of a generated message
+func writeGenerateReport(w io.Writer, result *generator.GenerateResult, format string) error {
+	messageText := result.CommitMessage.FormattedMessage
+	if messageText == "" {
+		messageText = result.CommitMessage.String()
+	}
+
+	report := validation.NewReport(version)
+	report.Add("generated", "", messageText, result.Diagnostics).Fixes = result.Fixes
+	if format == validation.FormatSARIF {
+		return report.Write(w, format)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generateReport{Report: report, Applied: result.Applied, RepairAttempts: result.RepairAttempts}); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
 var interactiveCmd = &cobra.Command{
 	Use:   "interactive",
 	Short: "Chế độ tương tác để cấu hình và tạo commit message",
@@ -196,7 +356,10 @@ Tự động stage tất cả thay đổi trước khi tạo commit message.`,
 		verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
 
 		// Auto-stage changes in interactive mode
-		gitService := git.NewService(".")
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
 		if !gitService.IsGitRepository() {
 			ui.ShowErrorMessage("Không phải trong Git repository")
 			return fmt.Errorf("not in a Git repository")
@@ -235,6 +398,10 @@ Tự động stage tất cả thay đổi trước khi tạo commit message.`,
 		// Use interface manager
 		ctx := context.Background()
 		result, err := interfaceMgr.Generate(ctx, req)
+		if errors.Is(err, interfaces.ErrAborted) {
+			ui.ShowWarningMessage("Đã hủy, không có commit nào được tạo")
+			return nil
+		}
 		if err != nil {
 			ui.ShowErrorMessage(fmt.Sprintf("Lỗi trong chế độ tương tác: %v", err))
 			return err
@@ -242,12 +409,12 @@ Tự động stage tất cả thay đổi trước khi tạo commit message.`,
 
 		// Display result
 		ui.PrintHeader("Kết quả")
-		if req.DryRun {
+		if result.Applied {
+			ui.ShowSuccessMessage("Commit message đã được tạo và áp dụng:")
+			fmt.Println(result.CommitMessage.FormattedMessage)
+		} else {
 			ui.ShowInfoMessage("Xem trước (chế độ dry-run):")
 			fmt.Println(result.Preview)
-		} else {
-			ui.ShowSuccessMessage("Commit message đã được tạo và áp dụng:")
-			fmt.Println(result.CommitMessage.String())
 		}
 
 		return nil
@@ -271,11 +438,20 @@ var versionCmd = &cobra.Command{
 }
 
 func init() {
-	generateCmd.Flags().StringP("style", "s", "conventional", "Commit message style (conventional, simple, detailed)")
+	generateCmd.Flags().StringP("style", "s", "", "Commit message style (conventional, gitmoji, angular, traditional, minimal, template; default: output.style)")
 	generateCmd.Flags().BoolP("dry-run", "d", false, "Preview the commit message without applying it")
 	generateCmd.Flags().BoolP("staged", "S", true, "Use staged changes (default: true)")
 	generateCmd.Flags().BoolP("multiple", "m", false, "Generate multiple commit message options")
 	generateCmd.Flags().Bool("no-add", false, "Skip automatic staging of changes (git add .)")
+	generateCmd.Flags().String("range", "", "Describe a revision range instead of staged changes (A..B, A...B or A for A..HEAD)")
+	generateCmd.Flags().String("against", "", "Describe the current branch against <branch> from their merge base")
+	generateCmd.Flags().Bool("include-untracked", false, "Preview working tree changes including untracked files without staging")
+	generateCmd.Flags().Bool("closes", false, "Reference issues from the branch name with Closes instead of the configured trailer")
+	generateCmd.Flags().Bool("signoff", false, "Add a Signed-off-by trailer with your git identity")
+	generateCmd.Flags().StringSlice("co-author", nil, "Add Co-authored-by trailers (team roster alias or \"Name <email>\")")
+	generateCmd.Flags().StringArray("trailer", nil, "Add a custom trailer (\"Token: value\"), may be repeated")
+	generateCmd.MarkFlagsMutuallyExclusive("range", "against")
+	generateCmd.Flags().String("format", validation.FormatText, "Output format: text, json or sarif (validation report of the generated message)")
 	generateCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output for debugging")
 
 	interactiveCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output for debugging")
@@ -366,7 +542,10 @@ var statusCmd = &cobra.Command{
 	Short: "Hiển thị trạng thái repository và tóm tắt thay đổi",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
-		gitService := git.NewService(".")
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
 
 		if !gitService.IsGitRepository() {
 			ui.ShowErrorMessage("Không phải trong Git repository")
@@ -469,14 +648,83 @@ func init() {
 	modeCmd.AddCommand(modeListCmd)
 }
 
+// printVersionAnalysis shows a version analysis and the commits that drove it
+func printVersionAnalysis(title string, analysis *types.VersionAnalysis) {
+	ui.PrintSubHeader(title)
+	if analysis.BaseTag != "" {
+		fmt.Printf("  %s• Từ tag: %s%s%s\n", ui.ColorBlue, ui.ColorCyan, analysis.BaseTag, ui.ColorReset)
+	}
+	fmt.Printf("  %s• Đề xuất: %s%s%s version bump\n",
+		ui.ColorBlue, ui.ColorCyan, strings.ToUpper(string(analysis.RecommendedBump)), ui.ColorReset)
+	fmt.Printf("  %s• Độ tin cậy: %s%.1f%%%s\n",
+		ui.ColorBlue, ui.ColorYellow, analysis.Confidence*100, ui.ColorReset)
+
+	if analysis.Reasoning != "" {
+		fmt.Printf("  %s• Lý do: %s%s%s\n",
+			ui.ColorBlue, ui.ColorWhite, analysis.Reasoning, ui.ColorReset)
+	}
+
+	// Show change details
+	if len(analysis.BreakingChanges) > 0 {
+		fmt.Printf("  %s• Breaking changes: %s%d%s\n",
+			ui.ColorRed, ui.ColorYellow, len(analysis.BreakingChanges), ui.ColorReset)
+	}
+	if len(analysis.NewFeatures) > 0 {
+		fmt.Printf("  %s• Features mới: %s%d%s\n",
+			ui.ColorGreen, ui.ColorYellow, len(analysis.NewFeatures), ui.ColorReset)
+	}
+	if len(analysis.BugFixes) > 0 {
+		fmt.Printf("  %s• Bug fixes: %s%d%s\n",
+			ui.ColorBlue, ui.ColorYellow, len(analysis.BugFixes), ui.ColorReset)
+	}
+
+	// Show incompatible changes to the exported Go API
+	if incompatible := apicompat.Incompatible(analysis.APIChanges); len(incompatible) > 0 {
+		fmt.Printf("  %s• Thay đổi API không tương thích:%s\n", ui.ColorRed, ui.ColorReset)
+		for _, change := range incompatible {
+			fmt.Printf("    %s%s%s %s\n", ui.ColorYellow, change.Package, ui.ColorReset, describeAPIChange(change))
+		}
+	}
+
+	// Show the commits that drove the decision
+	if len(analysis.Commits) > 0 {
+		fmt.Printf("  %s• Commits quyết định:%s\n", ui.ColorBlue, ui.ColorReset)
+		for _, commit := range analysis.Commits {
+			hash := commit.Hash
+			if len(hash) > 7 {
+				hash = hash[:7]
+			}
+			fmt.Printf("    %s%s%s %-5s %s (%s)\n",
+				ui.ColorYellow, hash, ui.ColorReset, commit.Bump, commit.Subject, commit.Reason)
+		}
+	}
+}
+
+// describeAPIChange shows an API change with its declarations before and after
+func describeAPIChange(change types.APIChange) string {
+	description := fmt.Sprintf("%s %s", change.Kind, change.Name)
+	if change.Kind == types.APIChangeChanged {
+		description += fmt.Sprintf(": %s → %s", change.Before, change.After)
+	}
+	return description
+}
+
 var tagCmd = &cobra.Command{
 	Use:   "tag",
 	Short: "Tạo semantic version tag tự động",
 	Long: `Phân tích thay đổi và tạo semantic version tag tự động dựa trên:
-- Conventional commits
-- Phân tích diff với AI
-- Lịch sử commit gần đây
-- Quy tắc semantic versioning (semver)`,
+- Conventional commits từ tag gần nhất: feat → minor, fix/perf → patch, ! hoặc BREAKING CHANGE → major
+- Quy tắc 0.x: breaking change chỉ tăng minor cho đến khi phát hành 1.0.0
+- Phân tích diff với AI như ý kiến thứ hai (--ai, tùy chọn)
+- Kiểm tra API Go đã export so với tag gần nhất: xoá hoặc đổi identifier → major
+- Quy tắc semantic versioning (semver 2.0)
+
+Trong monorepo, module được phát hiện từ go.work, go.mod, package.json workspaces
+và modules trong config. --module chỉ phân tích commits thay đổi module đó và dùng
+tag prefix của nó (api/v1.4.0, @scope/pkg@2.0.0); --all liệt kê module cần release.
+
+Pre-release tiếp tục trên cùng version khi có thể: v1.2.0-beta.1 → v1.2.0-beta.2,
+beta → rc (v1.2.0-rc.1), và --promote phát hành v1.2.0-rc.1 thành v1.2.0.`,
 	Aliases: []string{"v"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
@@ -485,25 +733,36 @@ var tagCmd = &cobra.Command{
 		preRelease, _ := cmd.Flags().GetString("pre-release")
 		message, _ := cmd.Flags().GetString("message")
 		push, _ := cmd.Flags().GetBool("push")
+		remote, _ := cmd.Flags().GetString("remote")
+		tagOnly, _ := cmd.Flags().GetBool("tag-only")
 		annotated, _ := cmd.Flags().GetBool("annotated")
+		useAI, _ := cmd.Flags().GetBool("ai")
+		withChangelog, _ := cmd.Flags().GetBool("changelog")
+		promote, _ := cmd.Flags().GetBool("promote")
+		build, _ := cmd.Flags().GetString("build")
+		noVersionFiles, _ := cmd.Flags().GetBool("no-version-files")
+		moduleName, _ := cmd.Flags().GetString("module")
+		allModules, _ := cmd.Flags().GetBool("all")
+		noAPICheck, _ := cmd.Flags().GetBool("no-api-check")
+
+		if remote == "" {
+			remote = appConfig.Git.Remote
+		}
 
 		// Initialize services
-		gitService := git.NewService(".")
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
 		diffProcessor := diff.NewProcessor(appConfig.Git.MaxDiffSize, 20)
 
-		// Initialize AI client if API key is available
+		// The AI is only used for an optional second opinion
 		var aiClient *ai.GeminiClient
-		if appConfig.Gemini.APIKey != "" {
-			var err error
-			aiClient, err = ai.NewGeminiClient(appConfig.Gemini)
-			if err != nil {
-				ui.ShowErrorMessage(fmt.Sprintf("Lỗi khởi tạo AI client: %v", err))
-				return fmt.Errorf("failed to initialize AI client: %w", err)
+		if useAI {
+			if aiClient, err = newAIClient(); err != nil {
+				return err
 			}
-		} else {
-			ui.ShowErrorMessage("Cần cấu hình Google Gemini API key để sử dụng tính năng này")
-			ui.ShowInfoMessage("Chạy 'git-generator init' để cấu hình API key")
-			return fmt.Errorf("gemini API key is required for version analysis")
+			defer aiClient.Close()
 		}
 
 		// Initialize version service
@@ -513,6 +772,26 @@ var tagCmd = &cobra.Command{
 		ui.ShowBanner(version)
 		ui.PrintHeader("🏷️  Semantic Version Tagging")
 
+		// In a monorepo, plan the releases of all modules or release a single one
+		if allModules {
+			return printModulePlan(gitService, !noAPICheck)
+		}
+		// A module release updates the files of the module, not those of the repository
+		versionFiles, changelogFile := appConfig.VersionFiles, appConfig.Changelog.File
+		if moduleName != "" {
+			selected, err := selectModule(gitService, versionService, moduleName)
+			if err != nil {
+				return err
+			}
+			versionFiles, changelogFile = module.ReleaseFiles(selected, changelogFile)
+		}
+		// Release files are relative to the repository root, not the working directory
+		root, err := gitService.GetRepositoryRoot()
+		if err != nil {
+			ui.ShowErrorMessage(fmt.Sprintf("Lỗi xác định thư mục gốc repository: %v", err))
+			return err
+		}
+
 		// Validate repository state
 		if err := versionService.ValidateRepositoryState(); err != nil {
 			ui.ShowErrorMessage(fmt.Sprintf("Lỗi trạng thái repository: %v", err))
@@ -528,50 +807,56 @@ var tagCmd = &cobra.Command{
 			return err
 		}
 
-		ui.ShowInfoMessage(fmt.Sprintf("📊 Version hiện tại: %s", currentVersion.TagName()))
+		ui.ShowInfoMessage(fmt.Sprintf("📊 Version hiện tại: %s", versionService.TagName(currentVersion)))
 
-		// Analyze changes for versioning
-		ui.ShowInfoMessage("🤖 Đang phân tích thay đổi để xác định version bump...")
+		// Analyze conventional commits since the latest tag
+		ui.ShowInfoMessage("🔍 Đang phân tích conventional commits từ tag gần nhất...")
 
 		ctx := context.Background()
-		analysis, err := versionService.AnalyzeChangesForVersioning(ctx, true)
+		analysis, err := versionService.AnalyzeCommitsSinceLatestTag()
 		if err != nil {
-			ui.ShowErrorMessage(fmt.Sprintf("Lỗi phân tích thay đổi: %v", err))
+			ui.ShowErrorMessage(fmt.Sprintf("Lỗi phân tích commits: %v", err))
 			return err
 		}
 
-		// Display analysis results
-		ui.PrintSubHeader("Kết quả phân tích AI")
-		fmt.Printf("  %s• Đề xuất: %s%s%s version bump\n",
-			ui.ColorBlue, ui.ColorCyan, strings.ToUpper(string(analysis.RecommendedBump)), ui.ColorReset)
-		fmt.Printf("  %s• Độ tin cậy: %s%.1f%%%s\n",
-			ui.ColorBlue, ui.ColorYellow, analysis.Confidence*100, ui.ColorReset)
-
-		if analysis.Reasoning != "" {
-			fmt.Printf("  %s• Lý do: %s%s%s\n",
-				ui.ColorBlue, ui.ColorWhite, analysis.Reasoning, ui.ColorReset)
+		// Incompatible changes to the exported Go API require a major release
+		if !noAPICheck {
+			applyAPICheck(versionService, analysis, currentVersion)
 		}
 
-		// Show change details
-		if len(analysis.BreakingChanges) > 0 {
-			fmt.Printf("  %s• Breaking changes: %s%d%s\n",
-				ui.ColorRed, ui.ColorYellow, len(analysis.BreakingChanges), ui.ColorReset)
-		}
-		if len(analysis.NewFeatures) > 0 {
-			fmt.Printf("  %s• Features mới: %s%d%s\n",
-				ui.ColorGreen, ui.ColorYellow, len(analysis.NewFeatures), ui.ColorReset)
+		printVersionAnalysis("Kết quả phân tích conventional commits", analysis)
+
+		// Ask the AI for a second opinion; failures do not block tagging
+		if useAI {
+			ui.ShowInfoMessage("🤖 Đang lấy ý kiến thứ hai từ AI...")
+			aiAnalysis, err := versionService.AnalyzeWithAI(ctx, analysis.BaseTag)
+			if err != nil {
+				ui.ShowWarningMessage(fmt.Sprintf("Không thể phân tích với AI: %v", err))
+			} else {
+				printVersionAnalysis("Ý kiến thứ hai từ AI", aiAnalysis)
+				if aiAnalysis.RecommendedBump != analysis.RecommendedBump {
+					ui.ShowWarningMessage(fmt.Sprintf("AI đề xuất %s nhưng conventional commits yêu cầu %s; dùng --type để chọn khác",
+						strings.ToUpper(string(aiAnalysis.RecommendedBump)), strings.ToUpper(string(analysis.RecommendedBump))))
+				}
+			}
 		}
-		if len(analysis.BugFixes) > 0 {
-			fmt.Printf("  %s• Bug fixes: %s%d%s\n",
-				ui.ColorBlue, ui.ColorYellow, len(analysis.BugFixes), ui.ColorReset)
+
+		if analysis.RecommendedBump == types.VersionBumpNone && forceBump == "" && !promote {
+			ui.ShowInfoMessage("ℹ️  Không có thay đổi cần release (feat, fix, perf hoặc breaking change). Dùng --type để ép tạo tag")
+			return nil
 		}
 
 		// Create tagging options
 		options := types.TaggingOptions{
-			DryRun:    dryRun,
-			Message:   message,
-			Push:      push,
-			Annotated: annotated,
+			DryRun:     dryRun,
+			Message:    message,
+			Push:       push,
+			Remote:     remote,
+			PushBranch: !tagOnly,
+			Annotated:  annotated,
+			Promote:    promote,
+			Build:      build,
+			APIChanges: apicompat.Incompatible(analysis.APIChanges),
 		}
 
 		// Handle force bump type
@@ -589,46 +874,135 @@ var tagCmd = &cobra.Command{
 			}
 		}
 
-		// Handle pre-release
+		// Handle pre-release; any SemVer identifiers are accepted, alpha < beta < rc by precedence
 		if preRelease != "" {
-			switch strings.ToLower(preRelease) {
-			case "alpha":
-				options.PreRelease = types.PreReleaseAlpha
-			case "beta":
-				options.PreRelease = types.PreReleaseBeta
-			case "rc":
-				options.PreRelease = types.PreReleaseRC
-			default:
-				ui.ShowErrorMessage(fmt.Sprintf("Loại pre-release không hợp lệ: %s (chỉ chấp nhận: alpha, beta, rc)", preRelease))
-				return fmt.Errorf("invalid pre-release type: %s", preRelease)
+			if err := types.ValidatePreRelease(preRelease); err != nil {
+				ui.ShowErrorMessage(fmt.Sprintf("Pre-release không hợp lệ: %v", err))
+				return err
+			}
+			options.PreRelease = types.PreReleaseType(preRelease)
+		}
+		if build != "" {
+			if err := types.ValidateBuild(build); err != nil {
+				ui.ShowErrorMessage(fmt.Sprintf("Build metadata không hợp lệ: %v", err))
+				return err
 			}
 		}
 
 		// Calculate next version
-		nextVersion := versionService.CalculateNextVersion(currentVersion, analysis, options)
+		nextVersion, err := versionService.CalculateNextVersion(currentVersion, analysis, options)
+		if err != nil {
+			ui.ShowErrorMessage(fmt.Sprintf("Lỗi tính version mới: %v", err))
+			return err
+		}
+
+		nextTag := versionService.TagName(nextVersion)
 
 		ui.PrintSubHeader("Version mới")
 		fmt.Printf("  %s%s → %s%s\n",
-			ui.ColorYellow, currentVersion.TagName(), nextVersion.TagName(), ui.ColorReset)
+			ui.ColorYellow, versionService.TagName(currentVersion), nextTag, ui.ColorReset)
+
+		// Collect the release notes for the new version before tagging
+		var releases []changelog.Release
+		renderer := changelog.NewRenderer(appConfig.Changelog)
+		if withChangelog {
+			if releases, err = changelog.NewService(gitService, versionService).Releases(nextVersion); err != nil {
+				ui.ShowErrorMessage(fmt.Sprintf("Lỗi tạo changelog: %v", err))
+				return err
+			}
+		}
+
+		// Plan the version bumps of project files such as package.json
+		var versionChanges []versionfile.Change
+		if !noVersionFiles && len(versionFiles) > 0 {
+			if versionChanges, err = versionfile.Plan(root, versionFiles, nextVersion.String()); err != nil {
+				ui.ShowErrorMessage(fmt.Sprintf("Lỗi cập nhật version files: %v", err))
+				return err
+			}
+		}
 
 		if dryRun {
 			ui.ShowInfoMessage("🔍 Chế độ dry-run: Không tạo tag thực tế")
+			if withChangelog {
+				ui.ShowInfoMessage(fmt.Sprintf("📝 Sẽ thêm vào %s:", changelogFile))
+				fmt.Print(renderer.RenderRelease(releases[0]))
+			}
+			if len(versionChanges) > 0 {
+				ui.ShowInfoMessage(fmt.Sprintf("📝 Sẽ cập nhật %d version files:", len(versionChanges)))
+				for _, change := range versionChanges {
+					fmt.Print(change.Diff())
+				}
+			}
+			if options.Push {
+				ui.ShowInfoMessage(fmt.Sprintf("📤 Sẽ push tag %s lên remote '%s'", nextTag, remote))
+			}
 			return nil
 		}
 
+		// Commit the changelog and version files so the tag points at the release commit
+		if withChangelog || len(versionChanges) > 0 {
+			var written []string
+			if withChangelog {
+				if err := updateChangelog(filepath.Join(root, changelogFile), renderer, releases, false); err != nil {
+					ui.ShowErrorMessage(fmt.Sprintf("Lỗi cập nhật changelog: %v", err))
+					return err
+				}
+				ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã cập nhật %s", changelogFile))
+				written = append(written, changelogFile)
+			}
+			if err := versionfile.Write(root, versionChanges); err != nil {
+				ui.ShowErrorMessage(fmt.Sprintf("Lỗi cập nhật version files: %v", err))
+				return err
+			}
+			for _, change := range versionChanges {
+				ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã cập nhật version trong %s", change.Path))
+				written = append(written, change.Path)
+			}
+
+			// Stage only the release files, which may be outside the working directory
+			if err := gitService.Add(written...); err != nil {
+				return fmt.Errorf("failed to stage release files: %w", err)
+			}
+			if err := gitService.Commit("chore(release): " + nextTag); err != nil {
+				return fmt.Errorf("failed to create release commit: %w", err)
+			}
+			ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã tạo commit chore(release): %s", nextTag))
+		}
+
 		// Create the tag
-		ui.ShowInfoMessage(fmt.Sprintf("🏷️  Đang tạo tag %s...", nextVersion.TagName()))
+		ui.ShowInfoMessage(fmt.Sprintf("🏷️  Đang tạo tag %s...", nextTag))
 
 		if err := versionService.CreateTag(ctx, nextVersion, options); err != nil {
 			ui.ShowErrorMessage(fmt.Sprintf("Lỗi tạo tag: %v", err))
 			return err
 		}
 
-		ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã tạo tag %s thành công!", nextVersion.TagName()))
+		ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã tạo tag %s thành công!", nextTag))
 
 		if options.Push {
-			ui.ShowInfoMessage("📤 Đang push tag lên remote...")
-			// TODO: Implement push functionality
+			if options.PushBranch {
+				ui.ShowInfoMessage(fmt.Sprintf("📤 Đang push branch và tag %s lên remote '%s' (atomic)...", nextTag, remote))
+			} else {
+				ui.ShowInfoMessage(fmt.Sprintf("📤 Đang push tag %s lên remote '%s'...", nextTag, remote))
+			}
+
+			if err := versionService.PushTag(ctx, nextVersion, options); err != nil {
+				var rejected *git.PushRejectedError
+				if errors.As(err, &rejected) {
+					ui.ShowErrorMessage(fmt.Sprintf("Remote '%s' từ chối push:", rejected.Remote))
+					for _, ref := range rejected.Rejected {
+						fmt.Printf("  %s• %s: %s%s\n", ui.ColorRed, ref.Ref, ref.Reason, ui.ColorReset)
+					}
+				} else {
+					ui.ShowErrorMessage(fmt.Sprintf("Lỗi push tag: %v", err))
+				}
+				if _, resolveErr := gitService.ResolveRevision("refs/tags/" + nextTag); resolveErr != nil {
+					ui.ShowWarningMessage(fmt.Sprintf("Đã xoá tag local %s để có thể tạo lại sau khi khắc phục lỗi", nextTag))
+				}
+				return err
+			}
+
+			ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã push tag %s lên %s", nextTag, remote))
 		}
 
 		return nil
@@ -638,8 +1012,18 @@ var tagCmd = &cobra.Command{
 func init() {
 	tagCmd.Flags().Bool("dry-run", false, "Xem trước version mà không tạo tag thực tế")
 	tagCmd.Flags().String("type", "", "Ép kiểu version bump (major|minor|patch)")
-	tagCmd.Flags().String("pre-release", "", "Tạo pre-release version (alpha|beta|rc)")
+	tagCmd.Flags().String("pre-release", "", "Tạo pre-release version (alpha|beta|rc hoặc identifier semver bất kỳ)")
+	tagCmd.Flags().Bool("promote", false, "Phát hành pre-release hiện tại thành bản chính thức (v1.2.0-rc.2 → v1.2.0)")
+	tagCmd.Flags().String("build", "", "Build metadata thêm sau dấu + (ví dụ: build.5)")
 	tagCmd.Flags().String("message", "", "Custom tag annotation message")
+	tagCmd.Flags().Bool("changelog", false, "Cập nhật changelog và commit cùng với release trước khi tạo tag")
+	tagCmd.Flags().String("module", "", "Tạo tag cho một module trong monorepo (ví dụ: api → api/v1.4.0)")
+	tagCmd.Flags().Bool("all", false, "Liệt kê các module trong monorepo cần release")
+	tagCmd.Flags().Bool("no-version-files", false, "Không cập nhật version files trong config (version_files)")
+	tagCmd.Flags().Bool("no-api-check", false, "Không kiểm tra tương thích API Go so với tag gần nhất")
+	tagCmd.Flags().Bool("ai", false, "Lấy thêm ý kiến thứ hai từ AI (cần Gemini API key)")
 	tagCmd.Flags().Bool("push", false, "Push tag lên remote sau khi tạo")
+	tagCmd.Flags().String("remote", "", "Remote để push tag (mặc định: git.remote trong config, thường là origin)")
+	tagCmd.Flags().Bool("tag-only", false, "Chỉ push tag, không push branch hiện tại cùng lúc")
 	tagCmd.Flags().Bool("annotated", true, "Tạo annotated tag (mặc định: true)")
 }
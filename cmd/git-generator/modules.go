@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/apicompat"
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/internal/module"
+	"github.com/nguyendkn/git-generator/internal/ui"
+	versioning "github.com/nguyendkn/git-generator/internal/version"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// discoverModules returns the modules of the repository; module paths are
+// relative to the repository root, whatever the working directory is
+func discoverModules(gitService *git.Service) ([]types.Module, error) {
+	root, err := gitService.GetRepositoryRoot()
+	if err != nil {
+		return nil, err
+	}
+	modules, err := module.Discover(root, appConfig.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover modules: %w", err)
+	}
+	return modules, nil
+}
+
+// selectModule limits the version service to the named monorepo module
+func selectModule(gitService *git.Service, versionService *versioning.Service, name string) (types.Module, error) {
+	modules, err := discoverModules(gitService)
+	if err != nil {
+		return types.Module{}, err
+	}
+
+	selected, found := module.Find(modules, name)
+	if !found {
+		names := make([]string, 0, len(modules))
+		for _, m := range modules {
+			names = append(names, m.Name)
+		}
+		ui.ShowErrorMessage(fmt.Sprintf("Không tìm thấy module '%s'. Các module hiện có: %s", name, strings.Join(names, ", ")))
+		return types.Module{}, fmt.Errorf("module not found: %s", name)
+	}
+
+	versionService.SetModule(selected)
+	ui.ShowInfoMessage(fmt.Sprintf("📦 Module %s (%s), tag dạng %sX.Y.Z", selected.Name, selected.Path, selected.TagPrefix))
+	return selected, nil
+}
+
+// printModulePlan shows which monorepo modules have release-worthy commits since their latest tag
+func printModulePlan(gitService *git.Service, checkAPI bool) error {
+	modules, err := discoverModules(gitService)
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		ui.ShowWarningMessage("Không tìm thấy module nào (go.work, go.mod, package.json workspaces hoặc modules trong config)")
+		return nil
+	}
+
+	ui.PrintSubHeader("Kế hoạch release theo module")
+	pending := 0
+	for _, m := range modules {
+		versionService := versioning.NewService(gitService, nil, nil, *appConfig)
+		versionService.SetModule(m)
+
+		current, err := versionService.GetLatestVersion()
+		if err != nil {
+			return err
+		}
+		analysis, err := versionService.AnalyzeCommitsSinceLatestTag()
+		if err != nil {
+			return fmt.Errorf("failed to analyze module %s: %w", m.Name, err)
+		}
+		if checkAPI {
+			applyAPICheck(versionService, analysis, current)
+		}
+
+		currentTag := analysis.BaseTag
+		if currentTag == "" {
+			currentTag = "(chưa có tag)"
+		}
+		if analysis.RecommendedBump == types.VersionBumpNone {
+			fmt.Printf("  %s• %-24s %s%s — không cần release%s\n", ui.ColorBlue, m.Name, currentTag, ui.ColorWhite, ui.ColorReset)
+			continue
+		}
+
+		next, err := versionService.CalculateNextVersion(current, analysis, types.TaggingOptions{})
+		if err != nil {
+			return err
+		}
+		pending++
+		details := fmt.Sprintf("%s, %d commits", strings.ToUpper(string(analysis.RecommendedBump)), len(analysis.Commits))
+		if incompatible := apicompat.Incompatible(analysis.APIChanges); len(incompatible) > 0 {
+			details += fmt.Sprintf(", %d API breaking", len(incompatible))
+		}
+		fmt.Printf("  %s• %-24s %s → %s%s (%s)%s\n",
+			ui.ColorGreen, m.Name, currentTag, ui.ColorCyan, versionService.TagName(next), details, ui.ColorReset)
+	}
+
+	if pending == 0 {
+		ui.ShowInfoMessage("ℹ️  Không có module nào cần release")
+		return nil
+	}
+	ui.ShowInfoMessage(fmt.Sprintf("📦 %d module cần release. Dùng: git-generator tag --module <tên>", pending))
+	return nil
+}
+
+// applyAPICheck compares the exported Go API with the latest tag and records
+// incompatible changes in the analysis; failures only produce a warning
+func applyAPICheck(versionService *versioning.Service, analysis *types.VersionAnalysis, current *types.SemanticVersion) {
+	changes, err := versionService.CheckAPICompatibility(analysis.BaseTag)
+	if err != nil {
+		ui.ShowWarningMessage(fmt.Sprintf("Không thể kiểm tra tương thích API: %v", err))
+		return
+	}
+	versioning.ApplyAPIChanges(analysis, changes, current)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nguyendkn/git-generator/internal/diff"
+	"github.com/nguyendkn/git-generator/internal/pr"
+	"github.com/nguyendkn/git-generator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Generate a pull request title and description",
+	Long: `Generate a pull request title and Markdown description for the current branch.
+The commits since the merge base with the base branch and the combined diff are
+summarized into a summary, changes grouped by scope, testing notes and breaking changes.
+
+When the repository has a pull request template (for example
+.github/pull_request_template.md), its matching sections are filled in.
+The output starts with the title, followed by a blank line and the body.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, _ := cmd.Flags().GetString("base")
+		output, _ := cmd.Flags().GetString("output")
+		templatePath, _ := cmd.Flags().GetString("template")
+		noTemplate, _ := cmd.Flags().GetBool("no-template")
+
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
+		aiClient, err := newAIClient()
+		if err != nil {
+			return err
+		}
+		defer aiClient.Close()
+
+		prService := pr.NewService(gitService, diff.NewProcessor(appConfig.Git.MaxDiffSize, 20), aiClient)
+
+		ui.ShowInfoMessage("🤖 Đang tạo mô tả pull request...")
+		pullRequest, err := prService.Generate(context.Background(), pr.Options{
+			Base:     base,
+			Language: appConfig.Output.Language,
+		})
+		if err != nil {
+			ui.ShowErrorMessage(fmt.Sprintf("Lỗi tạo pull request: %v", err))
+			return err
+		}
+
+		// Templates are looked up at the repository root, not the working directory
+		if templatePath == "" && !noTemplate {
+			root, err := gitService.GetRepositoryRoot()
+			if err != nil {
+				return err
+			}
+			templatePath, _ = pr.FindTemplate(root)
+		}
+
+		body := pr.RenderMarkdown(pullRequest)
+		if templatePath != "" && !noTemplate {
+			template, err := os.ReadFile(templatePath)
+			if err != nil {
+				return fmt.Errorf("failed to read pull request template: %w", err)
+			}
+			ui.ShowInfoMessage(fmt.Sprintf("📄 Sử dụng template: %s", templatePath))
+			body = pr.FillTemplate(string(template), pullRequest)
+		}
+
+		content := pullRequest.Title + "\n\n" + body
+		if output == "" || output == "-" {
+			fmt.Print(content)
+			return nil
+		}
+
+		if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write pull request description: %w", err)
+		}
+		ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã ghi mô tả pull request vào %s (%d commits từ %s)",
+			output, len(pullRequest.Commits), pullRequest.BaseBranch))
+		return nil
+	},
+}
+
+func init() {
+	prCmd.Flags().StringP("base", "b", "", "Base branch to compare against (default: main, master or develop)")
+	prCmd.Flags().StringP("output", "o", "", "Write the title and description to a file instead of stdout")
+	prCmd.Flags().String("template", "", "Pull request template to fill (default: detected in .github)")
+	prCmd.Flags().Bool("no-template", false, "Ignore pull request templates and use the default layout")
+
+	rootCmd.AddCommand(prCmd)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/nguyendkn/git-generator/internal/releasenotes"
+	"github.com/nguyendkn/git-generator/internal/ui"
+	versioning "github.com/nguyendkn/git-generator/internal/version"
+	"github.com/nguyendkn/git-generator/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "release-notes [range]",
+	Short: "Write user-facing release notes for a tag range with AI",
+	Long: `Write release notes for the commits of a range such as v1.2.0..v1.3.0.
+
+Unlike the changelog, which lists every commit mechanically, the notes are
+written for readers: a summary, highlights, upgrade notes for breaking
+changes, and the features, fixes and dependency updates that matter to them.
+
+Without a range, the commits since the latest version tag are used. Ranges
+accept A..B, A...B and A (for A..HEAD).
+
+Audiences:
+- developers: affected APIs, configuration and migration steps (default)
+- users:      visible behaviour in plain language`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		audience, _ := cmd.Flags().GetString("audience")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		switch types.ReleaseAudience(audience) {
+		case types.ReleaseAudienceDevelopers, types.ReleaseAudienceUsers:
+		default:
+			return fmt.Errorf("invalid audience: %s (must be one of: developers, users)", audience)
+		}
+		if format != "markdown" && format != "json" {
+			return fmt.Errorf("invalid format: %s (must be one of: markdown, json)", format)
+		}
+
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
+		if !gitService.IsGitRepository() {
+			ui.ShowErrorMessage("Không phải Git repository")
+			return fmt.Errorf("not in a Git repository")
+		}
+
+		var from, to string
+		if len(args) == 1 {
+			from, to, err = gitService.ResolveRange(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to resolve range: %w", err)
+			}
+		} else {
+			latest, err := versioning.NewService(gitService, nil, nil, *appConfig).GetLatestVersionTag()
+			if err != nil {
+				return err
+			}
+			if latest != nil {
+				from = latest.Name
+			}
+			to = "HEAD"
+		}
+
+		aiClient, err := newAIClient()
+		if err != nil {
+			return err
+		}
+		defer aiClient.Close()
+
+		ui.ShowInfoMessage("🤖 Đang viết release notes...")
+		notes, err := releasenotes.NewService(gitService, aiClient).Generate(context.Background(), releasenotes.Options{
+			From:     from,
+			To:       to,
+			Audience: types.ReleaseAudience(audience),
+			Language: appConfig.Output.Language,
+		})
+		if err != nil {
+			ui.ShowErrorMessage(fmt.Sprintf("Lỗi tạo release notes: %v", err))
+			return err
+		}
+
+		content := releasenotes.RenderMarkdown(notes)
+		if format == "json" {
+			data, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode release notes: %w", err)
+			}
+			content = string(data) + "\n"
+		}
+
+		if output == "" || output == "-" {
+			fmt.Print(content)
+			return nil
+		}
+
+		if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write release notes: %w", err)
+		}
+		ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã ghi release notes vào %s (%d commits)", output, notes.CommitCount))
+		return nil
+	},
+}
+
+func init() {
+	releaseNotesCmd.Flags().String("audience", string(types.ReleaseAudienceDevelopers), "Readers of the notes: developers or users")
+	releaseNotesCmd.Flags().String("format", "markdown", "Output format: markdown or json")
+	releaseNotesCmd.Flags().StringP("output", "o", "", "Write the release notes to a file instead of stdout")
+
+	rootCmd.AddCommand(releaseNotesCmd)
+}
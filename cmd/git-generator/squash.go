@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nguyendkn/git-generator/internal/squash"
+	"github.com/nguyendkn/git-generator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var squashMessageCmd = &cobra.Command{
+	Use:   "squash-message [range]",
+	Short: "Synthesize a squash-merge commit message from a commit range",
+	Long: `Collapse the commits of a range into a single conventional commit message.
+
+The header uses the dominant commit type (a feat or fix is never hidden by
+more frequent chores), the merged scope and the description of the oldest
+commit of that type. The body lists the other meaningful changes, skipping
+WIP, fixup! and review-feedback commits. Footers such as Closes,
+BREAKING CHANGE and Co-authored-by are preserved and deduplicated.
+
+Without a range, the commits of the current branch since its merge base with
+the base branch are used. Ranges accept A..B, A...B and A (for A..HEAD).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, _ := cmd.Flags().GetString("base")
+		output, _ := cmd.Flags().GetString("output")
+
+		gitService, err := newGitService()
+		if err != nil {
+			return err
+		}
+		if !gitService.IsGitRepository() {
+			ui.ShowErrorMessage("Không phải Git repository")
+			return fmt.Errorf("not in a Git repository")
+		}
+
+		var from, to string
+		if len(args) == 1 {
+			from, to, err = gitService.ResolveRange(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to resolve range: %w", err)
+			}
+		} else {
+			if base == "" {
+				if base, err = gitService.DetectBaseBranch(); err != nil {
+					return err
+				}
+			}
+			if from, err = gitService.GetMergeBase(base, "HEAD"); err != nil {
+				return err
+			}
+			to = "HEAD"
+		}
+
+		commits, err := gitService.GetCommitsInRange(from, to)
+		if err != nil {
+			return fmt.Errorf("failed to get commits: %w", err)
+		}
+		if len(commits) == 0 {
+			ui.ShowWarningMessage("Không có commit nào trong khoảng đã chọn")
+			return fmt.Errorf("no commits in range")
+		}
+
+		message := squash.Synthesize(commits).String() + "\n"
+		if output == "" || output == "-" {
+			fmt.Print(message)
+			return nil
+		}
+
+		if err := os.WriteFile(output, []byte(message), 0644); err != nil {
+			return fmt.Errorf("failed to write squash message: %w", err)
+		}
+		ui.ShowSuccessMessage(fmt.Sprintf("✅ Đã gộp %d commits vào %s", len(commits), output))
+		return nil
+	},
+}
+
+func init() {
+	squashMessageCmd.Flags().StringP("base", "b", "", "Base branch when no range is given (default: main, master or develop)")
+	squashMessageCmd.Flags().StringP("output", "o", "", "Write the message to a file instead of stdout (e.g. for git commit -F)")
+
+	rootCmd.AddCommand(squashMessageCmd)
+}
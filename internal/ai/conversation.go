@@ -0,0 +1,92 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/nguyendkn/git-generator/internal/diff"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Follow-up instructions of the interactive review
+const (
+	InstructionShorter  = "Make the commit message shorter: a more concise header and only the essential points in the body."
+	InstructionDetailed = "Make the commit message more detailed: explain in the body what changed, why it was needed and its impact."
+)
+
+// TypeInstruction asks for the commit message to describe the changes as another commit type
+func TypeInstruction(commitType string) string {
+	return fmt.Sprintf("Describe the changes as a '%s' commit: use this type and adjust the description and body to it.", commitType)
+}
+
+// Conversation keeps the turns of a commit message session, so that
+// follow-up instructions refine the previous answer instead of starting over
+type Conversation struct {
+	client    *GeminiClient
+	chat      *genai.ChatSession
+	styleName string
+}
+
+// StartConversation generates a commit message in a new conversation
+func (gc *GeminiClient) StartConversation(ctx context.Context, processedDiff *diff.ProcessedDiff, styleName string) (*Conversation, *types.CommitMessage, error) {
+	if processedDiff == nil {
+		return nil, nil, fmt.Errorf("processed diff is nil")
+	}
+
+	conversation := &Conversation{client: gc, chat: gc.model.StartChat(), styleName: styleName}
+	message, err := conversation.send(ctx, gc.buildPrompt(processedDiff, styleName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+	return conversation, message, nil
+}
+
+// Refine asks for a revision of the current message in a follow-up turn. The
+// current message is sent along, since it may have been edited by hand.
+func (c *Conversation) Refine(ctx context.Context, current, instruction string) (*types.CommitMessage, error) {
+	message, err := c.send(ctx, buildRefinePrompt(current, instruction))
+	if err != nil {
+		return nil, fmt.Errorf("failed to refine commit message: %w", err)
+	}
+	return message, nil
+}
+
+// Turns returns the number of answered requests
+func (c *Conversation) Turns() int {
+	return len(c.chat.History) / 2
+}
+
+// send sends a turn and parses the answer; a failed turn is removed from the history
+func (c *Conversation) send(ctx context.Context, prompt string) (*types.CommitMessage, error) {
+	c.client.rateLimiter.Wait()
+
+	turns := len(c.chat.History)
+	resp, err := c.chat.SendMessage(ctx, genai.Text(prompt))
+	if err != nil {
+		c.chat.History = c.chat.History[:turns]
+		return nil, err
+	}
+	text, err := responseText(resp)
+	if err != nil {
+		c.chat.History = c.chat.History[:turns]
+		return nil, err
+	}
+	return c.client.parseCommitMessage(text, c.styleName)
+}
+
+// buildRefinePrompt creates a follow-up prompt revising the current message
+func buildRefinePrompt(current, instruction string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("Revise the commit message for the same changes.\n\n")
+	prompt.WriteString("## Current Commit Message:\n")
+	prompt.WriteString(current)
+	prompt.WriteString("\n\n## Instruction:\n")
+	prompt.WriteString(instruction)
+	prompt.WriteString("\n\nKeep the format and rules given at the start of the conversation, and keep everything the instruction does not ask to change.\n")
+	prompt.WriteString("Return only the revised commit message, no additional text or explanations.")
+
+	return prompt.String()
+}
@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/nguyendkn/git-generator/internal/diff"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// failingGemini answers every request with a server error
+func failingGemini(t *testing.T) *GeminiClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 500, "message": "unavailable"}}`, http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewGeminiClient(types.GeminiConfig{APIKey: "test"},
+		option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	client.rateLimiter = NewRateLimiter(1 << 20)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// answeredConversation returns a conversation with the given number of answered turns
+func answeredConversation(client *GeminiClient, turns int) *Conversation {
+	chat := client.model.StartChat()
+	for i := 0; i < turns; i++ {
+		chat.History = append(chat.History,
+			&genai.Content{Role: "user", Parts: []genai.Part{genai.Text("prompt")}},
+			&genai.Content{Role: "model", Parts: []genai.Part{genai.Text("feat: add login")}})
+	}
+	return &Conversation{client: client, chat: chat, styleName: "conventional"}
+}
+
+func TestBuildRefinePrompt(t *testing.T) {
+	prompt := buildRefinePrompt("feat(auth): add login\n\nUsers sign in with email.", InstructionShorter)
+
+	assert.True(t, strings.HasPrefix(prompt, "Revise the commit message for the same changes."))
+	assert.Contains(t, prompt, "## Current Commit Message:\nfeat(auth): add login\n\nUsers sign in with email.\n\n## Instruction:\n"+InstructionShorter+"\n")
+	assert.Contains(t, prompt, "Keep the format and rules given at the start of the conversation")
+	assert.True(t, strings.HasSuffix(prompt, "no additional text or explanations."))
+
+	assert.Contains(t, buildRefinePrompt("fix: handle errors", TypeInstruction("refactor")), "Describe the changes as a 'refactor' commit")
+}
+
+func TestConversationTurns(t *testing.T) {
+	client := failingGemini(t)
+	ctx := context.Background()
+
+	conversation := answeredConversation(client, 2)
+	assert.Equal(t, 2, conversation.Turns())
+
+	// A failed turn is not kept in the history
+	_, err := conversation.Refine(ctx, "feat: add login", InstructionShorter)
+	assert.Error(t, err)
+	assert.Equal(t, 2, conversation.Turns())
+
+	// A conversation that could not be started is not returned
+	regenerated, _, err := client.StartConversation(ctx, &diff.ProcessedDiff{Summary: "1 file changed"}, "conventional")
+	assert.Error(t, err)
+	assert.Nil(t, regenerated)
+	assert.Equal(t, 2, conversation.Turns())
+
+	// A new conversation starts without turns
+	assert.Equal(t, 0, answeredConversation(client, 0).Turns())
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/nguyendkn/git-generator/internal/diff"
 	"github.com/nguyendkn/git-generator/internal/scope"
+	"github.com/nguyendkn/git-generator/internal/style"
 	"github.com/nguyendkn/git-generator/pkg/types"
 )
 
@@ -22,6 +23,8 @@ type GeminiClient struct {
 	config        types.GeminiConfig
 	rateLimiter   *RateLimiter
 	scopeDetector *scope.Detector
+	rules         []string        // Repository commit rules added to the prompt
+	styles        *style.Registry // Message styles by name
 }
 
 // RateLimiter implements simple rate limiting
@@ -48,14 +51,15 @@ func (rl *RateLimiter) Wait() {
 	rl.lastRequest = time.Now()
 }
 
-// NewGeminiClient creates a new Gemini API client
-func NewGeminiClient(config types.GeminiConfig) (*GeminiClient, error) {
+// NewGeminiClient creates a new Gemini API client; options such as
+// option.WithEndpoint are passed to the underlying client
+func NewGeminiClient(config types.GeminiConfig, opts ...option.ClientOption) (*GeminiClient, error) {
 	if config.APIKey == "" {
 		return nil, fmt.Errorf(":Google Gemini API key is required")
 	}
 
 	ctx := context.Background()
-	client, err := genai.NewClient(ctx, option.WithAPIKey(config.APIKey))
+	client, err := genai.NewClient(ctx, append([]option.ClientOption{option.WithAPIKey(config.APIKey)}, opts...)...)
 	if err != nil {
 		return nil, fmt.Errorf(":Failed to create Gemini client: %w", err)
 	}
@@ -113,8 +117,29 @@ func (gc *GeminiClient) Close() error {
 	return gc.client.Close()
 }
 
+// SetCommitRules sets the repository commit rules the generated messages must follow
+func (gc *GeminiClient) SetCommitRules(rules []string) {
+	gc.rules = rules
+}
+
+// SetStyles sets the message styles, such as the template style of the configuration
+func (gc *GeminiClient) SetStyles(styles *style.Registry) {
+	gc.styles = styles
+}
+
+// lookupStyle returns the style with the given name, or Conventional Commits
+// for an unknown name
+func (gc *GeminiClient) lookupStyle(name string) style.Style {
+	if gc.styles != nil {
+		if selected, err := gc.styles.Lookup(name); err == nil {
+			return selected
+		}
+	}
+	return style.Conventional()
+}
+
 // GenerateCommitMessage generates a commit message from processed diff data
-func (gc *GeminiClient) GenerateCommitMessage(ctx context.Context, processedDiff *diff.ProcessedDiff, style string) (*types.CommitMessage, error) {
+func (gc *GeminiClient) GenerateCommitMessage(ctx context.Context, processedDiff *diff.ProcessedDiff, styleName string) (*types.CommitMessage, error) {
 	if processedDiff == nil {
 		return nil, fmt.Errorf("processed diff is nil")
 	}
@@ -122,30 +147,79 @@ func (gc *GeminiClient) GenerateCommitMessage(ctx context.Context, processedDiff
 	// Apply rate limiting
 	gc.rateLimiter.Wait()
 
-	prompt := gc.buildPrompt(processedDiff, style)
+	prompt := gc.buildPrompt(processedDiff, styleName)
 
-	resp, err := gc.model.GenerateContent(ctx, genai.Text(prompt))
+	responseText, err := gc.generateText(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
+	return gc.parseCommitMessage(responseText, styleName)
+}
+
+// RepairCommitMessage asks the model to fix the problems found in a commit
+// message, keeping everything else unchanged
+func (gc *GeminiClient) RepairCommitMessage(ctx context.Context, message string, problems []string, styleName string) (*types.CommitMessage, error) {
+	gc.rateLimiter.Wait()
+
+	responseText, err := gc.generateText(ctx, buildRepairPrompt(message, problems))
+	if err != nil {
+		return nil, fmt.Errorf("failed to repair commit message: %w", err)
+	}
+
+	return gc.parseCommitMessage(responseText, styleName)
+}
+
+// buildRepairPrompt creates a prompt that targets the problems of a commit message
+func buildRepairPrompt(message string, problems []string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("The following Git commit message does not pass validation.\n\n")
+	prompt.WriteString("## Commit Message:\n")
+	prompt.WriteString(message)
+	prompt.WriteString("\n\n## Problems (line:column: problem):\n")
+	for _, problem := range problems {
+		prompt.WriteString(fmt.Sprintf("- %s\n", problem))
+	}
+
+	prompt.WriteString("\n## Instructions:\n")
+	prompt.WriteString("1. Fix only the problems listed above\n")
+	prompt.WriteString("2. Keep the meaning, type, scope, body and footers otherwise unchanged\n")
+	prompt.WriteString("3. When the subject is too long, rephrase it more concisely instead of cutting words\n\n")
+	prompt.WriteString("Return only the corrected commit message, no additional text or explanations.")
+
+	return prompt.String()
+}
+
+// generateText sends a single prompt to the model and returns the text of the first candidate
+func (gc *GeminiClient) generateText(ctx context.Context, prompt string) (string, error) {
+	resp, err := gc.model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		return "", err
+	}
+
+	return responseText(resp)
+}
+
+// responseText concatenates the text parts of the first response candidate
+func responseText(resp *genai.GenerateContentResponse) (string, error) {
 	if len(resp.Candidates) == 0 {
-		return nil, fmt.Errorf("no response candidates received")
+		return "", fmt.Errorf("no response candidates received")
 	}
 
 	candidate := resp.Candidates[0]
 	if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
-		return nil, fmt.Errorf("empty response content")
+		return "", fmt.Errorf("empty response content")
 	}
 
-	responseText := ""
+	var text strings.Builder
 	for _, part := range candidate.Content.Parts {
 		if textPart, ok := part.(genai.Text); ok {
-			responseText += string(textPart)
+			text.WriteString(string(textPart))
 		}
 	}
 
-	return gc.parseCommitMessage(responseText, style)
+	return text.String(), nil
 }
 
 // AnalyzeChangesForVersioning analyzes changes to determine semantic version bump type
@@ -159,33 +233,18 @@ func (gc *GeminiClient) AnalyzeChangesForVersioning(ctx context.Context, process
 
 	prompt := gc.buildVersionAnalysisPrompt(processedDiff, recentCommits)
 
-	resp, err := gc.model.GenerateContent(ctx, genai.Text(prompt))
+	responseText, err := gc.generateText(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate version analysis: %w", err)
 	}
 
-	if len(resp.Candidates) == 0 {
-		return nil, fmt.Errorf("no response candidates received")
-	}
-
-	candidate := resp.Candidates[0]
-	if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
-		return nil, fmt.Errorf("empty response content")
-	}
-
-	responseText := ""
-	for _, part := range candidate.Content.Parts {
-		if textPart, ok := part.(genai.Text); ok {
-			responseText += string(textPart)
-		}
-	}
-
 	return gc.parseVersionAnalysis(responseText)
 }
 
 // buildPrompt creates a prompt for the AI model
-func (gc *GeminiClient) buildPrompt(processedDiff *diff.ProcessedDiff, style string) string {
+func (gc *GeminiClient) buildPrompt(processedDiff *diff.ProcessedDiff, styleName string) string {
 	var prompt strings.Builder
+	selected := gc.lookupStyle(styleName)
 
 	prompt.WriteString("You are an expert software developer tasked with generating a high-quality Git commit message that explains both WHAT changed and WHY the changes were made.\n\n")
 
@@ -198,62 +257,38 @@ func (gc *GeminiClient) buildPrompt(processedDiff *diff.ProcessedDiff, style str
 	prompt.WriteString("5. Provide specific examples when configuration or function changes are involved\n\n")
 
 	// Add style-specific instructions
-	switch style {
-	case "conventional":
-		prompt.WriteString("Generate a commit message following the Conventional Commits specification:\n")
-		prompt.WriteString("Format: <type>[optional scope]: <description>\n")
-		prompt.WriteString("\n<why the change was made and its purpose>\n")
-		prompt.WriteString("<context from previous related changes if relevant>\n")
-		prompt.WriteString("[optional footer(s)]\n\n")
-		prompt.WriteString("IMPORTANT: Choose ONLY ONE type that best represents the primary change:\n")
-		prompt.WriteString("Types: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert\n")
-		prompt.WriteString("- Use 'feat' for new features or functionality\n")
-		prompt.WriteString("- Use 'fix' for bug fixes\n")
-		prompt.WriteString("- Use 'docs' for documentation changes\n")
-		prompt.WriteString("- Use 'refactor' for code refactoring without changing functionality\n")
-		prompt.WriteString("- Use 'test' for test-related changes\n")
-		prompt.WriteString("- Use 'chore' for maintenance tasks, build changes, or tooling\n")
-		prompt.WriteString("- Use 'style' for formatting, missing semicolons, etc.\n")
-		prompt.WriteString("- Use 'perf' for performance improvements\n")
-		prompt.WriteString("- Use 'build' for build system or external dependencies\n")
-		prompt.WriteString("- Use 'ci' for CI configuration files and scripts\n\n")
-		prompt.WriteString("DO NOT mix multiple types in one commit message. Choose the most appropriate single type.\n\n")
-
-		// Add scope detection information for conventional commits
-		if processedDiff.DiffSummary != nil {
-			detectedScope := gc.scopeDetector.DetectScope(processedDiff.DiffSummary)
-			multipleScopes := gc.scopeDetector.DetectMultipleScopes(processedDiff.DiffSummary)
-
-			if detectedScope != "" {
-				prompt.WriteString("## Scope Detection Analysis:\n")
-				prompt.WriteString(fmt.Sprintf("Primary detected scope: '%s'\n", detectedScope))
-
-				if len(multipleScopes) > 1 {
-					prompt.WriteString("Multiple scopes detected:\n")
-					for scope, confidence := range multipleScopes {
-						prompt.WriteString(fmt.Sprintf("- %s (%.1f%% confidence)\n", scope, confidence*100))
-					}
-					prompt.WriteString("\nGuidelines for scope selection:\n")
-					prompt.WriteString("- If changes affect a single module/component, use that as scope\n")
-					prompt.WriteString("- If changes affect multiple modules, consider using the primary module or omit scope for broader changes\n")
-					prompt.WriteString("- For core/shared changes, use 'core' or omit scope\n")
-				} else {
-					prompt.WriteString(fmt.Sprintf("Use scope '%s' in your conventional commit format.\n", detectedScope))
+	for _, line := range selected.Guidance() {
+		prompt.WriteString(line + "\n")
+	}
+	prompt.WriteString("\n")
+
+	// Add scope detection information for styles with conventional headers
+	if selected.Info().Conventional && processedDiff.DiffSummary != nil {
+		detectedScope := gc.scopeDetector.DetectScope(processedDiff.DiffSummary)
+		multipleScopes := gc.scopeDetector.DetectMultipleScopes(processedDiff.DiffSummary)
+
+		if detectedScope != "" {
+			prompt.WriteString("## Scope Detection Analysis:\n")
+			prompt.WriteString(fmt.Sprintf("Primary detected scope: '%s'\n", detectedScope))
+
+			if len(multipleScopes) > 1 {
+				prompt.WriteString("Multiple scopes detected:\n")
+				for scope, confidence := range multipleScopes {
+					prompt.WriteString(fmt.Sprintf("- %s (%.1f%% confidence)\n", scope, confidence*100))
 				}
-				prompt.WriteString("\n")
+				prompt.WriteString("\nGuidelines for scope selection:\n")
+				prompt.WriteString("- If changes affect a single module/component, use that as scope\n")
+				prompt.WriteString("- If changes affect multiple modules, consider using the primary module or omit scope for broader changes\n")
+				prompt.WriteString("- For core/shared changes, use 'core' or omit scope\n")
 			} else {
-				prompt.WriteString("## Scope Detection:\n")
-				prompt.WriteString("No clear module pattern detected. Generate conventional commit without scope.\n\n")
+				prompt.WriteString(fmt.Sprintf("Use scope '%s' in your conventional commit format.\n", detectedScope))
 			}
+			prompt.WriteString("\n")
+		} else {
+			prompt.WriteString("## Scope Detection:\n")
+			prompt.WriteString("No clear module pattern detected. Generate conventional commit without scope.\n\n")
 		}
-	case "simple":
-		prompt.WriteString("Generate a simple, clear commit message that describes what was changed and why.\n")
-		prompt.WriteString("Keep it concise but include the reasoning behind the change.\n\n")
-	default:
-		prompt.WriteString("Generate a detailed commit message that clearly explains the changes and their purpose.\n")
-		prompt.WriteString("Include a subject line and body that covers both what changed and why.\n\n")
 	}
-
 	// Add diff summary
 	prompt.WriteString(fmt.Sprintf("## Change Summary\n%s\n\n", processedDiff.Summary))
 
@@ -356,10 +391,20 @@ func (gc *GeminiClient) buildPrompt(processedDiff *diff.ProcessedDiff, style str
 	prompt.WriteString("9. Keep the subject line under 50 characters\n")
 	prompt.WriteString("10. Use imperative mood (e.g., 'Add feature' not 'Added feature')\n\n")
 
+	if len(gc.rules) > 0 {
+		prompt.WriteString("## Repository Commit Rules (these take precedence over the instructions above):\n")
+		for _, rule := range gc.rules {
+			prompt.WriteString(fmt.Sprintf("- %s\n", rule))
+		}
+		prompt.WriteString("\n")
+	}
+
 	prompt.WriteString("## Expected Format:\n")
-	prompt.WriteString("<type>: <what changed>\n\n")
-	prompt.WriteString("<why the change was made and its purpose>\n")
-	prompt.WriteString("<context from previous related changes if relevant>\n\n")
+	prompt.WriteString(selected.Info().Example + "\n\n")
+	if selected.Info().Body {
+		prompt.WriteString("<why the change was made and its purpose>\n")
+		prompt.WriteString("<context from previous related changes if relevant>\n\n")
+	}
 
 	prompt.WriteString("Generate only the commit message following this format, no additional text or explanations.")
 
@@ -367,7 +412,7 @@ func (gc *GeminiClient) buildPrompt(processedDiff *diff.ProcessedDiff, style str
 }
 
 // parseCommitMessage parses the AI response into a structured commit message
-func (gc *GeminiClient) parseCommitMessage(response, style string) (*types.CommitMessage, error) {
+func (gc *GeminiClient) parseCommitMessage(response, styleName string) (*types.CommitMessage, error) {
 	response = strings.TrimSpace(response)
 	if response == "" {
 		return nil, fmt.Errorf("empty response from AI")
@@ -382,8 +427,9 @@ func (gc *GeminiClient) parseCommitMessage(response, style string) (*types.Commi
 
 	// Parse the first line (subject)
 	subject := strings.TrimSpace(lines[0])
+	selected := gc.lookupStyle(styleName)
 
-	if style == "conventional" {
+	if selected.Info().Conventional {
 		// Parse conventional commit format
 		if err := gc.parseConventionalCommit(subject, commitMsg); err != nil {
 			// If parsing fails, try to extract type manually or fallback gracefully
@@ -412,13 +458,19 @@ func (gc *GeminiClient) parseCommitMessage(response, style string) (*types.Commi
 			}
 		}
 	} else {
-		// Simple format
-		commitMsg.Type = types.CommitTypeChore
-		commitMsg.Description = subject
+		// Other styles give the type through their header, such as a gitmoji
+		header, _ := selected.Parse(subject)
+		commitMsg.Type = types.CommitType(header.Type)
+		commitMsg.Scope = header.Scope
+		commitMsg.Description = header.Description
+		commitMsg.Breaking = header.Breaking
+		if commitMsg.Type == "" {
+			commitMsg.Type = types.CommitTypeChore
+		}
 	}
 
-	// Parse body and footer if present
-	if len(lines) > 1 {
+	// Parse body and footer if present, for styles with a body
+	if len(lines) > 1 && selected.Info().Body {
 		var bodyLines []string
 		var footerLines []string
 		inFooter := false
@@ -600,30 +652,11 @@ func (gc *GeminiClient) buildVersionAnalysisPrompt(processedDiff *diff.Processed
 
 // parseVersionAnalysis parses the AI response for version analysis
 func (gc *GeminiClient) parseVersionAnalysis(responseText string) (*types.VersionAnalysis, error) {
-	// Clean up the response text
-	responseText = strings.TrimSpace(responseText)
-
-	// Remove markdown code blocks if present
-	if strings.HasPrefix(responseText, "```json") {
-		responseText = strings.TrimPrefix(responseText, "```json")
-		responseText = strings.TrimSuffix(responseText, "```")
-	} else if strings.HasPrefix(responseText, "```") {
-		responseText = strings.TrimPrefix(responseText, "```")
-		responseText = strings.TrimSuffix(responseText, "```")
-	}
-
-	responseText = strings.TrimSpace(responseText)
-
-	// Try to extract JSON from the response
-	jsonStart := strings.Index(responseText, "{")
-	jsonEnd := strings.LastIndex(responseText, "}")
-
-	if jsonStart == -1 || jsonEnd == -1 || jsonStart >= jsonEnd {
-		return nil, fmt.Errorf("no valid JSON found in response")
+	jsonStr, err := extractJSON(responseText)
+	if err != nil {
+		return nil, err
 	}
 
-	jsonStr := responseText[jsonStart : jsonEnd+1]
-
 	// Parse the JSON response
 	var rawAnalysis struct {
 		RecommendedBump string   `json:"recommended_bump"`
@@ -674,3 +707,30 @@ func (gc *GeminiClient) parseVersionAnalysis(responseText string) (*types.Versio
 
 	return analysis, nil
 }
+
+// extractJSON returns the JSON object in a model response, stripping Markdown code fences
+func extractJSON(responseText string) (string, error) {
+	// Clean up the response text
+	responseText = strings.TrimSpace(responseText)
+
+	// Remove markdown code blocks if present
+	if strings.HasPrefix(responseText, "```json") {
+		responseText = strings.TrimPrefix(responseText, "```json")
+		responseText = strings.TrimSuffix(responseText, "```")
+	} else if strings.HasPrefix(responseText, "```") {
+		responseText = strings.TrimPrefix(responseText, "```")
+		responseText = strings.TrimSuffix(responseText, "```")
+	}
+
+	responseText = strings.TrimSpace(responseText)
+
+	// Try to extract JSON from the response
+	jsonStart := strings.Index(responseText, "{")
+	jsonEnd := strings.LastIndex(responseText, "}")
+
+	if jsonStart == -1 || jsonEnd == -1 || jsonStart >= jsonEnd {
+		return "", fmt.Errorf("no valid JSON found in response")
+	}
+
+	return responseText[jsonStart : jsonEnd+1], nil
+}
@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/diff"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// maxPullRequestCommits limits how many commits are listed in the prompt
+const maxPullRequestCommits = 50
+
+// GeneratePullRequest generates a pull request title and description from the
+// commits of a branch and its combined diff against the base branch
+func (gc *GeminiClient) GeneratePullRequest(ctx context.Context, processedDiff *diff.ProcessedDiff, commits []*types.CommitInfo, language string) (*types.PullRequest, error) {
+	if processedDiff == nil {
+		return nil, fmt.Errorf("processed diff is nil")
+	}
+
+	// Apply rate limiting
+	gc.rateLimiter.Wait()
+
+	prompt := gc.buildPullRequestPrompt(processedDiff, commits, language)
+
+	responseText, err := gc.generateText(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate pull request: %w", err)
+	}
+
+	return gc.parsePullRequest(responseText)
+}
+
+// buildPullRequestPrompt creates a prompt for pull request generation
+func (gc *GeminiClient) buildPullRequestPrompt(processedDiff *diff.ProcessedDiff, commits []*types.CommitInfo, language string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("You are an experienced software engineer writing a pull request description for your reviewers.\n")
+	prompt.WriteString("Describe the branch as a whole: what it changes, why, and how reviewers can verify it.\n\n")
+
+	prompt.WriteString("COMMITS ON THE BRANCH (oldest first):\n")
+	for i := len(commits) - 1; i >= 0; i-- {
+		if len(commits)-1-i >= maxPullRequestCommits {
+			prompt.WriteString(fmt.Sprintf("- ... and %d more commits\n", i+1))
+			break
+		}
+		commit := commits[i]
+		prompt.WriteString(fmt.Sprintf("- %s\n", commit.Subject))
+		if commit.Body != "" {
+			for _, line := range strings.Split(commit.Body, "\n") {
+				if strings.TrimSpace(line) != "" {
+					prompt.WriteString(fmt.Sprintf("    %s\n", line))
+				}
+			}
+		}
+	}
+	prompt.WriteString("\n")
+
+	prompt.WriteString("COMBINED CHANGES:\n")
+	prompt.WriteString(fmt.Sprintf("Files changed: %d\n", processedDiff.TotalFiles))
+	prompt.WriteString(fmt.Sprintf("Lines added: %d\n", processedDiff.TotalAdded))
+	prompt.WriteString(fmt.Sprintf("Lines deleted: %d\n", processedDiff.TotalDeleted))
+	if processedDiff.Summary != "" {
+		prompt.WriteString(fmt.Sprintf("Summary: %s\n", processedDiff.Summary))
+	}
+	for _, chunk := range processedDiff.Chunks {
+		for _, file := range chunk.Files {
+			prompt.WriteString(fmt.Sprintf("- %s (%s): +%d -%d lines\n",
+				file.Path, file.ChangeType, file.LinesAdded, file.LinesDeleted))
+		}
+	}
+	prompt.WriteString("\n")
+
+	prompt.WriteString("INSTRUCTIONS:\n")
+	prompt.WriteString("1. The title must be a single line under 72 characters; use a conventional commit header (type(scope): description) when the commits follow that convention\n")
+	prompt.WriteString("2. The summary is 2-4 sentences explaining the purpose and the approach\n")
+	prompt.WriteString("3. Group changes by scope (module, package or area); use the commit scopes when present\n")
+	prompt.WriteString("4. Testing notes describe concrete steps or commands a reviewer can run\n")
+	prompt.WriteString("5. List breaking changes only if behaviour or APIs change incompatibly; otherwise return an empty list\n")
+	prompt.WriteString("6. Ignore noise such as WIP, fixup or merge commits\n")
+	if language == "vi" {
+		prompt.WriteString("7. Write all text in Vietnamese\n")
+	} else {
+		prompt.WriteString("7. Write all text in English\n")
+	}
+
+	prompt.WriteString("\nRESPONSE FORMAT:\n")
+	prompt.WriteString("Respond with a JSON object only:\n")
+	prompt.WriteString("{\n")
+	prompt.WriteString(`  "title": "feat(api): add pagination to list endpoints",` + "\n")
+	prompt.WriteString(`  "summary": "What the pull request does and why",` + "\n")
+	prompt.WriteString(`  "changes": [{"scope": "api", "items": ["change description"]}],` + "\n")
+	prompt.WriteString(`  "testing": ["how to verify the change"],` + "\n")
+	prompt.WriteString(`  "breaking_changes": ["list of breaking changes if any"]` + "\n")
+	prompt.WriteString("}\n")
+
+	return prompt.String()
+}
+
+// parsePullRequest parses the AI response for pull request generation
+func (gc *GeminiClient) parsePullRequest(responseText string) (*types.PullRequest, error) {
+	jsonStr, err := extractJSON(responseText)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr types.PullRequest
+	if err := json.Unmarshal([]byte(jsonStr), &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	pr.Title = strings.TrimSpace(pr.Title)
+	if pr.Title == "" {
+		return nil, fmt.Errorf("pull request title is empty")
+	}
+	pr.Summary = strings.TrimSpace(pr.Summary)
+
+	return &pr, nil
+}
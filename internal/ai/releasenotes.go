@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// maxReleaseNotesCommits limits how many commits are listed in the prompt
+const maxReleaseNotesCommits = 100
+
+// GenerateReleaseNotes writes release notes for the given audience from the
+// commits of a release and their aggregated version analysis
+func (gc *GeminiClient) GenerateReleaseNotes(ctx context.Context, commits []*types.CommitInfo, analysis *types.VersionAnalysis, audience types.ReleaseAudience, language string) (*types.ReleaseNotes, error) {
+	if analysis == nil {
+		return nil, fmt.Errorf("version analysis is nil")
+	}
+
+	// Apply rate limiting
+	gc.rateLimiter.Wait()
+
+	prompt := gc.buildReleaseNotesPrompt(commits, analysis, audience, language)
+
+	responseText, err := gc.generateText(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate release notes: %w", err)
+	}
+
+	return gc.parseReleaseNotes(responseText)
+}
+
+// buildReleaseNotesPrompt creates a prompt for release notes generation
+func (gc *GeminiClient) buildReleaseNotesPrompt(commits []*types.CommitInfo, analysis *types.VersionAnalysis, audience types.ReleaseAudience, language string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("You are a product-minded software engineer writing the release notes for a new version.\n")
+	if audience == types.ReleaseAudienceUsers {
+		prompt.WriteString("The readers are end users: describe visible behaviour and benefits in plain language, avoid code identifiers, file names and internal refactorings.\n\n")
+	} else {
+		prompt.WriteString("The readers are developers integrating this project: mention affected APIs, configuration keys and commands, and be precise about migrations.\n\n")
+	}
+
+	writeList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		prompt.WriteString(title + ":\n")
+		for _, item := range items {
+			prompt.WriteString(fmt.Sprintf("- %s\n", item))
+		}
+		prompt.WriteString("\n")
+	}
+	writeList("BREAKING CHANGES", analysis.BreakingChanges)
+	writeList("NEW FEATURES", analysis.NewFeatures)
+	writeList("BUG FIXES", analysis.BugFixes)
+	writeList("DEPENDENCY UPDATES", analysis.Dependencies)
+
+	prompt.WriteString("ALL COMMITS (oldest first):\n")
+	for i := len(commits) - 1; i >= 0; i-- {
+		if len(commits)-1-i >= maxReleaseNotesCommits {
+			prompt.WriteString(fmt.Sprintf("- ... and %d more commits\n", i+1))
+			break
+		}
+		commit := commits[i]
+		prompt.WriteString(fmt.Sprintf("- %s\n", commit.Subject))
+		if commit.Body != "" {
+			for _, line := range strings.Split(commit.Body, "\n") {
+				if strings.TrimSpace(line) != "" {
+					prompt.WriteString(fmt.Sprintf("    %s\n", line))
+				}
+			}
+		}
+	}
+	prompt.WriteString("\n")
+
+	prompt.WriteString("INSTRUCTIONS:\n")
+	prompt.WriteString("1. The title is a short headline for the release without the version number\n")
+	prompt.WriteString("2. The summary is 1-3 sentences about what the release means for the reader\n")
+	prompt.WriteString("3. Highlights are the 1-5 most important changes, each one sentence; do not just repeat commit subjects\n")
+	prompt.WriteString("4. Features and fixes are rewritten for the reader; merge related commits and skip internal-only changes (chore, ci, test, refactor) unless they affect the reader\n")
+	prompt.WriteString("5. For every breaking change, write an upgrade note telling the reader what to do; return an empty list when there are no breaking changes\n")
+	prompt.WriteString("6. Only list dependency updates that matter to the reader\n")
+	if language == "vi" {
+		prompt.WriteString("7. Write all text in Vietnamese\n")
+	} else {
+		prompt.WriteString("7. Write all text in English\n")
+	}
+
+	prompt.WriteString("\nRESPONSE FORMAT:\n")
+	prompt.WriteString("Respond with a JSON object only:\n")
+	prompt.WriteString("{\n")
+	prompt.WriteString(`  "title": "Faster exports and a new CSV format",` + "\n")
+	prompt.WriteString(`  "summary": "What this release means for the reader",` + "\n")
+	prompt.WriteString(`  "highlights": ["most important change"],` + "\n")
+	prompt.WriteString(`  "features": ["new feature"],` + "\n")
+	prompt.WriteString(`  "fixes": ["bug fix"],` + "\n")
+	prompt.WriteString(`  "dependencies": ["dependency update"],` + "\n")
+	prompt.WriteString(`  "upgrade_notes": ["what to change when upgrading"]` + "\n")
+	prompt.WriteString("}\n")
+
+	return prompt.String()
+}
+
+// parseReleaseNotes parses the AI response for release notes generation
+func (gc *GeminiClient) parseReleaseNotes(responseText string) (*types.ReleaseNotes, error) {
+	jsonStr, err := extractJSON(responseText)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes types.ReleaseNotes
+	if err := json.Unmarshal([]byte(jsonStr), &notes); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	notes.Title = strings.TrimSpace(notes.Title)
+	notes.Summary = strings.TrimSpace(notes.Summary)
+	if notes.Title == "" && notes.Summary == "" && len(notes.Highlights) == 0 {
+		return nil, fmt.Errorf("release notes are empty")
+	}
+
+	return &notes, nil
+}
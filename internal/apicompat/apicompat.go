@@ -0,0 +1,333 @@
+package apicompat
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// packageDecl is the declaration name used when a whole package is added or removed
+const packageDecl = "package"
+
+// API is the exported API of the Go packages of a module, keyed by package
+// directory and then by declaration name (e.g. "func Parse", "field Config.Name")
+type API map[string]map[string]string
+
+// Load extracts the exported API of the Go module in dir from the given file
+// paths, reading each Go file with read. Declarations are taken from the
+// syntax tree rather than by type-checking, so any revision can be compared
+// without downloading its dependencies. Test files, internal packages, main
+// packages and nested modules are skipped.
+func Load(files []string, dir string, read func(path string) ([]byte, error)) (API, error) {
+	fset := token.NewFileSet()
+	api := make(API)
+	for _, file := range SourceFiles(files, dir) {
+		pkgDir := path.Dir(file)
+		content, err := read(file)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parser.ParseFile(fset, file, content, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if parsed.Name.Name == "main" || strings.HasSuffix(parsed.Name.Name, "_test") {
+			continue
+		}
+
+		decls := api[pkgDir]
+		if decls == nil {
+			decls = make(map[string]string)
+			api[pkgDir] = decls
+		}
+		extract(fset, parsed, decls)
+	}
+	return api, nil
+}
+
+// Compare lists the changes from the API before to the API after, sorted by
+// package and name. Removed and changed declarations are incompatible;
+// additions are compatible.
+func Compare(before, after API) []types.APIChange {
+	var changes []types.APIChange
+
+	for _, pkg := range sortedKeys(before, after) {
+		old, current := before[pkg], after[pkg]
+		switch {
+		case current == nil:
+			changes = append(changes, types.APIChange{Package: pkg, Name: packageDecl, Kind: types.APIChangeRemoved})
+			continue
+		case old == nil:
+			changes = append(changes, types.APIChange{Package: pkg, Name: packageDecl, Kind: types.APIChangeAdded, Compatible: true})
+			continue
+		}
+
+		for _, name := range sortedKeys(old, current) {
+			was, existed := old[name]
+			now, exists := current[name]
+			switch {
+			case !exists:
+				changes = append(changes, types.APIChange{Package: pkg, Name: name, Kind: types.APIChangeRemoved, Before: was})
+			case !existed:
+				changes = append(changes, types.APIChange{Package: pkg, Name: name, Kind: types.APIChangeAdded, After: now, Compatible: true})
+			case was != now:
+				changes = append(changes, types.APIChange{Package: pkg, Name: name, Kind: types.APIChangeChanged, Before: was, After: now})
+			}
+		}
+	}
+	return changes
+}
+
+// Incompatible returns the changes that may break existing callers
+func Incompatible(changes []types.APIChange) []types.APIChange {
+	var incompatible []types.APIChange
+	for _, change := range changes {
+		if !change.Compatible {
+			incompatible = append(incompatible, change)
+		}
+	}
+	return incompatible
+}
+
+// extract adds the exported declarations of a file to decls
+func extract(fset *token.FileSet, file *ast.File, decls map[string]string) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			extractFunc(fset, decl, decls)
+		case *ast.GenDecl:
+			extractGen(fset, decl, decls)
+		}
+	}
+}
+
+// extractFunc records an exported function, or an exported method of an exported type
+func extractFunc(fset *token.FileSet, decl *ast.FuncDecl, decls map[string]string) {
+	if !decl.Name.IsExported() {
+		return
+	}
+	signature := &ast.FuncDecl{Name: decl.Name, Type: stripNames(decl.Type).(*ast.FuncType)}
+
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		decls["func "+decl.Name.Name] = format(fset, signature)
+		return
+	}
+
+	receiver := decl.Recv.List[0].Type
+	typeName := baseTypeName(receiver)
+	if !ast.IsExported(typeName) {
+		return
+	}
+	signature.Recv = &ast.FieldList{List: []*ast.Field{{Type: receiver}}}
+	decls["method "+typeName+"."+decl.Name.Name] = format(fset, signature)
+}
+
+// extractGen records the exported types, constants and variables of a declaration
+func extractGen(fset *token.FileSet, decl *ast.GenDecl, decls map[string]string) {
+	var constType ast.Expr // Constants without a type or value repeat the previous spec
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			if spec.Name.IsExported() {
+				extractType(fset, spec, decls)
+			}
+		case *ast.ValueSpec:
+			kind := "var"
+			valueType := spec.Type
+			if decl.Tok == token.CONST {
+				kind = "const"
+				if spec.Type != nil || len(spec.Values) > 0 {
+					constType = spec.Type
+				}
+				valueType = constType
+			}
+			for _, name := range spec.Names {
+				if name.IsExported() {
+					decls[kind+" "+name.Name] = formatOptional(fset, valueType)
+				}
+			}
+		}
+	}
+}
+
+// extractType records an exported type; struct fields are recorded separately
+// so adding a field stays compatible
+func extractType(fset *token.FileSet, spec *ast.TypeSpec, decls map[string]string) {
+	name := spec.Name.Name
+	params := ""
+	if spec.TypeParams != nil {
+		var list []string
+		for _, field := range spec.TypeParams.List {
+			names := make([]string, 0, len(field.Names))
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+			list = append(list, strings.Join(names, ", ")+" "+format(fset, field.Type))
+		}
+		params = "[" + strings.Join(list, ", ") + "] "
+	}
+
+	structType, isStruct := spec.Type.(*ast.StructType)
+	switch {
+	case spec.Assign.IsValid():
+		decls["type "+name] = params + "= " + format(fset, stripNames(spec.Type))
+	case isStruct:
+		decls["type "+name] = params + "struct"
+		for _, field := range structType.Fields.List {
+			fieldType := format(fset, stripNames(field.Type))
+			if len(field.Names) == 0 {
+				if embedded := baseTypeName(field.Type); ast.IsExported(embedded) {
+					decls["field "+name+"."+embedded] = fieldType
+				}
+				continue
+			}
+			for _, fieldName := range field.Names {
+				if fieldName.IsExported() {
+					decls["field "+name+"."+fieldName.Name] = fieldType
+				}
+			}
+		}
+	default:
+		// Interfaces are compared as a whole: adding a method breaks implementations
+		decls["type "+name] = params + format(fset, stripNames(spec.Type))
+	}
+}
+
+// stripNames removes parameter and result names from the function types in
+// node, which do not affect compatibility
+func stripNames(node ast.Node) ast.Node {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if funcType, ok := n.(*ast.FuncType); ok {
+			funcType.Params = unnamed(funcType.Params)
+			funcType.Results = unnamed(funcType.Results)
+		}
+		return true
+	})
+	return node
+}
+
+// unnamed returns a field list with one unnamed field per name
+func unnamed(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	result := &ast.FieldList{}
+	for _, field := range fields.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			result.List = append(result.List, &ast.Field{Type: field.Type})
+		}
+	}
+	return result
+}
+
+// baseTypeName returns the type name of a receiver or embedded field,
+// without pointers, package qualifiers or type arguments
+func baseTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return baseTypeName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return baseTypeName(expr.X)
+	case *ast.IndexListExpr:
+		return baseTypeName(expr.X)
+	case *ast.ParenExpr:
+		return baseTypeName(expr.X)
+	}
+	return ""
+}
+
+// format prints a syntax node on a single line
+func format(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// formatOptional prints an expression that may be absent, such as an inferred variable type
+func formatOptional(fset *token.FileSet, expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+	return format(fset, stripNames(expr))
+}
+
+// SourceFiles returns the Go files Load reads for the module in dir, so that
+// callers can fetch them at once
+func SourceFiles(files []string, dir string) []string {
+	dir = path.Clean(dir)
+	modules := make(map[string]bool)
+	for _, file := range files {
+		if path.Base(file) == "go.mod" {
+			modules[path.Dir(file)] = true
+		}
+	}
+
+	var sources []string
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if pkgDir := path.Dir(file); moduleOf(pkgDir, modules) == dir && !skipped(pkgDir) {
+			sources = append(sources, file)
+		}
+	}
+	return sources
+}
+
+// moduleOf returns the directory of the innermost module containing dir
+func moduleOf(dir string, modules map[string]bool) string {
+	for {
+		if modules[dir] {
+			return dir
+		}
+		if dir == "." || dir == "/" {
+			return "."
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// skipped reports whether a package directory is not part of the public API
+func skipped(dir string) bool {
+	for _, part := range strings.Split(dir, "/") {
+		if part == "internal" || part == "testdata" || part == "vendor" ||
+			(part != "." && (strings.HasPrefix(part, ".") || strings.HasPrefix(part, "_"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the union of the keys of two maps in order
+func sortedKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,184 @@
+package apicompat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// load builds the API of an in-memory module rooted at dir
+func load(t *testing.T, dir string, sources map[string]string) API {
+	t.Helper()
+	files := make([]string, 0, len(sources))
+	for file := range sources {
+		files = append(files, file)
+	}
+	api, err := Load(files, dir, func(file string) ([]byte, error) {
+		content, ok := sources[file]
+		if !ok {
+			return nil, fmt.Errorf("missing %s", file)
+		}
+		return []byte(content), nil
+	})
+	require.NoError(t, err)
+	return api
+}
+
+func TestLoad(t *testing.T) {
+	api := load(t, ".", map[string]string{
+		"go.mod": "module example.com/app\n",
+		"client/client.go": `package client
+
+// Client talks to the server
+type Client struct {
+	URL     string
+	timeout int
+	*Options
+}
+
+type Options struct{ Retries int }
+
+type Handler interface {
+	Handle(ctx Context, name string) (result string, err error)
+}
+
+type List[T any] []T
+
+type ID = string
+
+const (
+	KindA Kind = iota
+	KindB
+	internalKind
+)
+
+var Default = New("")
+
+func New(url string) *Client { return &Client{URL: url} }
+
+func (c *Client) Do(method, path string) error { return nil }
+
+func (l List[T]) Len() int { return len(l) }
+
+func (c *client) Hidden() {}
+
+func helper() {}
+`,
+		"client/client_test.go":       "package client\n\nfunc TestHelper() {}\n",
+		"internal/secret/secret.go":   "package secret\n\nfunc Key() string { return \"\" }\n",
+		"cmd/app/main.go":             "package main\n\nfunc Run() {}\n",
+		"tools/go.mod":                "module example.com/app/tools\n",
+		"tools/gen/gen.go":            "package gen\n\nfunc Generate() {}\n",
+		"testdata/fixture/fixture.go": "package fixture\n\nfunc Fixture() {}\n",
+	})
+
+	assert.Equal(t, API{
+		"client": {
+			"type Client":           "struct",
+			"field Client.URL":      "string",
+			"field Client.Options":  "*Options",
+			"type Options":          "struct",
+			"field Options.Retries": "int",
+			"type Handler":          "interface { Handle(Context, string) (string, error) }",
+			"type List":             "[T any] []T",
+			"type ID":               "= string",
+			"const KindA":           "Kind",
+			"const KindB":           "Kind",
+			"var Default":           "",
+			"func New":              "func New(string) *Client",
+			"method Client.Do":      "func (*Client) Do(string, string) error",
+			"method List.Len":       "func (List[T]) Len() int",
+		},
+	}, api)
+
+	tools := load(t, "tools", map[string]string{
+		"go.mod":           "module example.com/app\n",
+		"tools/go.mod":     "module example.com/app/tools\n",
+		"tools/gen/gen.go": "package gen\n\nfunc Generate() {}\n",
+	})
+	assert.Equal(t, API{"tools/gen": {"func Generate": "func Generate()"}}, tools)
+}
+
+func TestSourceFiles(t *testing.T) {
+	files := []string{
+		"go.mod", "api.go", "api_test.go", "README.md",
+		"client/client.go", "internal/secret/secret.go", "testdata/fixture.go",
+		"tools/go.mod", "tools/gen.go",
+	}
+	assert.Equal(t, []string{"api.go", "client/client.go"}, SourceFiles(files, "."))
+	assert.Equal(t, []string{"tools/gen.go"}, SourceFiles(files, "tools"))
+}
+
+func TestCompare(t *testing.T) {
+	before := load(t, ".", map[string]string{
+		"go.mod": "module example.com/app\n",
+		"api/api.go": `package api
+
+type Config struct {
+	Name string
+}
+
+type Store interface {
+	Get(key string) string
+}
+
+func Parse(input string) (Config, error) { return Config{}, nil }
+
+func Validate(c Config) bool { return true }
+
+func Close() {}
+`,
+		"legacy/legacy.go": "package legacy\n\nfunc Old() {}\n",
+	})
+	after := load(t, ".", map[string]string{
+		"go.mod": "module example.com/app\n",
+		"api/api.go": `package api
+
+type Config struct {
+	Name    string
+	Verbose bool
+}
+
+type Store interface {
+	Get(name string) string
+	Set(name, value string)
+}
+
+func Parse(data string) (cfg Config, err error) { return Config{}, nil }
+
+func Validate(c *Config) bool { return true }
+
+func Open() {}
+`,
+		"v2/v2.go": "package v2\n\nfunc New() {}\n",
+	})
+
+	changes := Compare(before, after)
+	var described []string
+	for _, change := range changes {
+		described = append(described, fmt.Sprintf("%s compatible=%t", change, change.Compatible))
+	}
+	assert.Equal(t, []string{
+		"api: added field Config.Verbose compatible=true",
+		"api: removed func Close compatible=false",
+		"api: added func Open compatible=true",
+		"api: changed func Validate compatible=false",
+		"api: changed type Store compatible=false",
+		"legacy: removed package compatible=false",
+		"v2: added package compatible=true",
+	}, described)
+
+	incompatible := Incompatible(changes)
+	require.Len(t, incompatible, 4)
+	assert.Equal(t, types.APIChange{
+		Package: "api",
+		Name:    "func Validate",
+		Kind:    types.APIChangeChanged,
+		Before:  "func Validate(Config) bool",
+		After:   "func Validate(*Config) bool",
+	}, incompatible[1])
+}
@@ -0,0 +1,302 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nguyendkn/git-generator/internal/conventional"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Supported changelog formats
+const (
+	FormatKeepAChangelog = "keepachangelog"
+	FormatConventional   = "conventional"
+)
+
+// UnreleasedTitle is the heading of changes that are not part of a release yet
+const UnreleasedTitle = "Unreleased"
+
+// keepAChangelogHeader is the preamble recommended by keepachangelog.com
+const keepAChangelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// releaseHeadingPattern matches a release heading and captures its version,
+// e.g. "## [1.2.0] - 2024-01-02", "## [1.2.0](url) (2024-01-02)" or "## v1.2.0"
+var releaseHeadingPattern = regexp.MustCompile(`^##\s+\[?v?([^\]\s()]+)`)
+
+// Release is a version and the commits it introduced
+type Release struct {
+	Version     string              // Version without the "v" prefix, "" for unreleased changes
+	Tag         string              // Tag name, "" for unreleased changes
+	PreviousTag string              // Tag of the previous release, "" for the first one
+	Date        time.Time           // Release date, zero for unreleased changes
+	Commits     []*types.CommitInfo // Commits since the previous release, newest first
+}
+
+// Title returns the version shown in the release heading
+func (r Release) Title() string {
+	if r.Version == "" {
+		return UnreleasedTitle
+	}
+	return r.Version
+}
+
+// group is a section of a release listing commits of the given types
+type group struct {
+	title string
+	types []types.CommitType
+}
+
+// keepAChangelogGroups follow the Keep a Changelog section order; types that do
+// not affect users (docs, chore, ci, ...) are left out
+var keepAChangelogGroups = []group{
+	{"Added", []types.CommitType{types.CommitTypeFeat}},
+	{"Changed", []types.CommitType{types.CommitTypePerf, types.CommitTypeRefactor}},
+	{"Removed", []types.CommitType{types.CommitTypeRevert}},
+	{"Fixed", []types.CommitType{types.CommitTypeFix}},
+}
+
+// conventionalGroups follow the conventional-changelog preset
+var conventionalGroups = []group{
+	{"Features", []types.CommitType{types.CommitTypeFeat}},
+	{"Bug Fixes", []types.CommitType{types.CommitTypeFix}},
+	{"Performance Improvements", []types.CommitType{types.CommitTypePerf}},
+	{"Reverts", []types.CommitType{types.CommitTypeRevert}},
+}
+
+// Renderer renders releases as Markdown
+type Renderer struct {
+	config types.ChangelogConfig
+}
+
+// NewRenderer creates a new changelog renderer
+func NewRenderer(config types.ChangelogConfig) *Renderer {
+	if config.Format == "" {
+		config.Format = FormatKeepAChangelog
+	}
+	return &Renderer{config: config}
+}
+
+// Document renders a complete changelog file
+func (r *Renderer) Document(releases []Release) string {
+	header := keepAChangelogHeader
+	if r.config.Format == FormatConventional {
+		header = "# Changelog\n"
+	}
+	if len(releases) == 0 {
+		return header
+	}
+	return header + "\n" + r.Render(releases)
+}
+
+// Render renders the given releases, newest first, without the file header
+func (r *Renderer) Render(releases []Release) string {
+	blocks := make([]string, 0, len(releases))
+	for _, release := range releases {
+		blocks = append(blocks, r.RenderRelease(release))
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// RenderRelease renders a single release: its heading, breaking change
+// callouts and the grouped entries
+func (r *Renderer) RenderRelease(release Release) string {
+	var content strings.Builder
+	content.WriteString(r.heading(release) + "\n")
+
+	groups := keepAChangelogGroups
+	bullet := "-"
+	if r.config.Format == FormatConventional {
+		groups = conventionalGroups
+		bullet = "*"
+	}
+
+	parsed := make([]*conventional.Commit, len(release.Commits))
+	var breaking []string
+	for i, commit := range release.Commits {
+		parsed[i] = conventional.Parse(commit.Subject + "\n\n" + commit.Body)
+		if !parsed[i].IsConventional {
+			continue
+		}
+		for _, note := range parsed[i].BreakingChanges() {
+			breaking = append(breaking, fmt.Sprintf("%s %s%s", bullet, scopePrefix(parsed[i].Scope), note))
+		}
+	}
+
+	if len(breaking) > 0 {
+		content.WriteString("\n### ⚠ BREAKING CHANGES\n\n")
+		content.WriteString(strings.Join(breaking, "\n") + "\n")
+	}
+
+	for _, g := range groups {
+		var entries []string
+		for i, commit := range release.Commits {
+			if parsed[i].IsConventional && containsType(g.types, parsed[i].Type) {
+				entries = append(entries, bullet+" "+r.entry(parsed[i], commit))
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		content.WriteString(fmt.Sprintf("\n### %s\n\n", g.title))
+		content.WriteString(strings.Join(entries, "\n") + "\n")
+	}
+
+	return content.String()
+}
+
+// Prepend adds the releases that are not yet in an existing changelog above
+// its newest release. The unreleased section is always regenerated. An empty
+// existing changelog results in a new document.
+func (r *Renderer) Prepend(existing string, releases []Release) string {
+	if strings.TrimSpace(existing) == "" {
+		return r.Document(releases)
+	}
+
+	lines := removeUnreleased(strings.Split(strings.ReplaceAll(existing, "\r\n", "\n"), "\n"))
+	present := ExistingVersions(strings.Join(lines, "\n"))
+
+	var fresh []Release
+	for _, release := range releases {
+		if release.Version != "" && present[release.Version] {
+			continue
+		}
+		fresh = append(fresh, release)
+	}
+	if len(fresh) == 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	insert := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			insert = i
+			break
+		}
+	}
+
+	head := strings.TrimRight(strings.Join(lines[:insert], "\n"), "\n")
+	tail := strings.Join(lines[insert:], "\n")
+
+	result := r.Render(fresh)
+	if head != "" {
+		result = head + "\n\n" + result
+	}
+	if strings.TrimSpace(tail) != "" {
+		result += "\n" + tail
+	}
+	return result
+}
+
+// ExistingVersions returns the versions that have a release heading in a changelog
+func ExistingVersions(content string) map[string]bool {
+	versions := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		if matches := releaseHeadingPattern.FindStringSubmatch(line); matches != nil {
+			versions[matches[1]] = true
+		}
+	}
+	return versions
+}
+
+// heading returns the release heading, linked to the comparison with the
+// previous release when a compare URL is configured
+func (r *Renderer) heading(release Release) string {
+	title := release.Title()
+	if url := r.compareURL(release); url != "" {
+		title = fmt.Sprintf("[%s](%s)", title, url)
+	} else if r.config.Format != FormatConventional {
+		title = "[" + title + "]"
+	}
+
+	if release.Date.IsZero() {
+		return "## " + title
+	}
+	date := release.Date.Format("2006-01-02")
+	if r.config.Format == FormatConventional {
+		return fmt.Sprintf("## %s (%s)", title, date)
+	}
+	return fmt.Sprintf("## %s - %s", title, date)
+}
+
+// compareURL fills the compare URL template for a release, or returns "" when
+// there is no template or no previous release
+func (r *Renderer) compareURL(release Release) string {
+	if r.config.CompareURL == "" || release.PreviousTag == "" {
+		return ""
+	}
+	to := release.Tag
+	if to == "" {
+		to = "HEAD"
+	}
+	return strings.NewReplacer("{from}", release.PreviousTag, "{to}", to).Replace(r.config.CompareURL)
+}
+
+// entry renders a commit as "**scope:** description (hash) by author"
+func (r *Renderer) entry(parsed *conventional.Commit, commit *types.CommitInfo) string {
+	line := scopePrefix(parsed.Scope) + parsed.Description
+
+	if short := shortHash(commit.Hash); short != "" {
+		if r.config.CommitURL != "" {
+			url := strings.NewReplacer("{hash}", commit.Hash, "{short}", short).Replace(r.config.CommitURL)
+			line += fmt.Sprintf(" ([%s](%s))", short, url)
+		} else {
+			line += fmt.Sprintf(" (%s)", short)
+		}
+	}
+
+	if r.config.Authors && commit.Author != "" {
+		line += " by " + commit.Author
+	}
+	return line
+}
+
+// removeUnreleased drops the unreleased section, up to the next release heading
+func removeUnreleased(lines []string) []string {
+	result := make([]string, 0, len(lines))
+	skipping := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			matches := releaseHeadingPattern.FindStringSubmatch(line)
+			skipping = matches != nil && strings.EqualFold(matches[1], UnreleasedTitle)
+		}
+		if !skipping {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// scopePrefix returns the bold scope prefix of an entry, if any
+func scopePrefix(scope string) string {
+	if scope == "" {
+		return ""
+	}
+	return "**" + scope + ":** "
+}
+
+// shortHash abbreviates a commit hash to 7 characters
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// containsType reports whether commitType is one of commitTypes
+func containsType(commitTypes []types.CommitType, commitType string) bool {
+	for _, t := range commitTypes {
+		if string(t) == commitType {
+			return true
+		}
+	}
+	return false
+}
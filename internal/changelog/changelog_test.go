@@ -0,0 +1,141 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func sampleReleases() []Release {
+	return []Release{
+		{
+			Commits: []*types.CommitInfo{
+				{Hash: "4444444444", Subject: "docs: update readme", Author: "Ann"},
+				{Hash: "3333333333", Subject: "perf(diff): cache results", Author: "Bob"},
+			},
+			PreviousTag: "v1.1.0",
+		},
+		{
+			Version:     "1.1.0",
+			Tag:         "v1.1.0",
+			PreviousTag: "v1.0.0",
+			Date:        time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC),
+			Commits: []*types.CommitInfo{
+				{Hash: "2222222222", Subject: "fix: handle empty repo", Author: "Bob"},
+				{Hash: "1111111111", Subject: "feat(api)!: drop v1 endpoints", Body: "BREAKING CHANGE: /v1 is gone", Author: "Ann"},
+				{Hash: "0000000000", Subject: "wip", Author: "Ann"},
+			},
+		},
+	}
+}
+
+func TestRenderKeepAChangelog(t *testing.T) {
+	renderer := NewRenderer(types.ChangelogConfig{
+		CommitURL:  "https://example.com/commit/{hash}",
+		CompareURL: "https://example.com/compare/{from}...{to}",
+		Authors:    true,
+	})
+
+	expected := `## [Unreleased](https://example.com/compare/v1.1.0...HEAD)
+
+### Changed
+
+- **diff:** cache results ([3333333](https://example.com/commit/3333333333)) by Bob
+
+## [1.1.0](https://example.com/compare/v1.0.0...v1.1.0) - 2024-03-02
+
+### ⚠ BREAKING CHANGES
+
+- **api:** /v1 is gone
+
+### Added
+
+- **api:** drop v1 endpoints ([1111111](https://example.com/commit/1111111111)) by Ann
+
+### Fixed
+
+- handle empty repo ([2222222](https://example.com/commit/2222222222)) by Bob
+`
+	assert.Equal(t, expected, renderer.Render(sampleReleases()))
+}
+
+func TestRenderConventional(t *testing.T) {
+	renderer := NewRenderer(types.ChangelogConfig{Format: FormatConventional})
+
+	expected := `## 1.1.0 (2024-03-02)
+
+### ⚠ BREAKING CHANGES
+
+* **api:** /v1 is gone
+
+### Features
+
+* **api:** drop v1 endpoints (1111111)
+
+### Bug Fixes
+
+* handle empty repo (2222222)
+`
+	assert.Equal(t, expected, renderer.RenderRelease(sampleReleases()[1]))
+}
+
+func TestPrepend(t *testing.T) {
+	renderer := NewRenderer(types.ChangelogConfig{})
+	releases := sampleReleases()
+
+	existing := `# Changelog
+
+Hand-written intro.
+
+## [Unreleased]
+
+- stale entry
+
+## [1.1.0] - 2024-03-02
+
+Edited by hand.
+`
+	releases[0].Version = "1.2.0"
+	releases[0].Tag = "v1.2.0"
+	releases[0].Date = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	expected := `# Changelog
+
+Hand-written intro.
+
+## [1.2.0] - 2024-04-01
+
+### Changed
+
+- **diff:** cache results (3333333)
+
+## [1.1.0] - 2024-03-02
+
+Edited by hand.
+`
+	assert.Equal(t, expected, renderer.Prepend(existing, releases))
+
+	// Nothing new to add leaves the changelog untouched
+	assert.Equal(t, expected, renderer.Prepend(expected, releases))
+}
+
+func TestPrependEmpty(t *testing.T) {
+	renderer := NewRenderer(types.ChangelogConfig{})
+	document := renderer.Prepend("", sampleReleases()[1:])
+
+	assert.Contains(t, document, "# Changelog\n\nAll notable changes")
+	assert.Contains(t, document, "\n## [1.1.0] - 2024-03-02\n")
+}
+
+func TestExistingVersions(t *testing.T) {
+	content := "# Changelog\n## [Unreleased]\n## [1.2.0](url) (2024-01-02)\n## v1.1.0\n## 1.0.0-rc.1 - 2023-12-01\n### Added\n"
+	assert.Equal(t, map[string]bool{
+		"Unreleased": true,
+		"1.2.0":      true,
+		"1.1.0":      true,
+		"1.0.0-rc.1": true,
+	}, ExistingVersions(content))
+}
@@ -0,0 +1,76 @@
+package changelog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/internal/version"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Service collects releases from the repository's version tags
+type Service struct {
+	gitService     *git.Service
+	versionService *version.Service
+}
+
+// NewService creates a new changelog service
+func NewService(gitService *git.Service, versionService *version.Service) *Service {
+	return &Service{
+		gitService:     gitService,
+		versionService: versionService,
+	}
+}
+
+// Releases returns the releases of the repository, newest first, with the
+// commits between consecutive version tags. Commits after the latest tag are
+// returned as the release of next when it is given (e.g. while tagging), or as
+// unreleased changes otherwise.
+func (s *Service) Releases(next *types.SemanticVersion) ([]Release, error) {
+	tags, err := s.versionService.GetVersionTags()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := s.versionService.Paths()
+	var releases []Release
+	var previous *types.GitTag
+	for _, tag := range tags {
+		release := Release{
+			Version: tag.Version.String(),
+			Tag:     tag.Name,
+			Date:    tag.Date,
+		}
+		from := ""
+		if previous != nil {
+			from = previous.Hash
+			release.PreviousTag = previous.Name
+		}
+		if release.Commits, err = s.gitService.GetCommitsInRangeForPaths(from, tag.Hash, paths); err != nil {
+			return nil, fmt.Errorf("failed to get commits for %s: %w", tag.Name, err)
+		}
+		releases = append([]Release{release}, releases...)
+		previous = tag
+	}
+
+	head := Release{}
+	from := ""
+	if previous != nil {
+		from = previous.Hash
+		head.PreviousTag = previous.Name
+	}
+	if head.Commits, err = s.gitService.GetCommitsInRangeForPaths(from, "HEAD", paths); err != nil {
+		return nil, fmt.Errorf("failed to get unreleased commits: %w", err)
+	}
+
+	if next != nil {
+		head.Version = next.String()
+		head.Tag = s.versionService.TagName(next)
+		head.Date = time.Now()
+	}
+	if next != nil || len(head.Commits) > 0 {
+		releases = append([]Release{head}, releases...)
+	}
+	return releases, nil
+}
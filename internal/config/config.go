@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"text/template"
 
 	"github.com/nguyendkn/git-generator/pkg/types"
 	"github.com/spf13/viper"
@@ -82,6 +84,8 @@ func (m *Manager) setDefaults() {
 	// Git defaults
 	viper.SetDefault("git.max_diff_size", 10000)
 	viper.SetDefault("git.include_staged", true)
+	viper.SetDefault("git.backend", "exec")
+	viper.SetDefault("git.remote", "origin")
 	viper.SetDefault("git.ignore_files", []string{
 		"*.log",
 		"*.tmp",
@@ -97,18 +101,33 @@ func (m *Manager) setDefaults() {
 	viper.SetDefault("output.style", "conventional")
 	viper.SetDefault("output.max_lines", 100)
 	viper.SetDefault("output.dry_run", false)
+	viper.SetDefault("output.repair_attempts", 2)
+
+	// Trailer defaults: Jira-style keys (PROJ-123) and issue numbers after a
+	// feature, fix or issue prefix (feature/123-foo); release/2024 is not an issue
+	viper.SetDefault("trailers.issue_patterns", []string{
+		`\b([A-Z][A-Z0-9]+-\d+)\b`,
+		`(?:^|/)(?:feature|feat|fix|bugfix|hotfix|issues?)/(\d+)(?:[-_/]|$)`,
+	})
+	viper.SetDefault("trailers.issue_token", "Refs")
+	viper.SetDefault("trailers.sign_off", false)
+
+	// Changelog defaults
+	viper.SetDefault("changelog.file", "CHANGELOG.md")
+	viper.SetDefault("changelog.format", "keepachangelog")
+	viper.SetDefault("changelog.authors", false)
+
+	// Versioning defaults
+	viper.SetDefault("versioning.scheme", "semver")
+	viper.SetDefault("versioning.calver_format", "YYYY.0M.MICRO")
 }
 
 // validateConfig validates the loaded configuration
 func (m *Manager) validateConfig(config *types.Config) error {
-	// Validate Gemini config
+	// Validate Gemini config; the API key is only required by commands that use AI
 	if config.Gemini.APIKey == "" {
 		// Try to get from environment
-		if apiKey := os.Getenv("GEMINI_API_KEY"); apiKey != "" {
-			config.Gemini.APIKey = apiKey
-		} else {
-			return fmt.Errorf("gemini API key is required (set GEMINI_API_KEY environment variable or add to config file)")
-		}
+		config.Gemini.APIKey = os.Getenv("GEMINI_API_KEY")
 	}
 
 	if config.Gemini.Temperature < 0 || config.Gemini.Temperature > 2 {
@@ -124,20 +143,155 @@ func (m *Manager) validateConfig(config *types.Config) error {
 		return fmt.Errorf("max_diff_size must be positive")
 	}
 
+	validBackends := map[string]bool{
+		"exec":   true,
+		"go-git": true,
+	}
+	if !validBackends[config.Git.Backend] {
+		return fmt.Errorf("invalid git backend: %s (must be one of: exec, go-git)", config.Git.Backend)
+	}
+
 	// Validate Output config
 	validStyles := map[string]bool{
 		"conventional": true,
+		"gitmoji":      true,
+		"angular":      true,
+		"traditional":  true,
+		"minimal":      true,
+		"template":     true,
 		"simple":       true,
 		"detailed":     true,
 	}
 	if !validStyles[config.Output.Style] {
-		return fmt.Errorf("invalid output style: %s (must be one of: conventional, simple, detailed)", config.Output.Style)
+		return fmt.Errorf("invalid output style: %s (must be one of: conventional, gitmoji, angular, traditional, minimal, template)", config.Output.Style)
+	}
+	if config.Output.Style == "template" && config.Output.Template.Header == "" {
+		return fmt.Errorf("output style template needs output.template.header")
+	}
+	if config.Output.Template.Header != "" {
+		if _, err := template.New("header").Parse(config.Output.Template.Header); err != nil {
+			return fmt.Errorf("invalid template header %q: %w", config.Output.Template.Header, err)
+		}
+	}
+	if config.Output.Template.Pattern != "" {
+		if _, err := regexp.Compile(config.Output.Template.Pattern); err != nil {
+			return fmt.Errorf("invalid template pattern %q: %w", config.Output.Template.Pattern, err)
+		}
 	}
 
 	if config.Output.MaxLines <= 0 {
 		return fmt.Errorf("max_lines must be positive")
 	}
 
+	if config.Output.RepairAttempts < 0 {
+		return fmt.Errorf("repair_attempts must not be negative")
+	}
+
+	// Validate Trailer config
+	for _, pattern := range config.Trailers.IssuePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid issue pattern %q: %w", pattern, err)
+		}
+	}
+
+	// Validate Changelog config
+	validFormats := map[string]bool{
+		"keepachangelog": true,
+		"conventional":   true,
+	}
+	if !validFormats[config.Changelog.Format] {
+		return fmt.Errorf("invalid changelog format: %s (must be one of: keepachangelog, conventional)", config.Changelog.Format)
+	}
+
+	// Validate Versioning config
+	switch config.Versioning.Scheme {
+	case "semver":
+	case "calver":
+		if _, err := types.ParseCalendarLayout(config.Versioning.CalVerFormat); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid versioning scheme: %s (must be one of: semver, calver)", config.Versioning.Scheme)
+	}
+
+	// Validate commit rules
+	if err := validateCommitRules(config.Validation); err != nil {
+		return err
+	}
+
+	// Validate version files, including those of modules
+	if err := validateVersionFiles(config.VersionFiles); err != nil {
+		return err
+	}
+	for _, module := range config.Modules {
+		if err := validateVersionFiles(module.VersionFiles); err != nil {
+			return fmt.Errorf("module %s: %w", module.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// validateVersionFiles checks the paths and patterns of version files
+func validateVersionFiles(files []types.VersionFile) error {
+	for _, file := range files {
+		if file.Path == "" {
+			return fmt.Errorf("version file path is required")
+		}
+		if file.Pattern != "" {
+			if _, err := regexp.Compile(file.Pattern); err != nil {
+				return fmt.Errorf("invalid pattern for version file %s: %w", file.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCommitRules checks the severities and patterns of the commit rules
+func validateCommitRules(rules types.CommitRulesConfig) error {
+	validSeverities := map[string]bool{
+		"error":   true,
+		"warning": true,
+		"off":     true,
+	}
+	for rule, severity := range rules.Rules {
+		if !validSeverities[severity] {
+			return fmt.Errorf("invalid severity for rule %s: %s (must be one of: error, warning, off)", rule, severity)
+		}
+	}
+
+	if rules.TicketPattern != "" {
+		if _, err := regexp.Compile(rules.TicketPattern); err != nil {
+			return fmt.Errorf("invalid ticket pattern %q: %w", rules.TicketPattern, err)
+		}
+	}
+
+	validFields := map[string]bool{
+		"":        true,
+		"subject": true,
+		"body":    true,
+		"footer":  true,
+		"message": true,
+	}
+	seen := make(map[string]bool)
+	for _, rule := range rules.Custom {
+		if rule.ID == "" {
+			return fmt.Errorf("custom rule id is required")
+		}
+		if seen[rule.ID] {
+			return fmt.Errorf("duplicate custom rule: %s", rule.ID)
+		}
+		seen[rule.ID] = true
+		if _, err := regexp.Compile(rule.Pattern); err != nil || rule.Pattern == "" {
+			return fmt.Errorf("invalid pattern for custom rule %s: %q", rule.ID, rule.Pattern)
+		}
+		if !validFields[rule.Field] {
+			return fmt.Errorf("invalid field for custom rule %s: %s (must be one of: subject, body, footer, message)", rule.ID, rule.Field)
+		}
+		if rule.Severity != "" && rule.Severity != "error" && rule.Severity != "warning" {
+			return fmt.Errorf("invalid severity for custom rule %s: %s (must be one of: error, warning)", rule.ID, rule.Severity)
+		}
+	}
 	return nil
 }
 
@@ -208,6 +362,8 @@ func (m *Manager) CreateDefaultConfig() error {
 git:
   max_diff_size: 10000
   include_staged: true
+  backend: "exec" # exec (git binary) or go-git (pure Go, no git binary required)
+  remote: "origin" # remote used by tag --push
   ignore_files:
     - "*.log"
     - "*.tmp"
@@ -219,9 +375,80 @@ git:
     - "dist/*"
 
 output:
-  style: "conventional"
+  style: "conventional" # conventional, gitmoji, angular, traditional, minimal or template
   max_lines: 100
   dry_run: false
+  repair_attempts: 2 # Times the model is asked to fix validation errors, 0 disables
+  # Header template of the template style, with the fields .Type, .Scope,
+  # .Description, .Breaking and .Emoji
+  # template:
+  #   header: "[{{.Type}}] {{.Description}}"
+  #   pattern: "" # Regex with named groups parsing headers; derived from simple headers
+  #   guidance: [] # Additional instructions for the model
+  #   subject_only: false # Messages without a body
+
+trailers:
+  # Issue keys are extracted from the branch name (feature/PROJ-123-foo -> Refs: PROJ-123)
+  issue_patterns:
+    - '\b([A-Z][A-Z0-9]+-\d+)\b'
+    - '(?:^|/)(?:feature|feat|fix|bugfix|hotfix|issues?)/(\d+)(?:[-_/]|$)'
+  issue_token: "Refs" # Refs or Closes
+  sign_off: false
+  team: {} # alias: "Name <email>", used with --co-author alias
+  custom: [] # e.g. "Reviewed-by: Jane <jane@example.com>"
+
+changelog:
+  file: "CHANGELOG.md"
+  format: "keepachangelog" # keepachangelog or conventional
+  commit_url: "" # e.g. "https://github.com/owner/repo/commit/{hash}"
+  compare_url: "" # e.g. "https://github.com/owner/repo/compare/{from}...{to}"
+  authors: false
+
+versioning:
+  scheme: "semver" # semver or calver
+  calver_format: "YYYY.0M.MICRO" # used by calver, e.g. YY.0M.MICRO or YYYY.0M.0D
+
+# Commit message rules, applied on top of a commitlint configuration
+validation:
+  rules: {} # severity per rule type or ID, e.g. imperative_mood: off, GG101: warning
+  require_body_for: [] # e.g. ["feat", "fix"]
+  forbidden_words: [] # e.g. ["WIP", "TODO"]
+  ticket_pattern: "" # e.g. '[A-Z]+-\d+'
+  scopes: [] # allowed scopes, empty allows any
+  detected_scopes: false # also allow the scopes of the scope detection rules
+  custom: []
+#  - id: "no-console"
+#    pattern: 'console\.log'
+#    field: "body" # subject, body, footer or message
+#    message: "Do not mention console.log"
+#  - id: "signed-off"
+#    pattern: '^Signed-off-by: '
+#    field: "footer"
+#    require: true
+#    severity: "warning"
+
+# Files whose version is bumped by the tag command, committed as chore(release): vX.Y.Z
+# Format and key are detected for *.json, *.yaml, pyproject.toml, Cargo.toml, VERSION,
+# VERSION.txt and *.go; any other file needs a format (and pattern for regex)
+version_files: []
+#  - path: "package.json"
+#  - path: "charts/app/Chart.yaml"
+#    key: "appVersion"
+#  - path: "pyproject.toml"
+#    key: "tool.poetry.version"
+#  - path: "Dockerfile"
+#    format: "regex"
+#    pattern: 'LABEL version="([^"]+)"'
+
+# Monorepo modules released separately with tag --module; go.work, go.mod
+# and package.json workspaces are discovered automatically
+modules: []
+#  - name: "api"
+#    path: "services/api"
+#    tag_prefix: "api/v"
+#    version_files:           # Relative to the module directory
+#      - path: "package.json"
+#    changelog: "CHANGELOG.md"  # Relative to the module directory
 `)
 
 	// Write the config file with explicit UTF-8 encoding
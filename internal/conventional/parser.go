@@ -0,0 +1,154 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BreakingChangeToken is the footer token that marks a breaking change
+const BreakingChangeToken = "BREAKING CHANGE"
+
+// headerPattern matches "type(scope)!: description"
+var headerPattern = regexp.MustCompile(`^([A-Za-z][\w-]*)(?:\(([^()\r\n]*)\))?(!)?: (.*)$`)
+
+// footerPattern matches a footer line "Token: value" or "Token #value"
+var footerPattern = regexp.MustCompile(`^(BREAKING[ -]CHANGE|[\w][\w-]*)(: | #)(.*)$`)
+
+// Commit is a commit message parsed according to the Conventional Commits specification
+type Commit struct {
+	Header         string   // First line of the message
+	Type           string   // Commit type, e.g. feat; empty for non-conventional headers
+	Scope          string   // Optional scope
+	Breaking       bool     // Set by "!" in the header or a BREAKING CHANGE footer
+	Description    string   // Text after "type(scope): ", or the whole header if not conventional
+	Body           string   // Free-form body between header and footers
+	Footers        []Footer // Trailing footers in their original order
+	IsConventional bool     // Whether the header follows the type(scope): format
+}
+
+// Footer is a single "Token: value" or "Token #value" footer
+type Footer struct {
+	Token     string
+	Separator string // ": " or " #"
+	Value     string
+}
+
+// String formats the footer the way it appears in a commit message
+func (f Footer) String() string {
+	return f.Token + f.Separator + f.Value
+}
+
+// IsBreakingChange reports whether the footer describes a breaking change
+func (f Footer) IsBreakingChange() bool {
+	return f.Token == BreakingChangeToken || f.Token == "BREAKING-CHANGE"
+}
+
+// Parse parses a full commit message. It never fails; messages that do not
+// follow the specification are returned with IsConventional set to false and
+// the header as Description.
+func Parse(message string) *Commit {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	message = strings.Trim(message, "\n")
+
+	header, rest, _ := strings.Cut(message, "\n")
+	commit := ParseHeader(header)
+
+	paragraphs := splitParagraphs(rest)
+	if n := len(paragraphs); n > 0 {
+		if footers, ok := parseFooters(paragraphs[n-1]); ok {
+			commit.Footers = footers
+			paragraphs = paragraphs[:n-1]
+		}
+	}
+	commit.Body = strings.Join(paragraphs, "\n\n")
+
+	for _, footer := range commit.Footers {
+		if footer.IsBreakingChange() {
+			commit.Breaking = true
+		}
+	}
+
+	return commit
+}
+
+// ParseHeader parses only the first line of a commit message
+func ParseHeader(header string) *Commit {
+	header = strings.TrimSpace(header)
+	commit := &Commit{Header: header, Description: header}
+
+	matches := headerPattern.FindStringSubmatch(header)
+	if matches == nil {
+		return commit
+	}
+
+	commit.Type = strings.ToLower(matches[1])
+	commit.Scope = strings.TrimSpace(matches[2])
+	commit.Breaking = matches[3] == "!"
+	commit.Description = strings.TrimSpace(matches[4])
+	commit.IsConventional = true
+	return commit
+}
+
+// FooterValues returns the values of all footers with the given token (case-insensitive)
+func (c *Commit) FooterValues(token string) []string {
+	var values []string
+	for _, footer := range c.Footers {
+		if strings.EqualFold(footer.Token, token) {
+			values = append(values, footer.Value)
+		}
+	}
+	return values
+}
+
+// BreakingChanges returns the descriptions of breaking changes in the commit.
+// A "!" header without a BREAKING CHANGE footer uses the description itself.
+func (c *Commit) BreakingChanges() []string {
+	var changes []string
+	for _, footer := range c.Footers {
+		if footer.IsBreakingChange() {
+			changes = append(changes, footer.Value)
+		}
+	}
+	if len(changes) == 0 && c.Breaking {
+		changes = append(changes, c.Description)
+	}
+	return changes
+}
+
+// splitParagraphs splits text into blank-line separated paragraphs
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, strings.TrimRight(line, " \t"))
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, "\n"))
+	}
+	return paragraphs
+}
+
+// parseFooters parses a paragraph as footers. It fails if the first line is
+// not a footer; later lines that are not footers continue the previous value.
+func parseFooters(paragraph string) ([]Footer, bool) {
+	var footers []Footer
+	for _, line := range strings.Split(paragraph, "\n") {
+		if matches := footerPattern.FindStringSubmatch(line); matches != nil {
+			footers = append(footers, Footer{Token: matches[1], Separator: matches[2], Value: matches[3]})
+			continue
+		}
+		if len(footers) == 0 {
+			return nil, false
+		}
+		last := &footers[len(footers)-1]
+		last.Value += "\n" + line
+	}
+	return footers, len(footers) > 0
+}
@@ -0,0 +1,65 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		expectedType   string
+		expectedScope  string
+		expectedDesc   string
+		breaking       bool
+		isConventional bool
+	}{
+		{"type only", "fix: handle nil config", "fix", "", "handle nil config", false, true},
+		{"with scope", "feat(api): add pagination", "feat", "api", "add pagination", false, true},
+		{"breaking", "refactor(core)!: drop v1 API", "refactor", "core", "drop v1 API", true, true},
+		{"uppercase type", "Feat: add login", "feat", "", "add login", false, true},
+		{"not conventional", "Update README", "", "", "Update README", false, false},
+		{"missing space", "feat:add login", "", "", "feat:add login", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit := ParseHeader(tt.header)
+			assert.Equal(t, tt.expectedType, commit.Type)
+			assert.Equal(t, tt.expectedScope, commit.Scope)
+			assert.Equal(t, tt.expectedDesc, commit.Description)
+			assert.Equal(t, tt.breaking, commit.Breaking)
+			assert.Equal(t, tt.isConventional, commit.IsConventional)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	message := "feat(auth): add token refresh\n\n" +
+		"Tokens are refreshed before they expire.\n\n" +
+		"Second paragraph.\n\n" +
+		"Refs #42\n" +
+		"BREAKING CHANGE: sessions are no longer stored\n" +
+		"  in cookies\n" +
+		"Reviewed-by: Jane\n"
+
+	commit := Parse(message)
+
+	assert.True(t, commit.IsConventional)
+	assert.True(t, commit.Breaking)
+	assert.Equal(t, "Tokens are refreshed before they expire.\n\nSecond paragraph.", commit.Body)
+	assert.Len(t, commit.Footers, 3)
+	assert.Equal(t, "Refs #42", commit.Footers[0].String())
+	assert.Equal(t, []string{"Jane"}, commit.FooterValues("reviewed-by"))
+	assert.Equal(t, []string{"sessions are no longer stored\n  in cookies"}, commit.BreakingChanges())
+}
+
+func TestParseWithoutFooters(t *testing.T) {
+	commit := Parse("fix!: remove deprecated flag\n\nThe flag was unused.")
+
+	assert.Empty(t, commit.Footers)
+	assert.Equal(t, "The flag was unused.", commit.Body)
+	assert.Equal(t, []string{"remove deprecated flag"}, commit.BreakingChanges())
+}
@@ -0,0 +1,398 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// devNull is the path git uses for the missing side of added and deleted files
+const devNull = "/dev/null"
+
+// Parse parses git unified diff output (as produced by git diff, git show or
+// git log -p) into file changes. It never fails: unrecognized lines are kept in
+// the file's Content but otherwise ignored, so partial or malformed input still
+// yields as much information as possible. Language is left for the caller.
+func Parse(diffOutput string) []types.FileChange {
+	p := &parser{}
+	for _, line := range strings.SplitAfter(diffOutput, "\n") {
+		if line == "" {
+			continue
+		}
+		p.parseLine(line)
+	}
+	p.flush()
+	return p.files
+}
+
+// parser holds the state of a single Parse call
+type parser struct {
+	files   []types.FileChange
+	current *fileState
+}
+
+// fileState accumulates one file section
+type fileState struct {
+	change  types.FileChange
+	content strings.Builder
+	hunk    *types.DiffHunk
+	parents int // Number of parents for combined diffs, 1 for regular diffs
+
+	headerOld, headerNew string // Paths from the diff --git line
+	minusPath, plusPath  string // Paths from the ---/+++ lines
+	renameFrom, renameTo string
+	copyFrom, copyTo     string
+	added, deleted       bool
+}
+
+func (p *parser) parseLine(rawLine string) {
+	line := strings.TrimSuffix(strings.TrimSuffix(rawLine, "\n"), "\r")
+
+	if strings.HasPrefix(line, "diff --git ") || strings.HasPrefix(line, "diff --cc ") || strings.HasPrefix(line, "diff --combined ") {
+		p.flush()
+		p.current = newFileState(line)
+		p.current.content.WriteString(rawLine)
+		return
+	}
+
+	f := p.current
+	if f == nil {
+		return // Preamble such as commit headers
+	}
+	f.content.WriteString(rawLine)
+
+	if f.hunk != nil && f.parseHunkLine(line) {
+		return
+	}
+
+	if strings.HasPrefix(line, "@@") {
+		f.startHunk(line)
+		return
+	}
+
+	f.parseHeaderLine(line)
+}
+
+// flush finalizes the current file and appends it to the result
+func (p *parser) flush() {
+	if p.current == nil {
+		return
+	}
+	p.files = append(p.files, p.current.finish())
+	p.current = nil
+}
+
+// newFileState starts a file section from its diff header line
+func newFileState(line string) *fileState {
+	f := &fileState{parents: 1}
+
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		f.headerOld, f.headerNew = splitHeaderPaths(strings.TrimPrefix(line, "diff --git "))
+	case strings.HasPrefix(line, "diff --cc "):
+		f.headerNew = parsePath(strings.TrimPrefix(line, "diff --cc "))
+		f.headerOld = f.headerNew
+	default:
+		f.headerNew = parsePath(strings.TrimPrefix(line, "diff --combined "))
+		f.headerOld = f.headerNew
+	}
+
+	return f
+}
+
+// parseHeaderLine handles extended header lines between diff --git and the first hunk
+func (f *fileState) parseHeaderLine(line string) {
+	switch {
+	case strings.HasPrefix(line, "old mode "):
+		f.change.OldMode = strings.TrimPrefix(line, "old mode ")
+	case strings.HasPrefix(line, "new mode "):
+		f.change.NewMode = strings.TrimPrefix(line, "new mode ")
+	case strings.HasPrefix(line, "new file mode "):
+		f.added = true
+		f.change.NewMode = strings.TrimPrefix(line, "new file mode ")
+	case strings.HasPrefix(line, "deleted file mode "):
+		f.deleted = true
+		f.change.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+	case strings.HasPrefix(line, "similarity index "):
+		f.change.Similarity = parsePercent(strings.TrimPrefix(line, "similarity index "))
+	case strings.HasPrefix(line, "rename from "):
+		f.renameFrom = parsePath(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		f.renameTo = parsePath(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "copy from "):
+		f.copyFrom = parsePath(strings.TrimPrefix(line, "copy from "))
+	case strings.HasPrefix(line, "copy to "):
+		f.copyTo = parsePath(strings.TrimPrefix(line, "copy to "))
+	case strings.HasPrefix(line, "index "):
+		// index <old>..<new> <mode>: the mode is only present when unchanged
+		fields := strings.Fields(line)
+		if len(fields) == 3 && f.change.OldMode == "" && f.change.NewMode == "" {
+			f.change.OldMode = fields[2]
+			f.change.NewMode = fields[2]
+		}
+	case strings.HasPrefix(line, "--- "):
+		f.minusPath = parsePath(strings.TrimPrefix(line, "--- "))
+	case strings.HasPrefix(line, "+++ "):
+		f.plusPath = parsePath(strings.TrimPrefix(line, "+++ "))
+	case strings.HasPrefix(line, "Binary files ") && strings.HasSuffix(line, " differ"):
+		f.change.IsBinary = true
+		f.parseBinaryLine(strings.TrimSuffix(strings.TrimPrefix(line, "Binary files "), " differ"))
+	case line == "GIT binary patch":
+		f.change.IsBinary = true
+	}
+}
+
+// parseBinaryLine extracts paths from "Binary files <old> and <new> differ".
+// The old side is matched against the diff --git header first because
+// unquoted paths may themselves contain " and ".
+func (f *fileState) parseBinaryLine(paths string) {
+	var oldPath, newPath string
+	switch {
+	case strings.HasPrefix(paths, `"`):
+		var rest string
+		oldPath, rest = parseQuoted(paths)
+		newPath = parsePath(strings.TrimPrefix(rest, " and "))
+	case strings.HasPrefix(paths, "a/"+f.headerOld+" and "):
+		oldPath, newPath = "a/"+f.headerOld, parsePath(strings.TrimPrefix(paths, "a/"+f.headerOld+" and "))
+	case strings.HasPrefix(paths, devNull+" and "):
+		oldPath, newPath = devNull, parsePath(strings.TrimPrefix(paths, devNull+" and "))
+	default:
+		var found bool
+		if oldPath, newPath, found = strings.Cut(paths, " and "); !found {
+			return
+		}
+	}
+
+	if f.minusPath == "" {
+		f.minusPath = oldPath
+	}
+	if f.plusPath == "" {
+		f.plusPath = newPath
+	}
+}
+
+// startHunk parses a hunk header like "@@ -1,4 +1,6 @@ func main()"
+func (f *fileState) startHunk(line string) {
+	markers := 0
+	for markers < len(line) && line[markers] == '@' {
+		markers++
+	}
+	closing := strings.Index(line[markers:], " "+strings.Repeat("@", markers))
+	if markers < 2 || closing < 0 {
+		return
+	}
+
+	hunk := types.DiffHunk{}
+	ranges := strings.Fields(line[markers : markers+closing])
+	parents := 0
+	for _, r := range ranges {
+		if len(r) < 2 {
+			continue
+		}
+		start, count := parseRange(r[1:])
+		switch r[0] {
+		case '-':
+			if parents == 0 {
+				hunk.OldStart, hunk.OldLines = start, count
+			}
+			parents++
+		case '+':
+			hunk.NewStart, hunk.NewLines = start, count
+		}
+	}
+	if parents == 0 {
+		return
+	}
+
+	hunk.Section = strings.TrimSpace(line[markers+closing+1+markers:])
+	f.parents = parents
+	f.change.Hunks = append(f.change.Hunks, hunk)
+	f.hunk = &f.change.Hunks[len(f.change.Hunks)-1]
+}
+
+// parseHunkLine records a hunk body line; it returns false when the line does not belong to the hunk
+func (f *fileState) parseHunkLine(line string) bool {
+	if line == "" {
+		// Some tools strip the trailing space of empty context lines
+		f.hunk.Lines = append(f.hunk.Lines, line)
+		return true
+	}
+	if strings.HasPrefix(line, `\`) {
+		// "\ No newline at end of file"
+		f.hunk.Lines = append(f.hunk.Lines, line)
+		return true
+	}
+	if len(line) < f.parents {
+		f.hunk = nil
+		return false
+	}
+
+	prefix := line[:f.parents]
+	if strings.Trim(prefix, " +-") != "" {
+		f.hunk = nil
+		return false
+	}
+
+	switch {
+	case strings.Contains(prefix, "+"):
+		f.hunk.LinesAdded++
+		f.change.LinesAdded++
+	case strings.Contains(prefix, "-"):
+		f.hunk.LinesDeleted++
+		f.change.LinesDeleted++
+	}
+	f.hunk.Lines = append(f.hunk.Lines, line)
+	return true
+}
+
+// finish resolves paths and the change type once the whole section is read
+func (f *fileState) finish() types.FileChange {
+	change := f.change
+	change.Content = f.content.String()
+
+	oldPath := firstNonEmpty(f.renameFrom, f.copyFrom, strings.TrimPrefix(f.minusPath, "a/"), f.headerOld)
+	newPath := firstNonEmpty(f.renameTo, f.copyTo, strings.TrimPrefix(f.plusPath, "b/"), f.headerNew)
+	if oldPath == devNull {
+		oldPath = newPath
+	}
+	if newPath == devNull {
+		newPath = oldPath
+	}
+	change.Path = newPath
+
+	switch {
+	case f.copyFrom != "" || f.copyTo != "":
+		change.ChangeType = types.ChangeTypeCopied
+		change.OldPath = oldPath
+	case f.renameFrom != "" || f.renameTo != "":
+		change.ChangeType = types.ChangeTypeRenamed
+		change.OldPath = oldPath
+	case f.added || f.minusPath == devNull:
+		change.ChangeType = types.ChangeTypeAdded
+	case f.deleted || f.plusPath == devNull:
+		change.ChangeType = types.ChangeTypeDeleted
+	default:
+		change.ChangeType = types.ChangeTypeModified
+	}
+
+	return change
+}
+
+// splitHeaderPaths splits the "a/<old> b/<new>" part of a diff --git line.
+// Unquoted paths may contain spaces, so the split is ambiguous; git only writes
+// such headers when both sides are equal or when rename/copy lines follow, so
+// an equal split is preferred and rename/copy lines override the result anyway.
+func splitHeaderPaths(paths string) (string, string) {
+	if strings.HasPrefix(paths, `"`) {
+		oldPath, rest := parseQuoted(paths)
+		return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(parsePath(strings.TrimPrefix(rest, " ")), "b/")
+	}
+
+	if idx := strings.Index(paths, ` "`); idx >= 0 && strings.HasSuffix(paths, `"`) {
+		newPath, _ := parseQuoted(paths[idx+1:])
+		return strings.TrimPrefix(paths[:idx], "a/"), strings.TrimPrefix(newPath, "b/")
+	}
+
+	if len(paths)%2 == 1 {
+		mid := len(paths) / 2
+		oldPath, newPath := strings.TrimPrefix(paths[:mid], "a/"), strings.TrimPrefix(paths[mid+1:], "b/")
+		if paths[mid] == ' ' && oldPath == newPath {
+			return oldPath, newPath
+		}
+	}
+
+	if idx := strings.LastIndex(paths, " b/"); idx >= 0 {
+		return strings.TrimPrefix(paths[:idx], "a/"), paths[idx+len(" b/"):]
+	}
+	if idx := strings.Index(paths, " "); idx >= 0 {
+		return paths[:idx], paths[idx+1:]
+	}
+	return paths, paths
+}
+
+// parsePath parses a path from a header line, unquoting it if needed.
+// Unquoted paths in ---/+++ lines may be followed by a tab and a timestamp.
+func parsePath(value string) string {
+	if strings.HasPrefix(value, `"`) {
+		path, _ := parseQuoted(value)
+		return path
+	}
+	if idx := strings.Index(value, "\t"); idx >= 0 {
+		value = value[:idx]
+	}
+	return value
+}
+
+// parseQuoted decodes a C-style quoted path as written by git (core.quotePath)
+// and returns it together with the remaining input after the closing quote
+func parseQuoted(value string) (string, string) {
+	var path []byte
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"':
+			return string(path), value[i+1:]
+		case c != '\\' || i+1 >= len(value):
+			path = append(path, c)
+		default:
+			i++
+			switch e := value[i]; e {
+			case 'a':
+				path = append(path, '\a')
+			case 'b':
+				path = append(path, '\b')
+			case 'f':
+				path = append(path, '\f')
+			case 'n':
+				path = append(path, '\n')
+			case 'r':
+				path = append(path, '\r')
+			case 't':
+				path = append(path, '\t')
+			case 'v':
+				path = append(path, '\v')
+			case '0', '1', '2', '3':
+				if i+2 < len(value) && isOctal(value[i+1]) && isOctal(value[i+2]) {
+					path = append(path, (e-'0')<<6|(value[i+1]-'0')<<3|(value[i+2]-'0'))
+					i += 2
+				} else {
+					path = append(path, e)
+				}
+			default:
+				path = append(path, e)
+			}
+		}
+	}
+	return string(path), ""
+}
+
+// parseRange parses "start,count" from a hunk header; count defaults to 1
+func parseRange(value string) (int, int) {
+	startText, countText, hasCount := strings.Cut(value, ",")
+	start, _ := strconv.Atoi(startText)
+	count := 1
+	if hasCount {
+		count, _ = strconv.Atoi(countText)
+	}
+	return start, count
+}
+
+// parsePercent parses values like "87%"
+func parsePercent(value string) int {
+	percent, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	return percent
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
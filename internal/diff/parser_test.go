@@ -0,0 +1,275 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modifiedDiff = `diff --git a/main.go b/main.go
+index 3b18e51..8c1d2f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,5 @@ package main
+ package main
+
+-func main() {}
++import "fmt"
++
++func main() { fmt.Println("hi") }
+@@ -10,2 +12,2 @@ func helper() {
+--- old comment
++++ new comment
+ }
+`
+
+const addedDeletedDiff = `diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..3b18e51
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++hello
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 3b18e51..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-hello
+-world
+\ No newline at end of file
+`
+
+const renameCopyDiff = `diff --git a/docs/old name.md b/docs/new name.md
+similarity index 87%
+rename from docs/old name.md
+rename to docs/new name.md
+index 1111111..2222222 100644
+--- a/docs/old name.md
++++ b/docs/new name.md
+@@ -1 +1 @@
+-Old title
++New title
+diff --git a/a.go b/b.go
+similarity index 100%
+copy from a.go
+copy to b.go
+`
+
+const binaryModeDiff = `diff --git a/logo.png b/logo.png
+index 1111111..2222222 100644
+Binary files a/logo.png and b/logo.png differ
+diff --git a/build.sh b/build.sh
+old mode 100644
+new mode 100755
+diff --git a/my file.bin b/my file.bin
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/my file.bin differ
+`
+
+const quotedDiff = `diff --git "a/caf\303\251 \"menu\".txt" "b/caf\303\251 \"menu\".txt"
+index 1111111..2222222 100644
+--- "a/caf\303\251 \"menu\".txt"
++++ "b/caf\303\251 \"menu\".txt"
+@@ -1 +1,2 @@
+ coffee
++tea
+diff --git a/plain.txt "b/tab\there.txt"
+similarity index 90%
+rename from plain.txt
+rename to "tab\there.txt"
+`
+
+const combinedDiff = `diff --cc conflict.go
+index 1111111,2222222..3333333
+--- a/conflict.go
++++ b/conflict.go
+@@@ -1,3 -1,3 +1,4 @@@ package main
+  package main
+- var a = 1
+ -var a = 2
+++var a = 3
++ var b = 4
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     string
+		expected []types.FileChange
+	}{
+		{
+			name: "modified file with multiple hunks",
+			diff: modifiedDiff,
+			expected: []types.FileChange{
+				{Path: "main.go", ChangeType: types.ChangeTypeModified, LinesAdded: 4, LinesDeleted: 2, OldMode: "100644", NewMode: "100644"},
+			},
+		},
+		{
+			name: "added and deleted files",
+			diff: addedDeletedDiff,
+			expected: []types.FileChange{
+				{Path: "new.txt", ChangeType: types.ChangeTypeAdded, LinesAdded: 1, NewMode: "100644"},
+				{Path: "old.txt", ChangeType: types.ChangeTypeDeleted, LinesDeleted: 2, OldMode: "100644"},
+			},
+		},
+		{
+			name: "rename with spaces and copy",
+			diff: renameCopyDiff,
+			expected: []types.FileChange{
+				{Path: "docs/new name.md", OldPath: "docs/old name.md", ChangeType: types.ChangeTypeRenamed, Similarity: 87, LinesAdded: 1, LinesDeleted: 1, OldMode: "100644", NewMode: "100644"},
+				{Path: "b.go", OldPath: "a.go", ChangeType: types.ChangeTypeCopied, Similarity: 100},
+			},
+		},
+		{
+			name: "binary and mode-only changes",
+			diff: binaryModeDiff,
+			expected: []types.FileChange{
+				{Path: "logo.png", ChangeType: types.ChangeTypeModified, IsBinary: true, OldMode: "100644", NewMode: "100644"},
+				{Path: "build.sh", ChangeType: types.ChangeTypeModified, OldMode: "100644", NewMode: "100755"},
+				{Path: "my file.bin", ChangeType: types.ChangeTypeAdded, IsBinary: true, NewMode: "100644"},
+			},
+		},
+		{
+			name: "quoted paths",
+			diff: quotedDiff,
+			expected: []types.FileChange{
+				{Path: `café "menu".txt`, ChangeType: types.ChangeTypeModified, LinesAdded: 1, OldMode: "100644", NewMode: "100644"},
+				{Path: "tab\there.txt", OldPath: "plain.txt", ChangeType: types.ChangeTypeRenamed, Similarity: 90},
+			},
+		},
+		{
+			name: "combined merge diff",
+			diff: combinedDiff,
+			expected: []types.FileChange{
+				{Path: "conflict.go", ChangeType: types.ChangeTypeModified, LinesAdded: 2, LinesDeleted: 2},
+			},
+		},
+		{
+			name:     "empty input",
+			diff:     "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := Parse(tt.diff)
+			require.Len(t, files, len(tt.expected))
+
+			for i, expected := range tt.expected {
+				actual := files[i]
+				assert.Equal(t, expected.Path, actual.Path)
+				assert.Equal(t, expected.OldPath, actual.OldPath)
+				assert.Equal(t, expected.ChangeType, actual.ChangeType)
+				assert.Equal(t, expected.LinesAdded, actual.LinesAdded)
+				assert.Equal(t, expected.LinesDeleted, actual.LinesDeleted)
+				assert.Equal(t, expected.OldMode, actual.OldMode)
+				assert.Equal(t, expected.NewMode, actual.NewMode)
+				assert.Equal(t, expected.Similarity, actual.Similarity)
+				assert.Equal(t, expected.IsBinary, actual.IsBinary)
+			}
+		})
+	}
+}
+
+func TestParse_Hunks(t *testing.T) {
+	files := Parse(modifiedDiff)
+	require.Len(t, files, 1)
+	require.Len(t, files[0].Hunks, 2)
+
+	first := files[0].Hunks[0]
+	assert.Equal(t, 1, first.OldStart)
+	assert.Equal(t, 3, first.OldLines)
+	assert.Equal(t, 1, first.NewStart)
+	assert.Equal(t, 5, first.NewLines)
+	assert.Equal(t, "package main", first.Section)
+	assert.Equal(t, 3, first.LinesAdded)
+	assert.Equal(t, 1, first.LinesDeleted)
+	assert.Len(t, first.Lines, 6)
+
+	second := files[0].Hunks[1]
+	assert.Equal(t, 10, second.OldStart)
+	assert.Equal(t, 12, second.NewStart)
+	assert.Equal(t, "func helper() {", second.Section)
+	assert.Equal(t, 1, second.LinesAdded, "lines starting with +++ inside a hunk are content")
+	assert.Equal(t, 1, second.LinesDeleted, "lines starting with --- inside a hunk are content")
+
+	assert.Equal(t, modifiedDiff, files[0].Content)
+
+	added := Parse(addedDeletedDiff)
+	require.Len(t, added, 2)
+	require.Len(t, added[0].Hunks, 1)
+	assert.Equal(t, 1, added[0].Hunks[0].NewLines, "missing count defaults to 1")
+	assert.Contains(t, added[1].Hunks[0].Lines, `\ No newline at end of file`)
+}
+
+func TestParse_IgnoresPreamble(t *testing.T) {
+	input := "commit 0123456789abcdef\nAuthor: Test <test@example.com>\n\n    feat: add thing\n\n" + modifiedDiff
+	files := Parse(input)
+	require.Len(t, files, 1)
+	assert.Equal(t, "main.go", files[0].Path)
+	assert.Equal(t, modifiedDiff, files[0].Content)
+}
+
+func TestSplitHeaderPaths(t *testing.T) {
+	tests := []struct {
+		header  string
+		oldPath string
+		newPath string
+	}{
+		{"a/main.go b/main.go", "main.go", "main.go"},
+		{"a/dir with space/a b.go b/dir with space/a b.go", "dir with space/a b.go", "dir with space/a b.go"},
+		{"a/old.go b/new.go", "old.go", "new.go"},
+		{`"a/\303\251.go" "b/\303\251.go"`, "é.go", "é.go"},
+		{`a/plain.go "b/tab\t.go"`, "plain.go", "tab\t.go"},
+		{"main.go main.go", "main.go", "main.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			oldPath, newPath := splitHeaderPaths(tt.header)
+			assert.Equal(t, tt.oldPath, oldPath)
+			assert.Equal(t, tt.newPath, newPath)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		modifiedDiff, addedDeletedDiff, renameCopyDiff, binaryModeDiff, quotedDiff, combinedDiff,
+		"diff --git a b\n@@",
+		"diff --git \"a/\\\n@@@ -1 @@@\n",
+		"diff --cc x\n@@@ -1,2 -1,2 +1,2 @@@\n+-x\n",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		files := Parse(input)
+
+		if !strings.Contains(input, "diff --") {
+			assert.Empty(t, files)
+		}
+
+		for _, file := range files {
+			assert.NotEmpty(t, file.ChangeType)
+
+			var added, deleted int
+			for _, hunk := range file.Hunks {
+				assert.GreaterOrEqual(t, hunk.LinesAdded, 0)
+				assert.GreaterOrEqual(t, hunk.LinesDeleted, 0)
+				added += hunk.LinesAdded
+				deleted += hunk.LinesDeleted
+			}
+			assert.Equal(t, added, file.LinesAdded)
+			assert.Equal(t, deleted, file.LinesDeleted)
+			assert.LessOrEqual(t, len(file.Content), len(input))
+		}
+	})
+}
@@ -222,8 +222,19 @@ func (p *Processor) generateChunkDescription(files []types.FileChange) string {
 
 	if len(files) == 1 {
 		file := files[0]
-		return fmt.Sprintf("%s: %s (%d+, %d-)",
-			string(file.ChangeType), file.Path, file.LinesAdded, file.LinesDeleted)
+		path := file.Path
+		if file.OldPath != "" && file.OldPath != file.Path {
+			path = file.OldPath + " -> " + file.Path
+		}
+		if file.IsBinary {
+			return fmt.Sprintf("%s: %s (binary)", string(file.ChangeType), path)
+		}
+		description := fmt.Sprintf("%s: %s (%d+, %d-)",
+			string(file.ChangeType), path, file.LinesAdded, file.LinesDeleted)
+		if file.IsModeChange() {
+			description += fmt.Sprintf(" [mode %s -> %s]", file.OldMode, file.NewMode)
+		}
+		return description
 	}
 
 	// Multiple files
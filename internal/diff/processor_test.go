@@ -272,6 +272,42 @@ func TestProcessor_GenerateChunkDescription(t *testing.T) {
 			},
 			expected: "added: main.go (10+, 0-)",
 		},
+		{
+			name: "renamed file",
+			files: []types.FileChange{
+				{
+					Path:         "cmd/app.go",
+					OldPath:      "main.go",
+					ChangeType:   types.ChangeTypeRenamed,
+					LinesAdded:   1,
+					LinesDeleted: 1,
+				},
+			},
+			expected: "renamed: main.go -> cmd/app.go (1+, 1-)",
+		},
+		{
+			name: "binary file",
+			files: []types.FileChange{
+				{
+					Path:       "logo.png",
+					ChangeType: types.ChangeTypeModified,
+					IsBinary:   true,
+				},
+			},
+			expected: "modified: logo.png (binary)",
+		},
+		{
+			name: "mode change",
+			files: []types.FileChange{
+				{
+					Path:       "build.sh",
+					ChangeType: types.ChangeTypeModified,
+					OldMode:    "100644",
+					NewMode:    "100755",
+				},
+			},
+			expected: "modified: build.sh (0+, 0-) [mode 100644 -> 100755]",
+		},
 		{
 			name: "multiple files",
 			files: []types.FileChange{
@@ -6,6 +6,9 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/nguyendkn/git-generator/internal/style"
+	"github.com/nguyendkn/git-generator/internal/textwrap"
+	"github.com/nguyendkn/git-generator/internal/trailer"
 	"github.com/nguyendkn/git-generator/pkg/types"
 )
 
@@ -21,6 +24,8 @@ type FormatterConfig struct {
 	AutoWrapBody      bool `json:"auto_wrap_body"`       // Default: true
 	BreakOnSentence   bool `json:"break_on_sentence"`    // Default: true
 	EnforceBlankLine  bool `json:"enforce_blank_line"`   // Default: true
+
+	Style style.Style `json:"-"` // Header format and whether messages have a body; nil is Conventional Commits
 }
 
 // NewMessageFormatter creates a new message formatter with default config
@@ -49,8 +54,8 @@ func (f *MessageFormatter) FormatCommitMessage(message *types.CommitMessage) str
 	subject := f.formatSubjectLine(message)
 	result.WriteString(subject)
 
-	// Add body if present
-	if message.Body != "" {
+	// Add body if present and the style has one
+	if message.Body != "" && f.style().Info().Body {
 		if f.config.EnforceBlankLine {
 			result.WriteString("\n\n")
 		} else {
@@ -67,33 +72,16 @@ func (f *MessageFormatter) FormatCommitMessage(message *types.CommitMessage) str
 		result.WriteString(message.Footer)
 	}
 
+	// Merge trailers into the footer, skipping ones the footer already contains
+	if len(message.Trailers) > 0 {
+		return trailer.Merge(result.String(), message.Trailers)
+	}
+
 	return result.String()
 }
 
-// formatSubjectLine formats the subject line according to conventional commits
+// formatSubjectLine formats the subject line with the header of the style
 func (f *MessageFormatter) formatSubjectLine(message *types.CommitMessage) string {
-	var subject strings.Builder
-
-	// Add type
-	if message.Type != "" {
-		subject.WriteString(strings.ToLower(string(message.Type)))
-	}
-
-	// Add scope if present
-	if message.Scope != "" {
-		subject.WriteString("(")
-		subject.WriteString(message.Scope)
-		subject.WriteString(")")
-	}
-
-	// Add breaking change indicator
-	if message.Breaking {
-		subject.WriteString("!")
-	}
-
-	// Add separator
-	subject.WriteString(": ")
-
 	// Add description
 	description := message.Description
 	if message.Subject != "" {
@@ -105,36 +93,55 @@ func (f *MessageFormatter) formatSubjectLine(message *types.CommitMessage) strin
 	description = f.capitalizeFirst(description)
 	description = strings.TrimSuffix(description, ".")
 
+	header := style.Header{
+		Type:        strings.ToLower(string(message.Type)),
+		Scope:       message.Scope,
+		Description: description,
+		Breaking:    message.Breaking,
+	}
+	subject := f.style().Format(header)
+
 	// Truncate if too long
-	if len(subject.String()+description) > f.config.MaxSubjectLength {
-		maxDescLength := f.config.MaxSubjectLength - len(subject.String())
+	if excess := textwrap.Width(subject) - f.config.MaxSubjectLength; excess > 0 {
+		maxDescLength := textwrap.Width(description) - excess
 		if maxDescLength > 0 {
-			description = f.truncateAtWord(description, maxDescLength)
+			header.Description = textwrap.TruncateAtWord(description, maxDescLength)
+			subject = f.style().Format(header)
 		}
 	}
 
-	subject.WriteString(description)
+	return subject
+}
 
-	return subject.String()
+// style returns the configured style, or Conventional Commits
+func (f *MessageFormatter) style() style.Style {
+	if f.config.Style == nil {
+		return style.Conventional()
+	}
+	return f.config.Style
 }
 
-// formatBody formats the commit message body with proper line wrapping
+// formatBody formats the commit message body with proper line wrapping.
+// Lists are wrapped item by item, while code blocks and a final trailer
+// block are kept as written.
 func (f *MessageFormatter) formatBody(body string) string {
 	if !f.config.AutoWrapBody {
 		return body
 	}
 
-	// Split into paragraphs
-	paragraphs := strings.Split(body, "\n\n")
+	blocks := textwrap.SplitBlocks(body)
 	var formattedParagraphs []string
-
-	for _, paragraph := range paragraphs {
-		if strings.TrimSpace(paragraph) == "" {
-			continue
+	for i, block := range blocks {
+		switch {
+		case block.Code:
+			formattedParagraphs = append(formattedParagraphs, strings.Join(block.Lines, "\n"))
+		case i == len(blocks)-1 && trailer.IsBlock(strings.Join(block.Lines, "\n")):
+			formattedParagraphs = append(formattedParagraphs, strings.Join(block.Lines, "\n"))
+		case block.IsList():
+			formattedParagraphs = append(formattedParagraphs, textwrap.WrapList(block.Lines, f.config.MaxBodyLineLength))
+		default:
+			formattedParagraphs = append(formattedParagraphs, f.formatParagraph(strings.Join(block.Lines, "\n")))
 		}
-
-		formattedParagraph := f.formatParagraph(paragraph)
-		formattedParagraphs = append(formattedParagraphs, formattedParagraph)
 	}
 
 	return strings.Join(formattedParagraphs, "\n\n")
@@ -150,7 +157,7 @@ func (f *MessageFormatter) formatParagraph(paragraph string) string {
 		return f.formatWithSentenceBreaks(paragraph)
 	}
 
-	return f.wrapText(paragraph, f.config.MaxBodyLineLength)
+	return textwrap.Wrap(paragraph, f.config.MaxBodyLineLength)
 }
 
 // formatWithSentenceBreaks formats text with automatic line breaks at sentence endings and bullet points
@@ -170,8 +177,8 @@ func (f *MessageFormatter) formatWithSentenceBreaks(text string) string {
 		maxLineLength := f.config.MaxBodyLineLength - len(bulletPrefix)
 
 		// If sentence is too long, wrap it while preserving bullet point
-		if len(sentence) > maxLineLength {
-			wrappedSentence := f.wrapText(sentence, maxLineLength)
+		if textwrap.Width(sentence) > maxLineLength {
+			wrappedSentence := textwrap.Wrap(sentence, maxLineLength)
 			sentenceLines := strings.Split(wrappedSentence, "\n")
 
 			// Add bullet point to first line
@@ -194,8 +201,9 @@ func (f *MessageFormatter) formatWithSentenceBreaks(text string) string {
 
 // splitIntoSentences splits text into sentences
 func (f *MessageFormatter) splitIntoSentences(text string) []string {
-	// Simple sentence splitting - can be enhanced with more sophisticated logic
-	sentenceEnders := regexp.MustCompile(`[.!?]+\s+`)
+	// Simple sentence splitting - can be enhanced with more sophisticated logic.
+	// CJK full stops end a sentence without a following space.
+	sentenceEnders := regexp.MustCompile(`[.!?]+\s+|[。！？]+\s*`)
 	parts := sentenceEnders.Split(text, -1)
 
 	var sentences []string
@@ -209,9 +217,9 @@ func (f *MessageFormatter) splitIntoSentences(text string) []string {
 		sentence := strings.TrimSpace(part)
 		if i < len(matches) {
 			// Add back the sentence ender
-			ender := strings.TrimSpace(matches[i])
-			if ender != "" {
-				sentence += ender[:1] // Just the punctuation, not the space
+			ender := []rune(strings.TrimSpace(matches[i]))
+			if len(ender) > 0 {
+				sentence += string(ender[0]) // Just the punctuation, not the space
 			}
 		}
 
@@ -221,47 +229,6 @@ func (f *MessageFormatter) splitIntoSentences(text string) []string {
 	return sentences
 }
 
-// wrapText wraps text to specified line length
-func (f *MessageFormatter) wrapText(text string, maxLength int) string {
-	if len(text) <= maxLength {
-		return text
-	}
-
-	words := strings.Fields(text)
-	var lines []string
-	var currentLine strings.Builder
-
-	for _, word := range words {
-		// Check if adding this word would exceed line length
-		testLine := currentLine.String()
-		if testLine != "" {
-			testLine += " " + word
-		} else {
-			testLine = word
-		}
-
-		if len(testLine) <= maxLength {
-			if currentLine.Len() > 0 {
-				currentLine.WriteString(" ")
-			}
-			currentLine.WriteString(word)
-		} else {
-			// Start new line
-			if currentLine.Len() > 0 {
-				lines = append(lines, currentLine.String())
-				currentLine.Reset()
-			}
-			currentLine.WriteString(word)
-		}
-	}
-
-	if currentLine.Len() > 0 {
-		lines = append(lines, currentLine.String())
-	}
-
-	return strings.Join(lines, "\n")
-}
-
 // capitalizeFirst capitalizes the first letter of a string
 func (f *MessageFormatter) capitalizeFirst(s string) string {
 	if s == "" {
@@ -273,24 +240,6 @@ func (f *MessageFormatter) capitalizeFirst(s string) string {
 	return string(runes)
 }
 
-// truncateAtWord truncates text at word boundary
-func (f *MessageFormatter) truncateAtWord(text string, maxLength int) string {
-	if len(text) <= maxLength {
-		return text
-	}
-
-	// Find last space before maxLength
-	truncated := text[:maxLength]
-	lastSpace := strings.LastIndex(truncated, " ")
-
-	if lastSpace > 0 {
-		return text[:lastSpace]
-	}
-
-	// No space found, hard truncate
-	return text[:maxLength-3] + "..."
-}
-
 // ValidateFormat validates commit message format
 func (f *MessageFormatter) ValidateFormat(message *types.CommitMessage) []string {
 	var issues []string
@@ -298,7 +247,7 @@ func (f *MessageFormatter) ValidateFormat(message *types.CommitMessage) []string
 	subject := f.formatSubjectLine(message)
 
 	// Check subject length
-	if len(subject) > f.config.MaxSubjectLength {
+	if textwrap.Width(subject) > f.config.MaxSubjectLength {
 		issues = append(issues, "Subject line quá dài (>50 ký tự)")
 	}
 
@@ -311,7 +260,7 @@ func (f *MessageFormatter) ValidateFormat(message *types.CommitMessage) []string
 	if message.Body != "" {
 		lines := strings.Split(message.Body, "\n")
 		for i, line := range lines {
-			if len(line) > f.config.MaxBodyLineLength {
+			if textwrap.Width(line) > f.config.MaxBodyLineLength {
 				issues = append(issues, fmt.Sprintf("Dòng %d trong body quá dài (>72 ký tự)", i+1))
 			}
 		}
@@ -0,0 +1,81 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nguyendkn/git-generator/internal/textwrap"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestFormatBody(t *testing.T) {
+	f := NewMessageFormatterWithConfig(FormatterConfig{
+		MaxSubjectLength:  50,
+		MaxBodyLineLength: 30,
+		AutoWrapBody:      true,
+		EnforceBlankLine:  true,
+	})
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "paragraph",
+			body:     "Sessions were cached per request, so every call hit the database.",
+			expected: "Sessions were cached per\nrequest, so every call hit the\ndatabase.",
+		},
+		{
+			name: "bullet list with hanging indent",
+			body: "Changes:\n- Move the session cache out of the request handler\n- Thêm bộ nhớ đệm cho phiên đăng nhập của người dùng",
+			expected: "Changes:\n" +
+				"- Move the session cache out\n  of the request handler\n" +
+				"- Thêm bộ nhớ đệm cho phiên\n  đăng nhập của người dùng",
+		},
+		{
+			name: "numbered list with continuation lines",
+			body: "1. Parse the configuration file\n   before starting the server\n10) 添加登录页面和记住我选项以及会话过期提示",
+			expected: "1. Parse the configuration\n   file before starting the\n   server\n" +
+				"10) 添加登录页面和记住我选项以\n    及会话过期提示",
+		},
+		{
+			name:     "fenced code block is kept",
+			body:     "Run:\n\n```\ngit-generator generate --format json --dry-run --verbose\n\n# done\n```",
+			expected: "Run:\n\n```\ngit-generator generate --format json --dry-run --verbose\n\n# done\n```",
+		},
+		{
+			name:     "indented code is kept",
+			body:     "Example:\n\n    config := validation.DefaultConfig() // with defaults",
+			expected: "Example:\n\n    config := validation.DefaultConfig() // with defaults",
+		},
+		{
+			name:     "trailers are kept",
+			body:     "Fix the crash.\n\nRefs: #12\nCo-authored-by: Nguyễn Văn An <an@example.com>",
+			expected: "Fix the crash.\n\nRefs: #12\nCo-authored-by: Nguyễn Văn An <an@example.com>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, f.formatBody(tt.body))
+		})
+	}
+}
+
+func TestFormatSubjectLineWidth(t *testing.T) {
+	f := NewMessageFormatter()
+
+	subject := f.formatSubjectLine(&types.CommitMessage{
+		Type:        types.CommitTypeFeat,
+		Description: "thêm trang đăng nhập với tùy chọn ghi nhớ mật khẩu người dùng",
+	})
+	assert.Equal(t, "feat: Thêm trang đăng nhập với tùy chọn ghi nhớ", subject)
+	assert.LessOrEqual(t, textwrap.Width(subject), 50)
+
+	subject = f.formatSubjectLine(&types.CommitMessage{Type: types.CommitTypeFix, Description: "修复登录页面在移动设备上无法提交表单的问题以及会话过期"})
+	assert.LessOrEqual(t, textwrap.Width(subject), 50)
+	assert.True(t, strings.HasPrefix(subject, "fix: 修复"))
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/nguyendkn/git-generator/internal/ai"
@@ -14,6 +15,8 @@ import (
 	"github.com/nguyendkn/git-generator/internal/diff"
 	"github.com/nguyendkn/git-generator/internal/formatter"
 	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/internal/style"
+	"github.com/nguyendkn/git-generator/internal/trailer"
 	"github.com/nguyendkn/git-generator/internal/validation"
 	"github.com/nguyendkn/git-generator/pkg/types"
 )
@@ -27,51 +30,130 @@ type Service struct {
 	formatter       *formatter.MessageFormatter
 	validator       *validation.Validator
 	config          types.Config
+
+	styles     *style.Registry             // Message styles, with the template style of output.template
+	style      style.Style                 // Style of the messages being generated
+	baseRules  validation.ValidationConfig // Repository rules before the style and validation section are applied
+	rulesFiles []string                    // Repository files for rules based on detected scopes
 }
 
 // NewService creates a new generator service
 func NewService(gitService *git.Service, diffProcessor *diff.Processor, aiClient *ai.GeminiClient, config types.Config) *Service {
 	contextAnalyzer := contextanalyzer.NewAnalyzer(gitService)
 
-	// Create formatter with config
-	formatterConfig := formatter.FormatterConfig{
-		MaxSubjectLength:  50,
-		MaxBodyLineLength: 72,
-		AutoWrapBody:      true,
-		BreakOnSentence:   true,
-		EnforceBlankLine:  true,
+	// Load the repository rules, including a commitlint configuration at the repository root
+	rulesDir := "."
+	if gitService != nil {
+		if root, err := gitService.GetRepositoryRoot(); err == nil {
+			rulesDir = root
+		}
 	}
-	messageFormatter := formatter.NewMessageFormatterWithConfig(formatterConfig)
-
-	// Create validator with config
-	validatorConfig := validation.ValidationConfig{
-		MaxSubjectLength:      50,
-		MaxBodyLineLength:     72,
-		EnforceImperative:     true,
-		EnforceCapitalization: true,
-		AllowedTypes:          []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert", "security", "deps"},
-		RequireBody:           false,
-		Language:              "vi", // Default to Vietnamese
+	validatorConfig, _, err := validation.LoadConfig(rulesDir, config.Output.MaxSubjectLength, config.Output.Language)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	var files []string
+	if config.Validation.DetectedScopes {
+		if files, err = gitService.ListFiles(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	styles, err := style.Builtin(config.Output.Template)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		styles, _ = style.Builtin(types.HeaderTemplate{})
 	}
-	messageValidator := validation.NewValidator(validatorConfig)
 
-	return &Service{
+	service := &Service{
 		gitService:      gitService,
 		diffProcessor:   diffProcessor,
 		aiClient:        aiClient,
 		contextAnalyzer: contextAnalyzer,
-		formatter:       messageFormatter,
-		validator:       messageValidator,
 		config:          config,
+		styles:          styles,
+		baseRules:       validatorConfig,
+		rulesFiles:      files,
+	}
+	if err := service.useStyle(config.Output.Style); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		_ = service.useStyle(style.Default)
+	}
+	return service
+}
+
+// useStyle selects the message style: its rules are applied on top of the
+// repository rules, then the validation section of the configuration
+func (s *Service) useStyle(name string) error {
+	selected, err := s.styles.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	validatorConfig := s.baseRules.Clone()
+	selected.Configure(&validatorConfig)
+	if err := validatorConfig.ApplyRules(s.config.Validation, s.rulesFiles); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	s.style = selected
+	s.validator = validation.NewValidator(validatorConfig)
+	if s.aiClient != nil {
+		s.aiClient.SetCommitRules(validatorConfig.PromptGuidance())
+		s.aiClient.SetStyles(s.styles)
 	}
+
+	// Create formatter with config
+	s.formatter = formatter.NewMessageFormatterWithConfig(formatter.FormatterConfig{
+		MaxSubjectLength:  validatorConfig.MaxSubjectLength,
+		MaxBodyLineLength: validatorConfig.MaxBodyLineLength,
+		AutoWrapBody:      true,
+		BreakOnSentence:   true,
+		EnforceBlankLine:  true,
+		Style:             selected,
+	})
+	return nil
+}
+
+// styleName returns the style selected by the options, or the configured style
+func (s *Service) styleName(options GenerateOptions) string {
+	if options.Style != "" {
+		return options.Style
+	}
+	return s.style.Info().Name
 }
 
 // GenerateOptions contains options for commit message generation
 type GenerateOptions struct {
-	Style         string // conventional, simple, detailed
-	IncludeStaged bool   // Include staged changes
-	DryRun        bool   // Preview only, don't commit
-	Interactive   bool   // Allow user to edit the message
+	Style            string // Message style name; empty uses output.style
+	IncludeStaged    bool   // Include staged changes
+	DryRun           bool   // Preview only, don't commit
+	Interactive      bool   // Allow user to edit the message
+	Range            string // Describe a revision range (A..B or A...B) instead of local changes
+	Against          string // Describe HEAD against a branch from their merge base
+	IncludeUntracked bool   // Include untracked files in working tree changes
+
+	// Trailers appended to the generated message
+	Closes    bool     // Reference branch issues with Closes instead of the configured token
+	SignOff   bool     // Add a Signed-off-by trailer
+	CoAuthors []string // Team roster aliases or "Name <email>" values
+	Trailers  []string // Additional "Token: value" trailers
+}
+
+// diffOptions returns the git diff selection for these options. Staged
+// changes are only read, and required, when no revisions are compared.
+func (o GenerateOptions) diffOptions() git.DiffOptions {
+	return git.DiffOptions{
+		Staged:           o.IncludeStaged && !o.IncludeUntracked && o.Range == "" && o.Against == "",
+		Range:            o.Range,
+		Against:          o.Against,
+		IncludeUntracked: o.IncludeUntracked,
+	}
+}
+
+// IsPreviewOnly reports whether the options describe changes that cannot be
+// committed from the index, so the message is only shown
+func (o GenerateOptions) IsPreviewOnly() bool {
+	return o.diffOptions().IsRevisionDiff() || o.IncludeUntracked
 }
 
 // GenerateResult contains the result of commit message generation
@@ -80,27 +162,77 @@ type GenerateResult struct {
 	ProcessedDiff *diff.ProcessedDiff  `json:"processed_diff"`
 	Preview       string               `json:"preview"`
 	Applied       bool                 `json:"applied"`
+
+	Fixes          []string `json:"fixes,omitempty"`           // Rules fixed automatically in the final message
+	RepairAttempts int      `json:"repair_attempts,omitempty"` // Times the model was asked to fix validation errors
+
+	Diagnostics []validation.Diagnostic `json:"diagnostics,omitempty"` // Rule violations of the final message with their positions
 }
 
 // Generate generates a commit message based on current changes
 func (s *Service) Generate(ctx context.Context, options GenerateOptions) (*GenerateResult, error) {
+	selected, err := s.prepareChanges(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate commit message using AI
+	commitMessage, err := s.aiClient.GenerateCommitMessage(ctx, selected.processedDiff, selected.styleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate commit message: %w", err)
+	}
+	commitMessage.Trailers = selected.trailers
+	result := s.buildResult(ctx, commitMessage, selected)
+
+	// Apply the commit if not in dry-run mode; ranges and untracked files are never committed
+	if !options.DryRun && !options.IsPreviewOnly() {
+		if err := s.applyCommit(commitMessage); err != nil {
+			return result, fmt.Errorf("failed to apply commit: %w", err)
+		}
+		result.Applied = true
+	}
+
+	return result, nil
+}
+
+// selectedChanges holds the changes a message is generated for and the
+// settings it is generated with
+type selectedChanges struct {
+	processedDiff *diff.ProcessedDiff
+	trailers      []types.Trailer
+	styleName     string
+}
+
+// prepareChanges checks, reads and processes the changes selected by the
+// options, resolves the trailers and selects the message style
+func (s *Service) prepareChanges(options GenerateOptions) (*selectedChanges, error) {
 	// Validate that we're in a Git repository
 	if !s.gitService.IsGitRepository() {
 		return nil, fmt.Errorf("not in a Git repository")
 	}
 
+	diffOptions := options.diffOptions()
+
 	// Check for changes
-	hasStaged, err := s.gitService.HasStagedChanges()
-	if err != nil {
-		return nil, fmt.Errorf("failed to check for staged changes: %w", err)
+	if diffOptions.Staged {
+		hasStaged, err := s.gitService.HasStagedChanges()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for staged changes: %w", err)
+		}
+
+		if !hasStaged {
+			return nil, fmt.Errorf("no staged changes found. Use 'git add' to stage changes first")
+		}
 	}
 
-	if !hasStaged && options.IncludeStaged {
-		return nil, fmt.Errorf("no staged changes found. Use 'git add' to stage changes first")
+	// Resolve trailers before calling the AI so configuration errors fail fast
+	trailers, err := s.resolveTrailers(options)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get diff summary
-	diffSummary, err := s.gitService.GetDiffSummary(options.IncludeStaged)
+	diffSummary, err := s.gitService.GetDiffSummaryFor(diffOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get diff summary: %w", err)
 	}
@@ -119,7 +251,7 @@ func (s *Service) Generate(ctx context.Context, options GenerateOptions) (*Gener
 	changeContext, err := s.contextAnalyzer.AnalyzeChangeContext(diffSummary)
 	if err != nil {
 		// Don't fail if context analysis fails, just log and continue without context
-		fmt.Printf("Warning: Failed to analyze change context: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: Failed to analyze change context: %v\n", err)
 		changeContext = nil
 	}
 
@@ -128,49 +260,107 @@ func (s *Service) Generate(ctx context.Context, options GenerateOptions) (*Gener
 		processedDiff.SetChangeContext(changeContext)
 	}
 
-	// Generate commit message using AI
-	commitMessage, err := s.aiClient.GenerateCommitMessage(ctx, processedDiff, options.Style)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate commit message: %w", err)
+	styleName := s.styleName(options)
+	if err := s.useStyle(styleName); err != nil {
+		return nil, err
 	}
 
-	// Format the commit message
-	formattedMessage := s.formatter.FormatCommitMessage(commitMessage)
-	commitMessage.FormattedMessage = formattedMessage
-
-	// Create a temporary message for validation with formatted content
-	formattedCommitMessage := &types.CommitMessage{
-		Type:     commitMessage.Type,
-		Scope:    commitMessage.Scope,
-		Subject:  s.extractSubjectFromFormatted(formattedMessage),
-		Body:     s.extractBodyFromFormatted(formattedMessage),
-		Footer:   commitMessage.Footer,
-		Breaking: commitMessage.Breaking,
-	}
+	return &selectedChanges{processedDiff: processedDiff, trailers: trailers, styleName: styleName}, nil
+}
 
-	// Validate the formatted commit message
-	validationResult := s.validator.ValidateCommitMessage(formattedCommitMessage)
+// buildResult formats, fixes and validates a generated message and creates its preview
+func (s *Service) buildResult(ctx context.Context, commitMessage *types.CommitMessage, selected *selectedChanges) *GenerateResult {
+	validationResult, fixes, repairs := s.finalizeMessage(ctx, commitMessage, selected.styleName)
 	commitMessage.ValidationResult = s.convertValidationResult(validationResult)
 
 	// Create preview with validation info
-	preview := s.createPreviewWithValidation(commitMessage, processedDiff, validationResult)
+	preview := s.createPreviewWithValidation(commitMessage, selected.processedDiff, validationResult)
+	if len(fixes) > 0 {
+		preview += fmt.Sprintf("\n🔧 Auto-fixed: %s\n", strings.Join(fixes, ", "))
+	}
+	if repairs > 0 {
+		preview += fmt.Sprintf("🤖 AI repair attempts: %d\n", repairs)
+	}
 
-	result := &GenerateResult{
-		CommitMessage: commitMessage,
-		ProcessedDiff: processedDiff,
-		Preview:       preview,
-		Applied:       false,
+	return &GenerateResult{
+		CommitMessage:  commitMessage,
+		ProcessedDiff:  selected.processedDiff,
+		Preview:        preview,
+		Applied:        false,
+		Fixes:          fixes,
+		RepairAttempts: repairs,
+		Diagnostics:    s.validator.Lint(commitMessage.FormattedMessage),
 	}
+}
 
-	// Apply the commit if not in dry-run mode
-	if !options.DryRun {
-		if err := s.applyCommit(commitMessage); err != nil {
-			return result, fmt.Errorf("failed to apply commit: %w", err)
+// finalizeMessage formats the message and applies the automatic fixes. While
+// validation errors remain, the model is asked to repair them, up to
+// output.repair_attempts times. It returns the validation result of the final
+// message, the rules fixed automatically in it and the number of repair attempts.
+func (s *Service) finalizeMessage(ctx context.Context, commitMessage *types.CommitMessage, styleName string) (*validation.ValidationResult, []string, int) {
+	for attempt := 0; ; attempt++ {
+		formatted, fixes := s.validator.Fix(s.formatter.FormatCommitMessage(commitMessage))
+		commitMessage.FormattedMessage = formatted
+
+		// Keep the structured message in sync with the fixed text
+		parsed := validation.ParseMessage(formatted)
+		header, _, _ := strings.Cut(formatted, "\n")
+		if header, ok := s.style.Parse(header); ok {
+			commitMessage.Type, commitMessage.Scope, commitMessage.Description = types.CommitType(header.Type), header.Scope, header.Description
+			commitMessage.Breaking = parsed.Breaking || header.Breaking
 		}
-		result.Applied = true
+
+		result := s.validator.ValidateCommitMessage(parsed)
+		if result.IsValid || s.aiClient == nil || attempt >= s.config.Output.RepairAttempts {
+			return result, fixes, attempt
+		}
+
+		var problems []string
+		for _, diagnostic := range s.validator.Lint(formatted) {
+			if diagnostic.Severity == validation.SeverityError {
+				problems = append(problems, diagnostic.String())
+			}
+		}
+		repaired, err := s.aiClient.RepairCommitMessage(ctx, formatted, problems, styleName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			return result, fixes, attempt + 1
+		}
+		repaired.Trailers = commitMessage.Trailers
+		*commitMessage = *repaired
 	}
+}
 
-	return result, nil
+// resolveTrailers builds the trailers for the commit from configuration, the current branch and options
+func (s *Service) resolveTrailers(options GenerateOptions) ([]types.Trailer, error) {
+	builder, err := trailer.NewBuilder(s.config.Trailers)
+	if err != nil {
+		return nil, err
+	}
+
+	trailerOptions := trailer.Options{
+		Closes:    options.Closes,
+		SignOff:   options.SignOff,
+		CoAuthors: options.CoAuthors,
+		Custom:    options.Trailers,
+	}
+
+	// A detached HEAD simply has no branch to extract issue keys from
+	trailerOptions.Branch, _ = s.gitService.GetCurrentBranch()
+
+	if builder.SignOffEnabled(trailerOptions) {
+		identity, err := s.gitService.GetUserIdentity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get git user identity: %w", err)
+		}
+		trailerOptions.Identity = identity
+	}
+
+	trailers, err := builder.Build(trailerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trailers: %w", err)
+	}
+	return trailers, nil
 }
 
 // GenerateMultipleOptions generates multiple commit message options
@@ -183,7 +373,7 @@ func (s *Service) GenerateMultipleOptions(ctx context.Context, options GenerateO
 	}
 
 	// Get diff summary once
-	diffSummary, err := s.gitService.GetDiffSummary(options.IncludeStaged)
+	diffSummary, err := s.gitService.GetDiffSummaryFor(options.diffOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get diff summary: %w", err)
 	}
@@ -200,15 +390,21 @@ func (s *Service) GenerateMultipleOptions(ctx context.Context, options GenerateO
 
 	// Generate multiple options
 	var messages []*types.CommitMessage
-	styles := []string{"conventional", "simple", "detailed"}
+	// Start with the configured style, then offer other styles
+	styles := []string{s.style.Info().Name}
+	for _, name := range []string{"conventional", "gitmoji", "traditional"} {
+		if !slices.Contains(styles, name) {
+			styles = append(styles, name)
+		}
+	}
 
 	for i := 0; i < count; i++ {
-		style := styles[i%len(styles)]
+		styleName := styles[i%len(styles)]
 		if options.Style != "" {
-			style = options.Style
+			styleName = options.Style
 		}
 
-		commitMessage, err := s.aiClient.GenerateCommitMessage(ctx, processedDiff, style)
+		commitMessage, err := s.aiClient.GenerateCommitMessage(ctx, processedDiff, styleName)
 		if err != nil {
 			log.Printf("Failed to generate option %d: %v", i+1, err)
 			continue
@@ -224,22 +420,25 @@ func (s *Service) GenerateMultipleOptions(ctx context.Context, options GenerateO
 	return messages, nil
 }
 
-// ValidateChanges validates that there are changes to commit
-func (s *Service) ValidateChanges(includeStaged bool) error {
+// ValidateChanges validates that the selected changes are not empty
+func (s *Service) ValidateChanges(options GenerateOptions) error {
 	if !s.gitService.IsGitRepository() {
 		return fmt.Errorf("not in a Git repository")
 	}
 
-	hasStaged, err := s.gitService.HasStagedChanges()
-	if err != nil {
-		return fmt.Errorf("failed to check for staged changes: %w", err)
-	}
+	diffOptions := options.diffOptions()
+	if diffOptions.Staged {
+		hasStaged, err := s.gitService.HasStagedChanges()
+		if err != nil {
+			return fmt.Errorf("failed to check for staged changes: %w", err)
+		}
 
-	if includeStaged && !hasStaged {
-		return fmt.Errorf("no staged changes found")
+		if !hasStaged {
+			return fmt.Errorf("no staged changes found")
+		}
 	}
 
-	diffSummary, err := s.gitService.GetDiffSummary(includeStaged)
+	diffSummary, err := s.gitService.GetDiffSummaryFor(diffOptions)
 	if err != nil {
 		return fmt.Errorf("failed to get diff summary: %w", err)
 	}
@@ -344,74 +543,6 @@ func (s *Service) createPreviewWithValidation(commitMessage *types.CommitMessage
 	return preview
 }
 
-// extractBodyFromFormatted extracts the body part from a formatted commit message
-func (s *Service) extractBodyFromFormatted(formattedMessage string) string {
-	lines := strings.Split(formattedMessage, "\n")
-
-	if len(lines) <= 1 {
-		return "" // No body, only subject
-	}
-
-	// Find the first empty line (separator between subject and body)
-	bodyStartIndex := -1
-	for i := 1; i < len(lines); i++ { // Start from line 1 (skip subject)
-		if strings.TrimSpace(lines[i]) == "" {
-			bodyStartIndex = i + 1
-			break
-		}
-	}
-
-	if bodyStartIndex == -1 {
-		// No empty line found, check if line 1 is body (no proper separation)
-		if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
-			bodyStartIndex = 1
-		} else {
-			return "" // No body found
-		}
-	}
-
-	if bodyStartIndex >= len(lines) {
-		return "" // No body content
-	}
-
-	// Find the end of body (before footer if any)
-	bodyEndIndex := len(lines)
-	for i := bodyStartIndex; i < len(lines); i++ {
-		// Look for footer patterns (like "Fixes #123", "Co-authored-by:", etc.)
-		line := strings.TrimSpace(lines[i])
-		if line == "" && i+1 < len(lines) {
-			nextLine := strings.TrimSpace(lines[i+1])
-			if strings.Contains(nextLine, ":") || strings.HasPrefix(nextLine, "Fixes") ||
-				strings.HasPrefix(nextLine, "Closes") || strings.HasPrefix(nextLine, "Co-authored-by") {
-				bodyEndIndex = i
-				break
-			}
-		}
-	}
-
-	// Extract body lines
-	bodyLines := lines[bodyStartIndex:bodyEndIndex]
-
-	// Remove trailing empty lines
-	for len(bodyLines) > 0 && strings.TrimSpace(bodyLines[len(bodyLines)-1]) == "" {
-		bodyLines = bodyLines[:len(bodyLines)-1]
-	}
-
-	return strings.Join(bodyLines, "\n")
-}
-
-// extractSubjectFromFormatted extracts the subject line from a formatted commit message
-func (s *Service) extractSubjectFromFormatted(formattedMessage string) string {
-	lines := strings.Split(formattedMessage, "\n")
-
-	if len(lines) == 0 {
-		return ""
-	}
-
-	// Subject is always the first line
-	return strings.TrimSpace(lines[0])
-}
-
 // convertValidationResult converts validation.ValidationResult to types.CommitValidationResult
 func (s *Service) convertValidationResult(vr *validation.ValidationResult) *types.CommitValidationResult {
 	if vr == nil {
@@ -429,6 +560,7 @@ func (s *Service) convertValidationResult(vr *validation.ValidationResult) *type
 	for i, err := range vr.Errors {
 		result.Errors[i] = types.ValidationError{
 			Type:     err.Type,
+			RuleID:   err.RuleID,
 			Message:  err.Message,
 			Line:     err.Line,
 			Column:   err.Column,
@@ -440,8 +572,11 @@ func (s *Service) convertValidationResult(vr *validation.ValidationResult) *type
 	for i, warning := range vr.Warnings {
 		result.Warnings[i] = types.ValidationWarning{
 			Type:       warning.Type,
+			RuleID:     warning.RuleID,
 			Message:    warning.Message,
 			Suggestion: warning.Suggestion,
+			Line:       warning.Line,
+			Column:     warning.Column,
 		}
 	}
 
@@ -466,11 +601,7 @@ func (s *Service) applyCommit(commitMessage *types.CommitMessage) error {
 		messageText = commitMessage.String()
 	}
 
-	cmd := exec.Command("git", "commit", "-m", messageText)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to commit: %w", err)
-	}
-	return nil
+	return s.gitService.Commit(messageText)
 }
 
 // GenerateInteractive generates a commit message with interactive confirmation
@@ -484,50 +615,133 @@ func (s *Service) GenerateInteractive(ctx context.Context, options GenerateOptio
 
 	// Show preview
 	fmt.Println("Generated commit message:")
-	fmt.Println(result.CommitMessage.String())
+	fmt.Println(result.CommitMessage.FormattedMessage)
 	fmt.Println("\nChange summary:")
 	fmt.Println(result.ProcessedDiff.Summary)
 
-	// Ask for confirmation
-	if s.confirmCommit() {
-		// Apply the commit
-		if err := s.applyCommit(result.CommitMessage); err != nil {
-			return result, fmt.Errorf("failed to apply commit: %w", err)
-		}
-		result.Applied = true
-		fmt.Println("Commit applied successfully!")
-	} else {
-		fmt.Println("Commit cancelled.")
+	if options.IsPreviewOnly() {
+		return result, nil
 	}
 
-	return result, nil
+	// Ask for confirmation, editing the message as often as needed
+	for {
+		switch s.confirmCommit() {
+		case "edit":
+			edited, err := s.EditCommitMessage(result.CommitMessage)
+			if err != nil {
+				return result, err
+			}
+			result.CommitMessage = edited
+			result.Diagnostics = s.validator.Lint(edited.FormattedMessage)
+			fmt.Println("\nEdited commit message:")
+			fmt.Println(edited.FormattedMessage)
+			continue
+		case "apply":
+			if err := s.applyCommit(result.CommitMessage); err != nil {
+				return result, fmt.Errorf("failed to apply commit: %w", err)
+			}
+			result.Applied = true
+			fmt.Println("Commit applied successfully!")
+		default:
+			fmt.Println("Commit cancelled.")
+		}
+		return result, nil
+	}
 }
 
-// confirmCommit asks the user for confirmation
-func (s *Service) confirmCommit() bool {
-	fmt.Print("Apply this commit? [y/N]: ")
+// confirmCommit asks whether to apply, edit or cancel the commit
+func (s *Service) confirmCommit() string {
+	fmt.Print("Apply this commit? [y/N/e(dit)]: ")
 
 	scanner := bufio.NewScanner(os.Stdin)
 	if scanner.Scan() {
-		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
-		return response == "y" || response == "yes"
+		switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+		case "y", "yes":
+			return "apply"
+		case "e", "edit":
+			return "edit"
+		}
 	}
 
-	return false
+	return "cancel"
 }
 
-// EditCommitMessage allows the user to edit the commit message
+// askYesNo asks a yes/no question, returning defaultAnswer for an empty answer
+func (s *Service) askYesNo(question string, defaultAnswer bool) bool {
+	fmt.Print(question)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+	case "":
+		return defaultAnswer
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// EditCommitMessage opens the commit message in $EDITOR and parses the edited
+// text back. Comment lines are ignored like git commit does. While the edited
+// message has validation errors, they are shown and the user can edit it again.
 func (s *Service) EditCommitMessage(commitMessage *types.CommitMessage) (*types.CommitMessage, error) {
-	// Create a temporary file with the commit message
+	text := commitMessage.FormattedMessage
+	if text == "" {
+		text = commitMessage.String()
+	}
+
+	for {
+		edited, err := s.editText(text)
+		if err != nil {
+			return nil, err
+		}
+
+		message := validation.StripComments(edited)
+		if message == "" {
+			return nil, fmt.Errorf("commit message cannot be empty")
+		}
+
+		editedMessage := s.parseMessage(message)
+		editedMessage.Language = commitMessage.Language
+		result := s.validator.ValidateCommitMessage(editedMessage)
+		editedMessage.ValidationResult = s.convertValidationResult(result)
+		if result.IsValid {
+			return editedMessage, nil
+		}
+
+		// Show the errors and keep them as comments for the next edit
+		var problems []string
+		for _, diagnostic := range s.validator.Lint(message) {
+			if diagnostic.Severity == validation.SeverityError {
+				problems = append(problems, diagnostic.String())
+			}
+		}
+		fmt.Println("The edited message has validation errors:")
+		for _, problem := range problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+		if !s.askYesNo("Edit the message again? [Y/n]: ", true) {
+			return editedMessage, nil
+		}
+		text = message + "\n\n# Validation errors:\n# " + strings.Join(problems, "\n# ") + "\n"
+	}
+}
+
+// editText opens a text in $EDITOR and returns the edited text
+func (s *Service) editText(text string) (string, error) {
+	// Create a temporary file with the text
 	tmpFile, err := os.CreateTemp("", "commit-msg-*.txt")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
 	defer os.Remove(tmpFile.Name())
 
-	// Write the current message to the file
-	if _, err := tmpFile.WriteString(commitMessage.String()); err != nil {
-		return nil, fmt.Errorf("failed to write to temp file: %w", err)
+	if _, err := tmpFile.WriteString(text); err != nil {
+		tmpFile.Close()
+		return "", fmt.Errorf("failed to write to temp file: %w", err)
 	}
 	tmpFile.Close()
 
@@ -543,31 +757,26 @@ func (s *Service) EditCommitMessage(commitMessage *types.CommitMessage) (*types.
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("failed to run editor: %w", err)
+		return "", fmt.Errorf("failed to run editor: %w", err)
 	}
 
-	// Read the edited content
 	content, err := os.ReadFile(tmpFile.Name())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read edited file: %w", err)
-	}
-
-	// Parse the edited content back into a commit message
-	editedText := strings.TrimSpace(string(content))
-	if editedText == "" {
-		return nil, fmt.Errorf("commit message cannot be empty")
+		return "", fmt.Errorf("failed to read edited file: %w", err)
 	}
+	return string(content), nil
+}
 
-	// For simplicity, create a new commit message with the edited text
-	// In a more sophisticated implementation, we could parse the conventional format
-	editedMessage := &types.CommitMessage{
-		Type:        commitMessage.Type,
-		Scope:       commitMessage.Scope,
-		Description: editedText,
-		Breaking:    commitMessage.Breaking,
+// parseMessage parses a message written in the selected style. The message
+// is committed as written.
+func (s *Service) parseMessage(message string) *types.CommitMessage {
+	parsed := validation.ParseMessage(message)
+	if header, ok := s.style.Parse(parsed.Subject); ok {
+		parsed.Type, parsed.Scope, parsed.Description = types.CommitType(header.Type), header.Scope, header.Description
+		parsed.Breaking = parsed.Breaking || header.Breaking
 	}
-
-	return editedMessage, nil
+	parsed.FormattedMessage = message
+	return parsed
 }
 
 // GetFileStats returns statistics about the repository
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// newTestService creates a service without Git or AI for a message style
+func newTestService(styleName string) *Service {
+	return NewService(nil, nil, nil, types.Config{Output: types.OutputConfig{Style: styleName, MaxSubjectLength: 72}})
+}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    string
+		message  string
+		expected types.CommitMessage
+	}{
+		{
+			name:    "conventional",
+			style:   "conventional",
+			message: "feat(auth)!: drop session cookies\n\nTokens replace cookies.\n\nBREAKING CHANGE: clients must send a token\nRefs: #12",
+			expected: types.CommitMessage{
+				Type: "feat", Scope: "auth", Description: "drop session cookies", Breaking: true,
+				Subject: "feat(auth)!: drop session cookies", Body: "Tokens replace cookies.",
+				Footer: "BREAKING CHANGE: clients must send a token\nRefs: #12",
+			},
+		},
+		{
+			name:    "gitmoji",
+			style:   "gitmoji",
+			message: "🐛 (api) Handle empty bodies\n\nReturn 400 instead of panicking.\n\nRefs: #7",
+			expected: types.CommitMessage{
+				Type: "fix", Scope: "api", Description: "Handle empty bodies",
+				Subject: "🐛 (api) Handle empty bodies", Body: "Return 400 instead of panicking.", Footer: "Refs: #7",
+			},
+		},
+		{
+			name:    "gitmoji breaking change footer",
+			style:   "gitmoji",
+			message: "💥 Remove the v1 API\n\nBREAKING CHANGE: v1 clients stop working",
+			expected: types.CommitMessage{
+				Description: "Remove the v1 API", Breaking: true,
+				Subject: "💥 Remove the v1 API", Footer: "BREAKING CHANGE: v1 clients stop working",
+			},
+		},
+		{
+			name:    "plain",
+			style:   "traditional",
+			message: "Update the install guide\n\nDescribe the Homebrew tap.\n\nSigned-off-by: Jane Doe <jane@example.com>",
+			expected: types.CommitMessage{
+				Description: "Update the install guide",
+				Subject:     "Update the install guide", Body: "Describe the Homebrew tap.",
+				Footer: "Signed-off-by: Jane Doe <jane@example.com>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newTestService(tt.style).parseMessage(tt.message)
+			assert.Equal(t, tt.expected.Type, parsed.Type)
+			assert.Equal(t, tt.expected.Scope, parsed.Scope)
+			assert.Equal(t, tt.expected.Description, parsed.Description)
+			assert.Equal(t, tt.expected.Breaking, parsed.Breaking)
+			assert.Equal(t, tt.expected.Subject, parsed.Subject)
+			assert.Equal(t, tt.expected.Body, parsed.Body)
+			assert.Equal(t, tt.expected.Footer, parsed.Footer)
+			assert.Equal(t, tt.message, parsed.FormattedMessage)
+		})
+	}
+}
+
+func TestFinalizeMessageSyncsHeader(t *testing.T) {
+	service := newTestService("angular")
+	commitMessage := &types.CommitMessage{
+		Type:        "feat",
+		Scope:       "auth",
+		Description: "Drop session cookies.",
+		Body:        "Tokens replace cookies.",
+		Footer:      "BREAKING CHANGE: clients must send a token",
+	}
+
+	_, _, attempts := service.finalizeMessage(context.Background(), commitMessage, "angular")
+	assert.Zero(t, attempts)
+	require.Contains(t, commitMessage.FormattedMessage, "feat(auth): drop session cookies\n")
+	assert.Equal(t, types.CommitType("feat"), commitMessage.Type)
+	assert.Equal(t, "auth", commitMessage.Scope)
+	assert.Equal(t, "drop session cookies", commitMessage.Description)
+	assert.True(t, commitMessage.Breaking, "the footer marks Angular breaking changes")
+}
+
+// initTestRepo creates a Git repository and returns its directory and a function running git in it
+func initTestRepo(t *testing.T) (string, func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	gitCmd("init", "-q", "-b", "main")
+	return dir, gitCmd
+}
+
+func TestValidateChangesWithRevisions(t *testing.T) {
+	dir, gitCmd := initTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.go"), []byte("package app\n"), 0644))
+	gitCmd("add", ".")
+	gitCmd("commit", "-q", "-m", "feat: initial")
+	gitCmd("checkout", "-q", "-b", "feature")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.go"), []byte("package app\n\nfunc Run() {}\n"), 0644))
+	gitCmd("commit", "-q", "-am", "feat: add run")
+
+	service := NewService(git.NewService(dir), nil, nil, types.Config{Output: types.OutputConfig{MaxSubjectLength: 72}})
+
+	// The working tree is clean, so only revisions can be described
+	for _, options := range []GenerateOptions{
+		{IncludeStaged: true, Range: "HEAD~1..HEAD", DryRun: true},
+		{IncludeStaged: true, Against: "main", DryRun: true},
+	} {
+		assert.NoError(t, service.ValidateChanges(options), options.diffOptions().Description())
+	}
+	assert.EqualError(t, service.ValidateChanges(GenerateOptions{IncludeStaged: true}), "no staged changes found")
+}
+
+func TestRulesFromRepositoryRoot(t *testing.T) {
+	dir, _ := initTestRepo(t)
+	rc := `{"rules": {"header-max-length": [2, "always", 50]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".commitlintrc.json"), []byte(rc), 0644))
+	subdir := filepath.Join(dir, "services", "api")
+	require.NoError(t, os.MkdirAll(subdir, 0755))
+
+	service := NewService(git.NewService(subdir), nil, nil, types.Config{Output: types.OutputConfig{MaxSubjectLength: 72}})
+	assert.Equal(t, 50, service.baseRules.MaxSubjectLength)
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/ai"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Session is an interactive review of a generated commit message. The
+// message can be refined by follow-up turns of the same conversation,
+// regenerated, edited or given another scope before it is committed.
+type Session struct {
+	service      *Service
+	options      GenerateOptions
+	changes      *selectedChanges
+	conversation *ai.Conversation
+
+	Result *GenerateResult // The message under review
+}
+
+// StartSession generates a first message for the selected changes
+func (s *Service) StartSession(ctx context.Context, options GenerateOptions) (*Session, error) {
+	changes, err := s.prepareChanges(options)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{service: s, options: options, changes: changes}
+	if err := session.Regenerate(ctx); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Regenerate generates a new message in a new conversation, discarding the
+// previous refinements
+func (ss *Session) Regenerate(ctx context.Context) error {
+	conversation, commitMessage, err := ss.service.aiClient.StartConversation(ctx, ss.changes.processedDiff, ss.changes.styleName)
+	if err != nil {
+		return fmt.Errorf("failed to generate commit message: %w", err)
+	}
+
+	ss.conversation = conversation
+	commitMessage.Trailers = ss.changes.trailers
+	ss.Result = ss.service.buildResult(ctx, commitMessage, ss.changes)
+	return nil
+}
+
+// Refine sends an instruction, such as ai.InstructionShorter, as a follow-up
+// turn building on the current message
+func (ss *Session) Refine(ctx context.Context, instruction string) error {
+	commitMessage, err := ss.conversation.Refine(ctx, ss.Message(), instruction)
+	if err != nil {
+		return err
+	}
+
+	commitMessage.Trailers = ss.changes.trailers
+	ss.Result = ss.service.buildResult(ctx, commitMessage, ss.changes)
+	return nil
+}
+
+// Edit opens the message in $EDITOR; the edited message is kept as written
+func (ss *Session) Edit() error {
+	edited, err := ss.service.EditCommitMessage(ss.Result.CommitMessage)
+	if err != nil {
+		return err
+	}
+
+	ss.setMessage(edited)
+	return nil
+}
+
+// SetScope rewrites the header with another scope, keeping the rest of the
+// message as it is; an empty scope removes it. Styles without scopes, such
+// as traditional and minimal, return an error.
+func (ss *Session) SetScope(scope string) error {
+	header, rest, hasBody := strings.Cut(ss.Message(), "\n")
+	message, err := ss.service.style.WithScope(header, scope)
+	if err != nil {
+		return err
+	}
+
+	if hasBody {
+		message += "\n" + rest
+	}
+	ss.setMessage(ss.service.parseMessage(message))
+	return nil
+}
+
+// setMessage replaces the message under review by a message written by hand
+func (ss *Session) setMessage(commitMessage *types.CommitMessage) {
+	validationResult := ss.service.validator.ValidateCommitMessage(commitMessage)
+	commitMessage.ValidationResult = ss.service.convertValidationResult(validationResult)
+
+	ss.Result.CommitMessage = commitMessage
+	ss.Result.Fixes, ss.Result.RepairAttempts = nil, 0
+	ss.Result.Diagnostics = ss.service.validator.Lint(commitMessage.FormattedMessage)
+	ss.Result.Preview = ss.service.createPreviewWithValidation(commitMessage, ss.changes.processedDiff, validationResult)
+}
+
+// Message returns the message under review as it will be committed
+func (ss *Session) Message() string {
+	if ss.Result.CommitMessage.FormattedMessage != "" {
+		return ss.Result.CommitMessage.FormattedMessage
+	}
+	return ss.Result.CommitMessage.String()
+}
+
+// Turns returns the number of requests answered in the current conversation
+func (ss *Session) Turns() int {
+	return ss.conversation.Turns()
+}
+
+// Diff returns the diff of the changes the message describes
+func (ss *Session) Diff() (string, error) {
+	diffOutput, err := ss.service.gitService.GetDiffFor(ss.options.diffOptions())
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+	return diffOutput, nil
+}
+
+// CanCommit reports whether the changes can be committed, unlike ranges and
+// untracked files that are only previewed
+func (ss *Session) CanCommit() bool {
+	return !ss.options.DryRun && !ss.options.IsPreviewOnly()
+}
+
+// Commit applies the message under review
+func (ss *Session) Commit() error {
+	if err := ss.service.applyCommit(ss.Result.CommitMessage); err != nil {
+		return fmt.Errorf("failed to apply commit: %w", err)
+	}
+	ss.Result.Applied = true
+	return nil
+}
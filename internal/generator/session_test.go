@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/internal/diff"
+)
+
+// newTestSession creates a session reviewing a message, without Git or AI
+func newTestSession(styleName, message string) *Session {
+	service := newTestService(styleName)
+	return &Session{
+		service: service,
+		changes: &selectedChanges{processedDiff: &diff.ProcessedDiff{}, styleName: styleName},
+		Result:  &GenerateResult{CommitMessage: service.parseMessage(message)},
+	}
+}
+
+func TestSessionSetScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    string
+		message  string
+		scope    string
+		expected string
+		err      string
+	}{
+		{
+			name:     "conventional adds a scope",
+			style:    "conventional",
+			message:  "feat: add login\n\nUsers sign in with email.",
+			scope:    "auth",
+			expected: "feat(auth): add login\n\nUsers sign in with email.",
+		},
+		{
+			name:     "conventional keeps the breaking marker",
+			style:    "conventional",
+			message:  "feat(api)!: drop v1",
+			scope:    "server",
+			expected: "feat(server)!: drop v1",
+		},
+		{
+			name:     "angular keeps a breaking marker",
+			style:    "angular",
+			message:  "feat(api)!: drop v1\n\nClients move to v2.\n\nBREAKING CHANGE: v1 is gone",
+			scope:    "",
+			expected: "feat!: drop v1\n\nClients move to v2.\n\nBREAKING CHANGE: v1 is gone",
+		},
+		{
+			name:     "gitmoji keeps the gitmoji",
+			style:    "gitmoji",
+			message:  "🚑️ Restore logins\n\n- Refresh expired sessions",
+			scope:    "auth",
+			expected: "🚑️ (auth): Restore logins\n\n- Refresh expired sessions",
+		},
+		{
+			name:     "gitmoji removes the scope",
+			style:    "gitmoji",
+			message:  ":bug: (api): Handle empty bodies",
+			scope:    "",
+			expected: ":bug: Handle empty bodies",
+		},
+		{
+			name:    "minimal has no scope",
+			style:   "minimal",
+			message: "Add login",
+			scope:   "auth",
+			err:     "the minimal style has no scope",
+		},
+		{
+			name:    "header not in the style",
+			style:   "conventional",
+			message: "Add login",
+			scope:   "auth",
+			err:     "does not follow the conventional style",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newTestSession(tt.style, tt.message)
+			err := session.SetScope(tt.scope)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Equal(t, tt.message, session.Message(), "the message is unchanged")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, session.Message())
+			assert.Equal(t, tt.scope, session.Result.CommitMessage.Scope)
+		})
+	}
+}
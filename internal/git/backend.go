@@ -0,0 +1,117 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Supported backend names for the git.backend configuration option
+const (
+	BackendExec  = "exec"   // Shells out to the git binary
+	BackendGoGit = "go-git" // Pure-Go implementation, no git binary required
+)
+
+// ErrNotRepository is returned by backends when the path is not inside a Git repository
+var ErrNotRepository = errors.New("not in a Git repository")
+
+// RejectedRef is a reference the remote refused to update during a push
+type RejectedRef struct {
+	Ref    string // Remote reference name, e.g. refs/tags/v1.2.0
+	Reason string // Why it was rejected, e.g. "already exists"
+}
+
+// PushRejectedError reports the references a remote refused to update
+type PushRejectedError struct {
+	Remote   string
+	Rejected []RejectedRef
+}
+
+// Error lists the rejected references and their reasons
+func (e *PushRejectedError) Error() string {
+	refs := make([]string, 0, len(e.Rejected))
+	for _, rejected := range e.Rejected {
+		refs = append(refs, fmt.Sprintf("%s (%s)", rejected.Ref, rejected.Reason))
+	}
+	return fmt.Sprintf("push to %s rejected: %s", e.Remote, strings.Join(refs, ", "))
+}
+
+// Backend is the low-level Git implementation used by Service.
+// Backends return raw data (unified diffs, commit lists, tags); parsing and
+// higher-level logic stay in Service so every backend behaves the same way.
+type Backend interface {
+	// IsRepository reports whether the backend points at a Git repository
+	IsRepository() bool
+	// Root returns the absolute path of the top-level directory of the working tree
+	Root() (string, error)
+	// HasStagedChanges reports whether the index differs from HEAD
+	HasStagedChanges() (bool, error)
+	// HasUnstagedChanges reports whether the working tree differs from the index,
+	// including untracked files
+	HasUnstagedChanges() (bool, error)
+	// StagedDiff returns the unified diff between HEAD and the index
+	StagedDiff() (string, error)
+	// WorkingDiff returns the unified diff between the index and the working tree
+	WorkingDiff() (string, error)
+	// ListFiles returns the paths of all tracked files
+	ListFiles() ([]string, error)
+	// ListFilesAt returns the paths of all files in the tree of a revision
+	ListFilesAt(rev string) ([]string, error)
+	// ReadFilesAt returns the contents of files in the tree of a revision, keyed by path
+	ReadFilesAt(rev string, paths []string) (map[string][]byte, error)
+	// CommitMessage returns the full message of a commit as written by its author
+	CommitMessage(rev string) (string, error)
+	// Log returns commits reachable from options.To (HEAD by default), newest first
+	Log(options LogOptions) ([]*types.CommitInfo, error)
+	// CommitDiff returns the unified diff introduced by a commit
+	CommitDiff(rev string) (string, error)
+	// RangeDiff returns the unified diff between the trees of two revisions
+	RangeDiff(from, to string) (string, error)
+	// MergeBase returns the hash of the best common ancestor of two revisions
+	MergeBase(a, b string) (string, error)
+	// UntrackedDiff returns untracked, non-ignored files as a unified diff of additions
+	UntrackedDiff() (string, error)
+	// ResolveRevision returns the commit hash a revision points to
+	ResolveRevision(rev string) (string, error)
+	// CurrentBranch returns the short name of the checked-out branch, or "" when HEAD is detached
+	CurrentBranch() (string, error)
+	// UserIdentity returns the committer as "Name <email>", or "" when it is not configured
+	UserIdentity() (string, error)
+	// Tags returns all tags; Version is left for Service to fill in
+	Tags() ([]*types.GitTag, error)
+	// CreateTag creates a tag pointing at HEAD
+	CreateTag(name, message string, annotated bool) error
+	// DeleteTag deletes a local tag
+	DeleteTag(name string) error
+	// Push updates the remote with the given refspecs; rejected references are reported as *PushRejectedError
+	Push(remote string, refspecs []string, atomic bool) error
+	// AddAll stages all changes in the working tree
+	AddAll() error
+	// Add stages the given paths, relative to the repository root
+	Add(paths ...string) error
+	// Commit records the staged changes with the given message
+	Commit(message string) error
+}
+
+// LogOptions filters the commits returned by Backend.Log
+type LogOptions struct {
+	MaxCount int      // Maximum number of commits, 0 means no limit
+	Paths    []string // Only commits touching these paths, relative to the repository root
+	NoMerges bool     // Skip merge commits
+	From     string   // Exclude commits reachable from this revision, as in git log From..To
+	To       string   // Revision to start from, HEAD when empty
+}
+
+// NewBackend creates the backend registered under name for the repository at repoPath
+func NewBackend(name, repoPath string) (Backend, error) {
+	switch name {
+	case "", BackendExec:
+		return NewExecBackend(repoPath), nil
+	case BackendGoGit:
+		return NewGoGitBackend(repoPath), nil
+	default:
+		return nil, fmt.Errorf("unknown git backend: %s (must be one of: %s, %s)", name, BackendExec, BackendGoGit)
+	}
+}
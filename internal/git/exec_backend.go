@@ -0,0 +1,502 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// fieldSeparator separates fields in custom git --format output.
+// The ASCII unit separator never appears in commit subjects or tag names.
+const fieldSeparator = "\x1f"
+
+// recordSeparator terminates multi-line records such as commits with bodies
+const recordSeparator = "\x1e"
+
+// gitDateLayout is the layout of dates printed with --date=iso
+const gitDateLayout = "2006-01-02 15:04:05 -0700"
+
+// ExecBackend implements Backend by running the git binary
+type ExecBackend struct {
+	repoPath string
+}
+
+// NewExecBackend creates a backend that shells out to git in repoPath
+func NewExecBackend(repoPath string) *ExecBackend {
+	if repoPath == "" {
+		repoPath = "."
+	}
+	return &ExecBackend{repoPath: repoPath}
+}
+
+// command builds a git command that runs inside the repository
+func (b *ExecBackend) command(args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = b.repoPath
+	return cmd
+}
+
+// run executes a git command and returns its stdout, including stderr in errors
+func (b *ExecBackend) run(args ...string) (string, error) {
+	cmd := b.command(args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return string(output), nil
+}
+
+// differs runs a quiet diff command and maps exit code 1 to "has differences"
+func (b *ExecBackend) differs(args ...string) (bool, error) {
+	err := b.command(args...).Run()
+	if err == nil {
+		return false, nil
+	}
+	if exitError, ok := err.(*exec.ExitError); ok && exitError.ExitCode() == 1 {
+		return true, nil
+	}
+	return false, err
+}
+
+// IsRepository checks if the repository path is inside a Git repository
+func (b *ExecBackend) IsRepository() bool {
+	return b.command("rev-parse", "--git-dir").Run() == nil
+}
+
+// Root returns the top-level directory of the working tree
+func (b *ExecBackend) Root() (string, error) {
+	output, err := b.run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// HasStagedChanges checks if there are staged changes
+func (b *ExecBackend) HasStagedChanges() (bool, error) {
+	changed, err := b.differs("diff", "--cached", "--quiet")
+	if err != nil {
+		return false, fmt.Errorf("failed to check staged changes: %w", err)
+	}
+	return changed, nil
+}
+
+// HasUnstagedChanges checks if there are unstaged changes (including untracked files)
+func (b *ExecBackend) HasUnstagedChanges() (bool, error) {
+	changed, err := b.differs("diff", "--quiet")
+	if err != nil {
+		return false, fmt.Errorf("failed to check unstaged changes: %w", err)
+	}
+	if changed {
+		return true, nil
+	}
+
+	output, err := b.run("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return false, fmt.Errorf("failed to check untracked files: %w", err)
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
+// StagedDiff returns the diff of staged changes
+func (b *ExecBackend) StagedDiff() (string, error) {
+	output, err := b.run("diff", "--cached")
+	if err != nil {
+		return "", fmt.Errorf("failed to get staged diff: %w", err)
+	}
+	return output, nil
+}
+
+// WorkingDiff returns the diff of working directory changes
+func (b *ExecBackend) WorkingDiff() (string, error) {
+	output, err := b.run("diff")
+	if err != nil {
+		return "", fmt.Errorf("failed to get working diff: %w", err)
+	}
+	return output, nil
+}
+
+// ListFiles returns all tracked files
+func (b *ExecBackend) ListFiles() ([]string, error) {
+	output, err := b.run("ls-files")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file list: %w", err)
+	}
+	return splitLines(output), nil
+}
+
+// ListFilesAt returns all files in the tree of a revision. Names are read
+// NUL-separated, so git does not quote paths with spaces or non-ASCII characters.
+func (b *ExecBackend) ListFilesAt(rev string) ([]string, error) {
+	output, err := b.run("ls-tree", "-r", "-z", "--name-only", "--full-tree", rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files at %s: %w", rev, err)
+	}
+	return strings.FieldsFunc(output, func(r rune) bool { return r == 0 }), nil
+}
+
+// ReadFilesAt returns the contents of files in the tree of a revision, read
+// by a single git cat-file --batch process instead of one process per file
+func (b *ExecBackend) ReadFilesAt(rev string, paths []string) (map[string][]byte, error) {
+	var input strings.Builder
+	for _, path := range paths {
+		if strings.Contains(path, "\n") {
+			return nil, fmt.Errorf("failed to read %q at %s: path contains a newline", path, rev)
+		}
+		input.WriteString(rev + ":" + path + "\n")
+	}
+
+	cmd := b.command("cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(input.String())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read files at %s: %w: %s", rev, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Each object is "<hash> blob <size>\n<content>\n", or "<name> missing\n"
+	reader := bufio.NewReader(bytes.NewReader(output))
+	contents := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 || fields[1] != "blob" {
+			return nil, fmt.Errorf("failed to read %s at %s: %s", path, rev, strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: invalid size %q", path, rev, fields[2])
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+		}
+		contents[path] = content[:size]
+	}
+	return contents, nil
+}
+
+// CommitMessage returns the full message of a commit as written by its author
+func (b *ExecBackend) CommitMessage(rev string) (string, error) {
+	output, err := b.run("log", "-1", "--format=%B", rev, "--")
+	if err != nil {
+		return "", fmt.Errorf("failed to read message of %s: %w", rev, err)
+	}
+	return strings.TrimRight(output, "\n"), nil
+}
+
+// Log returns commit history matching the options
+func (b *ExecBackend) Log(options LogOptions) ([]*types.CommitInfo, error) {
+	format := strings.Join([]string{"%H", "%s", "%an", "%ae", "%ad", "%f", "%b"}, fieldSeparator) + recordSeparator
+	args := []string{"log", "--pretty=format:" + format, "--date=iso"}
+	if options.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if options.MaxCount > 0 {
+		args = append(args, fmt.Sprintf("-%d", options.MaxCount))
+	}
+	to := options.To
+	if to == "" {
+		to = "HEAD"
+	}
+	if options.From != "" {
+		args = append(args, options.From+".."+to)
+	} else {
+		args = append(args, to)
+	}
+	// Anchor paths at the repository root like the go-git backend does,
+	// instead of resolving them against the working directory
+	args = append(args, "--")
+	for _, path := range options.Paths {
+		args = append(args, ":/"+path)
+	}
+
+	output, err := b.run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+
+	var commits []*types.CommitInfo
+	for _, record := range strings.Split(output, recordSeparator) {
+		parts := strings.SplitN(strings.TrimLeft(record, "\n"), fieldSeparator, 7)
+		if len(parts) < 7 {
+			continue
+		}
+
+		parsedDate, _ := time.Parse(gitDateLayout, parts[4])
+		commits = append(commits, &types.CommitInfo{
+			Hash:        parts[0],
+			Subject:     parts[1],
+			Body:        strings.TrimSpace(parts[6]),
+			Author:      parts[2],
+			AuthorEmail: parts[3],
+			Date:        parsedDate,
+			Slug:        parts[5],
+		})
+	}
+
+	return commits, nil
+}
+
+// CommitDiff returns the diff introduced by a commit
+func (b *ExecBackend) CommitDiff(rev string) (string, error) {
+	output, err := b.run("show", "--format=", rev)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit diff: %w", err)
+	}
+	return output, nil
+}
+
+// RangeDiff returns the diff between two revisions
+func (b *ExecBackend) RangeDiff(from, to string) (string, error) {
+	output, err := b.run("diff", from, to, "--")
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between %s and %s: %w", from, to, err)
+	}
+	return output, nil
+}
+
+// MergeBase returns the best common ancestor of two revisions
+func (b *ExecBackend) MergeBase(first, second string) (string, error) {
+	output, err := b.run("merge-base", first, second)
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", first, second, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// UntrackedDiff returns untracked files as additions using git diff --no-index
+func (b *ExecBackend) UntrackedDiff() (string, error) {
+	// -z keeps paths unquoted so they can be passed back to git as-is
+	output, err := b.run("ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return "", fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var diffOutput strings.Builder
+	for _, path := range strings.Split(output, "\x00") {
+		if path == "" {
+			continue
+		}
+		// git diff --no-index exits with 1 when the files differ, which is always the case here
+		cmd := b.command("diff", "--no-index", "--", "/dev/null", path)
+		fileDiff, err := cmd.Output()
+		if exitError, ok := err.(*exec.ExitError); err != nil && (!ok || exitError.ExitCode() != 1) {
+			return "", fmt.Errorf("failed to diff untracked file %s: %w", path, err)
+		}
+		diffOutput.Write(fileDiff)
+	}
+	return diffOutput.String(), nil
+}
+
+// ResolveRevision returns the commit hash a revision points to
+func (b *ExecBackend) ResolveRevision(rev string) (string, error) {
+	output, err := b.run("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// CurrentBranch returns the checked-out branch name, or "" for a detached HEAD
+func (b *ExecBackend) CurrentBranch() (string, error) {
+	output, err := b.run("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && exitError.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// UserIdentity returns the committer identity git would record, honouring GIT_COMMITTER_* variables
+func (b *ExecBackend) UserIdentity() (string, error) {
+	output, err := b.run("var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		// git var fails when user.name or user.email is missing
+		return "", nil
+	}
+	// Strip the trailing "<timestamp> <timezone>"
+	fields := strings.Fields(strings.TrimSpace(output))
+	if len(fields) < 3 {
+		return "", nil
+	}
+	return strings.Join(fields[:len(fields)-2], " "), nil
+}
+
+// Tags returns all tags in a single git invocation
+func (b *ExecBackend) Tags() ([]*types.GitTag, error) {
+	format := strings.Join([]string{
+		"%(refname:short)",
+		"%(objecttype)",
+		"%(if)%(*objectname)%(then)%(*objectname)%(else)%(objectname)%(end)",
+		"%(creatordate:iso)",
+		"%(contents:subject)",
+	}, fieldSeparator)
+
+	output, err := b.run("tag", "-l", "--sort=-version:refname", "--format="+format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var tags []*types.GitTag
+	for _, line := range splitLines(output) {
+		parts := strings.SplitN(line, fieldSeparator, 5)
+		if len(parts) < 5 {
+			continue
+		}
+
+		tag := &types.GitTag{
+			Name:        parts[0],
+			IsAnnotated: parts[1] == "tag",
+			Hash:        parts[2],
+			Message:     parts[4],
+		}
+		if date, err := time.Parse(gitDateLayout, parts[3]); err == nil {
+			tag.Date = date
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// CreateTag creates a new Git tag at HEAD
+func (b *ExecBackend) CreateTag(name, message string, annotated bool) error {
+	args := []string{"tag", name}
+	if annotated {
+		args = []string{"tag", "-a", name, "-m", message}
+	}
+	if _, err := b.run(args...); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteTag deletes a local tag
+func (b *ExecBackend) DeleteTag(name string) error {
+	if _, err := b.run("tag", "-d", name); err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// Push pushes refspecs to a remote, reading rejections from git's porcelain output
+func (b *ExecBackend) Push(remote string, refspecs []string, atomic bool) error {
+	args := []string{"push", "--porcelain"}
+	if atomic {
+		args = append(args, "--atomic")
+	}
+	args = append(append(args, remote), refspecs...)
+
+	cmd := b.command(args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if rejected := parsePorcelainRejections(stdout.String()); len(rejected) > 0 {
+			return &PushRejectedError{Remote: remote, Rejected: rejected}
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("failed to push to %s: %w: %s", remote, err, msg)
+		}
+		return fmt.Errorf("failed to push to %s: %w", remote, err)
+	}
+	return nil
+}
+
+// parsePorcelainRejections extracts rejected references from git push --porcelain output.
+// Rejected lines look like "!\trefs/tags/v1:refs/tags/v1\t[rejected] (already exists)".
+func parsePorcelainRejections(output string) []RejectedRef {
+	var rejected []RejectedRef
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 || fields[0] != "!" {
+			continue
+		}
+
+		ref := fields[1]
+		if _, to, found := strings.Cut(ref, ":"); found {
+			ref = to
+		}
+
+		summary := strings.TrimSpace(fields[2])
+		status, reason := summary, ""
+		if open := strings.Index(summary, "("); open >= 0 {
+			status = strings.TrimSpace(summary[:open])
+			reason = strings.TrimSuffix(summary[open+1:], ")")
+		}
+		status = strings.Trim(status, "[]")
+		if status != "rejected" && reason != "" {
+			reason = status + ": " + reason
+		} else if reason == "" {
+			reason = status
+		}
+
+		rejected = append(rejected, RejectedRef{Ref: ref, Reason: reason})
+	}
+	return rejected
+}
+
+// AddAll stages all changes in the working directory
+func (b *ExecBackend) AddAll() error {
+	if _, err := b.run("add", "."); err != nil {
+		return fmt.Errorf("failed to stage all changes: %w", err)
+	}
+	return nil
+}
+
+// Add stages paths relative to the repository root; the :/ pathspec magic
+// anchors them at the root whatever the working directory is
+func (b *ExecBackend) Add(paths ...string) error {
+	args := []string{"add", "--"}
+	for _, path := range paths {
+		args = append(args, ":/"+path)
+	}
+	if _, err := b.run(args...); err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
+	}
+	return nil
+}
+
+// Commit records the staged changes
+func (b *ExecBackend) Commit(message string) error {
+	if _, err := b.run("commit", "-m", message); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// splitLines splits command output into non-empty, trimmed lines
+func splitLines(output string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,1065 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/utils/binary"
+	utildiff "github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// GoGitBackend implements Backend in pure Go using go-git.
+// It works without a git binary and can operate on in-memory repositories.
+type GoGitBackend struct {
+	repo *gogit.Repository
+}
+
+// NewGoGitBackend opens the repository containing repoPath.
+// If no repository is found, IsRepository reports false and every other
+// method returns ErrNotRepository.
+func NewGoGitBackend(repoPath string) *GoGitBackend {
+	if repoPath == "" {
+		repoPath = "."
+	}
+	repo, err := gogit.PlainOpenWithOptions(repoPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return &GoGitBackend{}
+	}
+	return &GoGitBackend{repo: repo}
+}
+
+// NewGoGitBackendFromRepository wraps an already opened repository, such as
+// one created with memory storage in tests
+func NewGoGitBackendFromRepository(repo *gogit.Repository) *GoGitBackend {
+	return &GoGitBackend{repo: repo}
+}
+
+// IsRepository reports whether a repository was opened
+func (b *GoGitBackend) IsRepository() bool {
+	return b.repo != nil
+}
+
+// Root returns the top-level directory of the working tree
+func (b *GoGitBackend) Root() (string, error) {
+	if b.repo == nil {
+		return "", ErrNotRepository
+	}
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	return worktree.Filesystem.Root(), nil
+}
+
+// status returns the worktree status
+func (b *GoGitBackend) status() (gogit.Status, error) {
+	if b.repo == nil {
+		return nil, ErrNotRepository
+	}
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	return worktree.Status()
+}
+
+// HasStagedChanges checks if the index differs from HEAD
+func (b *GoGitBackend) HasStagedChanges() (bool, error) {
+	status, err := b.status()
+	if err != nil {
+		return false, fmt.Errorf("failed to check staged changes: %w", err)
+	}
+	for _, file := range status {
+		if file.Staging != gogit.Unmodified && file.Staging != gogit.Untracked {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasUnstagedChanges checks if the working tree differs from the index, including untracked files
+func (b *GoGitBackend) HasUnstagedChanges() (bool, error) {
+	status, err := b.status()
+	if err != nil {
+		return false, fmt.Errorf("failed to check unstaged changes: %w", err)
+	}
+	for _, file := range status {
+		if file.Worktree != gogit.Unmodified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// StagedDiff returns the unified diff between HEAD and the index
+func (b *GoGitBackend) StagedDiff() (string, error) {
+	if b.repo == nil {
+		return "", ErrNotRepository
+	}
+
+	headFiles, err := b.headFiles()
+	if err != nil {
+		return "", fmt.Errorf("failed to get staged diff: %w", err)
+	}
+	idx, err := b.repo.Storer.Index()
+	if err != nil {
+		return "", fmt.Errorf("failed to read index: %w", err)
+	}
+
+	var patches []fdiff.FilePatch
+	seen := make(map[string]bool)
+	for _, entry := range idx.Entries {
+		if entry.Stage != resolvedStage {
+			continue
+		}
+		seen[entry.Name] = true
+		to := &patchFile{path: entry.Name, hash: entry.Hash, mode: entry.Mode}
+
+		from, exists := headFiles[entry.Name]
+		if exists && from.hash == to.hash && from.mode == to.mode {
+			continue
+		}
+
+		patch, err := b.blobPatch(from, to)
+		if err != nil {
+			return "", fmt.Errorf("failed to diff %s: %w", entry.Name, err)
+		}
+		patches = append(patches, patch)
+	}
+
+	for path, from := range headFiles {
+		if seen[path] {
+			continue
+		}
+		patch, err := b.blobPatch(from, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to diff %s: %w", path, err)
+		}
+		patches = append(patches, patch)
+	}
+
+	return encodePatches(patches)
+}
+
+// WorkingDiff returns the unified diff between the index and the working tree
+func (b *GoGitBackend) WorkingDiff() (string, error) {
+	if b.repo == nil {
+		return "", ErrNotRepository
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working diff: %w", err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working diff: %w", err)
+	}
+	idx, err := b.repo.Storer.Index()
+	if err != nil {
+		return "", fmt.Errorf("failed to read index: %w", err)
+	}
+
+	var patches []fdiff.FilePatch
+	for path, fileStatus := range status {
+		if fileStatus.Worktree != gogit.Modified && fileStatus.Worktree != gogit.Deleted {
+			continue
+		}
+		entry, err := idx.Entry(path)
+		if err != nil {
+			continue
+		}
+		from := &patchFile{path: path, hash: entry.Hash, mode: entry.Mode}
+
+		if fileStatus.Worktree == gogit.Deleted {
+			patch, err := b.blobPatch(from, nil)
+			if err != nil {
+				return "", fmt.Errorf("failed to diff %s: %w", path, err)
+			}
+			patches = append(patches, patch)
+			continue
+		}
+
+		content, mode, err := readWorktreeFile(worktree, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		to := &patchFile{path: path, hash: plumbing.ComputeHash(plumbing.BlobObject, content), mode: mode}
+
+		oldContent, err := b.blobContent(from.hash)
+		if err != nil {
+			return "", fmt.Errorf("failed to diff %s: %w", path, err)
+		}
+		patches = append(patches, newFilePatch(from, to, oldContent, content))
+	}
+
+	return encodePatches(patches)
+}
+
+// ListFiles returns the paths of all files in the index
+func (b *GoGitBackend) ListFiles() ([]string, error) {
+	if b.repo == nil {
+		return nil, ErrNotRepository
+	}
+	idx, err := b.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file list: %w", err)
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, entry := range idx.Entries {
+		if !seen[entry.Name] {
+			seen[entry.Name] = true
+			files = append(files, entry.Name)
+		}
+	}
+	return files, nil
+}
+
+// ListFilesAt returns the paths of all files in the tree of a revision
+func (b *GoGitBackend) ListFilesAt(rev string) ([]string, error) {
+	tree, err := b.revisionTree(rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files at %s: %w", rev, err)
+	}
+
+	var files []string
+	err = tree.Files().ForEach(func(file *object.File) error {
+		files = append(files, file.Name)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files at %s: %w", rev, err)
+	}
+	return files, nil
+}
+
+// ReadFilesAt returns the contents of files in the tree of a revision
+func (b *GoGitBackend) ReadFilesAt(rev string, paths []string) (map[string][]byte, error) {
+	tree, err := b.revisionTree(rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read files at %s: %w", rev, err)
+	}
+	contents := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		file, err := tree.File(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+		}
+		if contents[path], err = b.blobContent(file.Hash); err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// CommitMessage returns the full message of a commit as written by its author
+func (b *GoGitBackend) CommitMessage(rev string) (string, error) {
+	commit, err := b.resolveCommit(rev)
+	if err != nil {
+		return "", fmt.Errorf("failed to read message of %s: %w", rev, err)
+	}
+	return strings.TrimRight(commit.Message, "\n"), nil
+}
+
+// Log returns commits reachable from HEAD, newest first
+func (b *GoGitBackend) Log(options LogOptions) ([]*types.CommitInfo, error) {
+	if b.repo == nil {
+		return nil, ErrNotRepository
+	}
+
+	var start plumbing.Hash
+	if options.To != "" {
+		commit, err := b.resolveCommit(options.To)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit log: %w", err)
+		}
+		start = commit.Hash
+	} else {
+		head, err := b.repo.Head()
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil // Empty repository has no history
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit log: %w", err)
+		}
+		start = head.Hash()
+	}
+
+	excluded, err := b.ancestors(options.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+
+	logOptions := &gogit.LogOptions{From: start, Order: gogit.LogOrderCommitterTime}
+	if len(options.Paths) > 0 {
+		logOptions.PathFilter = pathFilter(options.Paths)
+	}
+
+	iter, err := b.repo.Log(logOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []*types.CommitInfo
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if excluded[commit.Hash] || (options.NoMerges && commit.NumParents() > 1) {
+			return nil
+		}
+		commits = append(commits, commitInfo(commit))
+		if options.MaxCount > 0 && len(commits) >= options.MaxCount {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+
+	return commits, nil
+}
+
+// ancestors returns the set of commits reachable from rev, or an empty set if rev is empty
+func (b *GoGitBackend) ancestors(rev string) (map[plumbing.Hash]bool, error) {
+	set := make(map[plumbing.Hash]bool)
+	if rev == "" {
+		return set, nil
+	}
+
+	commit, err := b.resolveCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := b.repo.Log(&gogit.LogOptions{From: commit.Hash})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		set[c.Hash] = true
+		return nil
+	})
+	return set, err
+}
+
+// CommitDiff returns the diff between a commit and its first parent
+func (b *GoGitBackend) CommitDiff(rev string) (string, error) {
+	commit, err := b.resolveCommit(rev)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit diff: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit diff: %w", err)
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return "", fmt.Errorf("failed to get commit diff: %w", err)
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return "", fmt.Errorf("failed to get commit diff: %w", err)
+		}
+	}
+
+	output, err := encodeTreeDiff(parentTree, tree)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit diff: %w", err)
+	}
+	return output, nil
+}
+
+// RangeDiff returns the diff between the trees of two revisions
+func (b *GoGitBackend) RangeDiff(from, to string) (string, error) {
+	fromCommit, err := b.resolveCommit(from)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between %s and %s: %w", from, to, err)
+	}
+	toCommit, err := b.resolveCommit(to)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between %s and %s: %w", from, to, err)
+	}
+
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between %s and %s: %w", from, to, err)
+	}
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between %s and %s: %w", from, to, err)
+	}
+
+	output, err := encodeTreeDiff(fromTree, toTree)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between %s and %s: %w", from, to, err)
+	}
+	return output, nil
+}
+
+// MergeBase returns the best common ancestor of two revisions
+func (b *GoGitBackend) MergeBase(first, second string) (string, error) {
+	firstCommit, err := b.resolveCommit(first)
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", first, second, err)
+	}
+	secondCommit, err := b.resolveCommit(second)
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", first, second, err)
+	}
+
+	bases, err := firstCommit.MergeBase(secondCommit)
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", first, second, err)
+	}
+	if len(bases) == 0 {
+		return "", fmt.Errorf("no merge base between %s and %s", first, second)
+	}
+	return bases[0].Hash.String(), nil
+}
+
+// UntrackedDiff returns untracked, non-ignored files as additions
+func (b *GoGitBackend) UntrackedDiff() (string, error) {
+	if b.repo == nil {
+		return "", ErrNotRepository
+	}
+
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get untracked files: %w", err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to get untracked files: %w", err)
+	}
+
+	var patches []fdiff.FilePatch
+	for path, fileStatus := range status {
+		if fileStatus.Worktree != gogit.Untracked {
+			continue
+		}
+
+		content, mode, err := readWorktreeFile(worktree, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		to := &patchFile{path: path, hash: plumbing.ComputeHash(plumbing.BlobObject, content), mode: mode}
+		patches = append(patches, newFilePatch(nil, to, nil, content))
+	}
+
+	return encodePatches(patches)
+}
+
+// ResolveRevision returns the commit hash a revision points to
+func (b *GoGitBackend) ResolveRevision(rev string) (string, error) {
+	commit, err := b.resolveCommit(rev)
+	if err != nil {
+		return "", err
+	}
+	return commit.Hash.String(), nil
+}
+
+// CurrentBranch returns the checked-out branch name, or "" for a detached HEAD
+func (b *GoGitBackend) CurrentBranch() (string, error) {
+	if b.repo == nil {
+		return "", ErrNotRepository
+	}
+	head, err := b.repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+		return head.Target().Short(), nil
+	}
+	return "", nil
+}
+
+// UserIdentity returns the committer identity from the repository and global git config
+func (b *GoGitBackend) UserIdentity() (string, error) {
+	name, email, err := b.identity()
+	if err != nil || name == "" || email == "" {
+		return "", err
+	}
+	return fmt.Sprintf("%s <%s>", name, email), nil
+}
+
+// identity returns the committer name and email, preferring committer over user settings
+func (b *GoGitBackend) identity() (string, string, error) {
+	if b.repo == nil {
+		return "", "", ErrNotRepository
+	}
+	cfg, err := b.repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	name, email := cfg.User.Name, cfg.User.Email
+	if cfg.Committer.Name != "" {
+		name = cfg.Committer.Name
+	}
+	if cfg.Committer.Email != "" {
+		email = cfg.Committer.Email
+	}
+	return name, email, nil
+}
+
+// Tags returns all tags sorted by version, newest first
+func (b *GoGitBackend) Tags() ([]*types.GitTag, error) {
+	if b.repo == nil {
+		return nil, ErrNotRepository
+	}
+
+	refs, err := b.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer refs.Close()
+
+	var tags []*types.GitTag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		tag := &types.GitTag{Name: ref.Name().Short()}
+
+		if tagObject, err := b.repo.TagObject(ref.Hash()); err == nil {
+			tag.IsAnnotated = true
+			tag.Hash = tagObject.Target.String()
+			tag.Date = tagObject.Tagger.When
+			tag.Message = firstLine(tagObject.Message)
+			if commit, err := tagObject.Commit(); err == nil {
+				tag.Hash = commit.Hash.String()
+			}
+		} else if commit, err := b.repo.CommitObject(ref.Hash()); err == nil {
+			tag.Hash = commit.Hash.String()
+			tag.Date = commit.Committer.When
+			tag.Message = firstLine(commit.Message)
+		} else {
+			tag.Hash = ref.Hash().String()
+		}
+
+		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return compareVersionNames(tags[i].Name, tags[j].Name) > 0
+	})
+
+	return tags, nil
+}
+
+// CreateTag creates a tag at HEAD; annotated tags use the configured user as tagger
+func (b *GoGitBackend) CreateTag(name, message string, annotated bool) error {
+	if b.repo == nil {
+		return ErrNotRepository
+	}
+
+	head, err := b.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	var options *gogit.CreateTagOptions
+	if annotated {
+		taggerName, taggerEmail, err := b.identity()
+		if err != nil {
+			return fmt.Errorf("failed to create tag %s: %w", name, err)
+		}
+		if taggerName == "" || taggerEmail == "" {
+			return fmt.Errorf("failed to create tag %s: user.name and user.email must be configured", name)
+		}
+		options = &gogit.CreateTagOptions{
+			Message: message,
+			Tagger:  &object.Signature{Name: taggerName, Email: taggerEmail, When: time.Now()},
+		}
+	}
+	if _, err := b.repo.CreateTag(name, head.Hash(), options); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteTag deletes a local tag
+func (b *GoGitBackend) DeleteTag(name string) error {
+	if b.repo == nil {
+		return ErrNotRepository
+	}
+	if err := b.repo.DeleteTag(name); err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// pushErrorPattern matches go-git errors for references refused by the client or the remote
+var pushErrorPattern = regexp.MustCompile(`(non-fast-forward update|command error on) (\S+?)(?:: (.*))?$`)
+
+// Push pushes refspecs to a remote. Like git, existing remote tags are never
+// overwritten, even when the update would be a fast-forward.
+func (b *GoGitBackend) Push(remote string, refspecs []string, atomic bool) error {
+	if b.repo == nil {
+		return ErrNotRepository
+	}
+
+	specs := make([]config.RefSpec, 0, len(refspecs))
+	for _, refspec := range refspecs {
+		spec := config.RefSpec(refspec)
+		if err := spec.Validate(); err != nil {
+			return fmt.Errorf("invalid refspec %s: %w", refspec, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	if err := b.checkRemoteTags(remote, specs); err != nil {
+		return err
+	}
+
+	err := b.repo.Push(&gogit.PushOptions{RemoteName: remote, RefSpecs: specs, Atomic: atomic})
+	if err == nil || errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	if matches := pushErrorPattern.FindStringSubmatch(err.Error()); matches != nil {
+		reason := "non-fast-forward"
+		if matches[1] == "command error on" {
+			reason = "remote rejected: " + matches[3]
+		}
+		return &PushRejectedError{Remote: remote, Rejected: []RejectedRef{{Ref: matches[2], Reason: reason}}}
+	}
+	return fmt.Errorf("failed to push to %s: %w", remote, err)
+}
+
+// checkRemoteTags rejects pushes that would move a tag that already exists on the remote
+func (b *GoGitBackend) checkRemoteTags(remote string, specs []config.RefSpec) error {
+	gitRemote, err := b.repo.Remote(remote)
+	if err != nil {
+		return fmt.Errorf("failed to push to %s: %w", remote, err)
+	}
+	remoteRefs, err := gitRemote.List(&gogit.ListOptions{})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list references of %s: %w", remote, err)
+	}
+	existing := make(map[plumbing.ReferenceName]plumbing.Hash, len(remoteRefs))
+	for _, ref := range remoteRefs {
+		existing[ref.Name()] = ref.Hash()
+	}
+
+	var rejected []RejectedRef
+	for _, spec := range specs {
+		src := plumbing.ReferenceName(spec.Src())
+		dst := spec.Dst(src)
+		if !dst.IsTag() {
+			continue
+		}
+		remoteHash, found := existing[dst]
+		if !found {
+			continue
+		}
+		local, err := b.repo.Reference(src, true)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", src, err)
+		}
+		if local.Hash() != remoteHash {
+			rejected = append(rejected, RejectedRef{Ref: dst.String(), Reason: "already exists"})
+		}
+	}
+	if len(rejected) > 0 {
+		return &PushRejectedError{Remote: remote, Rejected: rejected}
+	}
+	return nil
+}
+
+// AddAll stages all changes in the working tree
+func (b *GoGitBackend) AddAll() error {
+	if b.repo == nil {
+		return ErrNotRepository
+	}
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to stage all changes: %w", err)
+	}
+	if err := worktree.AddWithOptions(&gogit.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("failed to stage all changes: %w", err)
+	}
+	return nil
+}
+
+// Add stages paths relative to the repository root
+func (b *GoGitBackend) Add(paths ...string) error {
+	if b.repo == nil {
+		return ErrNotRepository
+	}
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
+	}
+	for _, path := range paths {
+		if _, err := worktree.Add(path); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Commit records the staged changes using the configured user as author
+func (b *GoGitBackend) Commit(message string) error {
+	if b.repo == nil {
+		return ErrNotRepository
+	}
+	worktree, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	if _, err := worktree.Commit(message, &gogit.CommitOptions{}); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// resolveCommit resolves a revision to a commit object
+func (b *GoGitBackend) resolveCommit(rev string) (*object.Commit, error) {
+	if b.repo == nil {
+		return nil, ErrNotRepository
+	}
+	hash, err := b.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+	return b.repo.CommitObject(*hash)
+}
+
+// revisionTree returns the tree of the commit a revision points to
+func (b *GoGitBackend) revisionTree(rev string) (*object.Tree, error) {
+	commit, err := b.resolveCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// headFiles returns the files of the HEAD tree keyed by path
+func (b *GoGitBackend) headFiles() (map[string]*patchFile, error) {
+	files := make(map[string]*patchFile)
+
+	head, err := b.repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return files, nil // No commits yet, everything in the index is new
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := b.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tree.Files().ForEach(func(file *object.File) error {
+		files[file.Name] = &patchFile{path: file.Name, hash: file.Hash, mode: file.Mode}
+		return nil
+	})
+	return files, err
+}
+
+// blobContent reads a blob from the object database
+func (b *GoGitBackend) blobContent(hash plumbing.Hash) ([]byte, error) {
+	blob, err := b.repo.BlobObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// blobPatch builds a file patch between two blobs; either side may be nil
+func (b *GoGitBackend) blobPatch(from, to *patchFile) (fdiff.FilePatch, error) {
+	var oldContent, newContent []byte
+	var err error
+	if from != nil {
+		if oldContent, err = b.blobContent(from.hash); err != nil {
+			return nil, err
+		}
+	}
+	if to != nil {
+		if newContent, err = b.blobContent(to.hash); err != nil {
+			return nil, err
+		}
+	}
+	return newFilePatch(from, to, oldContent, newContent), nil
+}
+
+// readWorktreeFile reads a file and its mode from the worktree filesystem
+func readWorktreeFile(worktree *gogit.Worktree, path string) ([]byte, filemode.FileMode, error) {
+	info, err := worktree.Filesystem.Lstat(path)
+	if err != nil {
+		return nil, filemode.Empty, err
+	}
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return nil, filemode.Empty, err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := worktree.Filesystem.Readlink(path)
+		return []byte(target), mode, err
+	}
+
+	file, err := worktree.Filesystem.Open(path)
+	if err != nil {
+		return nil, filemode.Empty, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	return content, mode, err
+}
+
+// patchFile describes one side of a file patch
+type patchFile struct {
+	path string
+	hash plumbing.Hash
+	mode filemode.FileMode
+}
+
+func (f *patchFile) Hash() plumbing.Hash     { return f.hash }
+func (f *patchFile) Mode() filemode.FileMode { return f.mode }
+func (f *patchFile) Path() string            { return f.path }
+
+// patchChunk is a contiguous run of equal, added or deleted lines
+type patchChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c *patchChunk) Content() string       { return c.content }
+func (c *patchChunk) Type() fdiff.Operation { return c.op }
+
+// filePatch implements fdiff.FilePatch for content that is not in a commit tree
+type filePatch struct {
+	from, to *patchFile
+	binary   bool
+	chunks   []fdiff.Chunk
+}
+
+// newFilePatch computes the line diff between old and new content
+func newFilePatch(from, to *patchFile, oldContent, newContent []byte) *filePatch {
+	patch := &filePatch{from: from, to: to}
+
+	if isBinary(oldContent) || isBinary(newContent) {
+		patch.binary = true
+		return patch
+	}
+
+	for _, d := range utildiff.Do(string(oldContent), string(newContent)) {
+		var op fdiff.Operation
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		default:
+			op = fdiff.Equal
+		}
+		patch.chunks = append(patch.chunks, &patchChunk{content: d.Text, op: op})
+	}
+
+	return patch
+}
+
+func (p *filePatch) IsBinary() bool        { return p.binary }
+func (p *filePatch) Chunks() []fdiff.Chunk { return p.chunks }
+
+// Files returns untyped nils for missing sides so the encoder detects additions and deletions
+func (p *filePatch) Files() (from, to fdiff.File) {
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
+
+// encodeTreeDiff renders the changes between two trees; from may be nil for root commits
+func encodeTreeDiff(from, to *object.Tree) (string, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return "", err
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := fdiff.NewUnifiedEncoder(&buf, fdiff.DefaultContextLines).Encode(patch); err != nil {
+		return "", fmt.Errorf("failed to encode diff: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// memoryPatch is a set of file patches sorted by path
+type memoryPatch []fdiff.FilePatch
+
+func (p memoryPatch) FilePatches() []fdiff.FilePatch { return p }
+func (p memoryPatch) Message() string                { return "" }
+
+// encodePatches renders file patches as a git-style unified diff
+func encodePatches(patches []fdiff.FilePatch) (string, error) {
+	if len(patches) == 0 {
+		return "", nil
+	}
+
+	sort.Slice(patches, func(i, j int) bool {
+		return patchPath(patches[i]) < patchPath(patches[j])
+	})
+
+	var buf bytes.Buffer
+	if err := fdiff.NewUnifiedEncoder(&buf, fdiff.DefaultContextLines).Encode(memoryPatch(patches)); err != nil {
+		return "", fmt.Errorf("failed to encode diff: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// patchPath returns the path used to order a file patch
+func patchPath(patch fdiff.FilePatch) string {
+	from, to := patch.Files()
+	if to != nil {
+		return to.Path()
+	}
+	return from.Path()
+}
+
+// isBinary reports whether content looks like binary data
+func isBinary(content []byte) bool {
+	if len(content) == 0 {
+		return false
+	}
+	result, err := binary.IsBinary(bytes.NewReader(content))
+	return err == nil && result
+}
+
+// pathFilter matches files equal to or below any of the given paths
+func pathFilter(paths []string) func(string) bool {
+	return func(file string) bool {
+		for _, path := range paths {
+			path = strings.TrimSuffix(path, "/")
+			if path == "." || path == "" || file == path || strings.HasPrefix(file, path+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// commitInfo converts a go-git commit to CommitInfo
+func commitInfo(commit *object.Commit) *types.CommitInfo {
+	subject := firstLine(commit.Message)
+	body := ""
+	if _, rest, found := strings.Cut(strings.TrimSpace(commit.Message), "\n"); found {
+		body = strings.TrimSpace(rest)
+	}
+	return &types.CommitInfo{
+		Hash:        commit.Hash.String(),
+		Subject:     subject,
+		Body:        body,
+		Author:      commit.Author.Name,
+		AuthorEmail: commit.Author.Email,
+		Date:        commit.Author.When,
+		Slug:        slugify(subject),
+	}
+}
+
+// firstLine returns the first line of a message
+func firstLine(message string) string {
+	message = strings.TrimSpace(message)
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		return strings.TrimSpace(message[:i])
+	}
+	return message
+}
+
+// resolvedStage is the stage of index entries without merge conflicts.
+// go-git's index.Merged constant is 1, but entries written by git use 0.
+const resolvedStage index.Stage = 0
+
+var slugUnsafe = regexp.MustCompile(`[^A-Za-z0-9._]+`)
+
+// slugify mimics git's %f placeholder: a subject sanitized for use as a file name
+func slugify(subject string) string {
+	slug := slugUnsafe.ReplaceAllString(subject, "-")
+	return strings.Trim(slug, "-.")
+}
+
+// compareVersionNames compares tag names like git's version:refname sort,
+// treating runs of digits as numbers
+func compareVersionNames(a, b string) int {
+	for a != "" && b != "" {
+		aDigits, bDigits := isDigit(a[0]), isDigit(b[0])
+		aPart, aRest := splitRun(a, aDigits)
+		bPart, bRest := splitRun(b, bDigits)
+
+		if aDigits && bDigits {
+			aNum := strings.TrimLeft(aPart, "0")
+			bNum := strings.TrimLeft(bPart, "0")
+			if len(aNum) != len(bNum) {
+				return len(aNum) - len(bNum)
+			}
+			if c := strings.Compare(aNum, bNum); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(aPart, bPart); c != 0 {
+			return c
+		}
+
+		a, b = aRest, bRest
+	}
+	return len(a) - len(b)
+}
+
+// splitRun splits off the leading run of digits or non-digits
+func splitRun(s string, digits bool) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
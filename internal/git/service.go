@@ -1,75 +1,65 @@
 package git
 
 import (
-	"bufio"
 	"fmt"
-	"os/exec"
 	"path/filepath"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/nguyendkn/git-generator/internal/diff"
 	"github.com/nguyendkn/git-generator/pkg/types"
 )
 
 // Service handles Git operations
 type Service struct {
-	repoPath string
+	backend Backend
 }
 
-// NewService creates a new Git service
+// NewService creates a new Git service backed by the git binary
 func NewService(repoPath string) *Service {
-	if repoPath == "" {
-		repoPath = "."
-	}
+	return NewServiceWithBackend(NewExecBackend(repoPath))
+}
+
+// NewServiceWithBackend creates a new Git service using the given backend
+func NewServiceWithBackend(backend Backend) *Service {
 	return &Service{
-		repoPath: repoPath,
+		backend: backend,
 	}
 }
 
+// NewServiceFromConfig creates a new Git service using the backend selected in config
+func NewServiceFromConfig(repoPath string, config types.GitConfig) (*Service, error) {
+	backend, err := NewBackend(config.Backend, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewServiceWithBackend(backend), nil
+}
+
 // IsGitRepository checks if the current directory is a Git repository
 func (s *Service) IsGitRepository() bool {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	cmd.Dir = s.repoPath
-	return cmd.Run() == nil
+	return s.backend.IsRepository()
+}
+
+// GetRepositoryRoot returns the top-level directory of the working tree, so
+// that paths relative to the repository work from any subdirectory
+func (s *Service) GetRepositoryRoot() (string, error) {
+	return s.backend.Root()
 }
 
 // HasStagedChanges checks if there are staged changes
 func (s *Service) HasStagedChanges() (bool, error) {
-	cmd := exec.Command("git", "diff", "--cached", "--quiet")
-	cmd.Dir = s.repoPath
-	err := cmd.Run()
-	if err != nil {
-		// Exit code 1 means there are differences
-		if exitError, ok := err.(*exec.ExitError); ok && exitError.ExitCode() == 1 {
-			return true, nil
-		}
-		return false, fmt.Errorf("failed to check staged changes: %w", err)
-	}
-	return false, nil
+	return s.backend.HasStagedChanges()
 }
 
 // GetStagedDiff returns the diff of staged changes
 func (s *Service) GetStagedDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached")
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get staged diff: %w", err)
-	}
-	return string(output), nil
+	return s.backend.StagedDiff()
 }
 
 // GetWorkingDiff returns the diff of working directory changes
 func (s *Service) GetWorkingDiff() (string, error) {
-	cmd := exec.Command("git", "diff")
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get working diff: %w", err)
-	}
-	return string(output), nil
+	return s.backend.WorkingDiff()
 }
 
 // GetDiffSummary parses git diff output and returns a structured summary
@@ -97,116 +87,141 @@ func (s *Service) GetDiffSummary(includeStaged bool) (*types.DiffSummary, error)
 	return s.parseDiff(diffOutput)
 }
 
-// parseDiff parses git diff output into structured data
-func (s *Service) parseDiff(diffOutput string) (*types.DiffSummary, error) {
-	var files []types.FileChange
-	var totalAdded, totalDeleted int
+// DiffOptions selects the changes described by GetDiffSummaryFor
+type DiffOptions struct {
+	Staged           bool   // Compare HEAD with the index instead of the index with the working tree
+	Range            string // Revision range: "A..B", "A...B" (from their merge base) or "A" (A..HEAD)
+	Against          string // Describe HEAD against a branch, from their merge base
+	IncludeUntracked bool   // Add untracked files to working tree diffs
+}
 
-	// Split diff into individual file sections
-	fileSections := s.splitDiffByFile(diffOutput)
+// IsRevisionDiff reports whether the options describe committed history rather than local changes
+func (o DiffOptions) IsRevisionDiff() bool {
+	return o.Range != "" || o.Against != ""
+}
 
-	for _, section := range fileSections {
-		fileChange, err := s.parseFileSection(section)
-		if err != nil {
-			continue // Skip files that can't be parsed
-		}
+// Description returns a short human-readable name for the selected changes
+func (o DiffOptions) Description() string {
+	switch {
+	case o.Range != "":
+		return o.Range
+	case o.Against != "":
+		return o.Against + "...HEAD"
+	case o.Staged:
+		return "staged changes"
+	case o.IncludeUntracked:
+		return "working tree (including untracked files)"
+	default:
+		return "working tree"
+	}
+}
 
-		files = append(files, *fileChange)
-		totalAdded += fileChange.LinesAdded
-		totalDeleted += fileChange.LinesDeleted
+// GetDiffSummaryFor returns the diff summary for a revision range, a branch
+// comparison or local changes, depending on options
+func (s *Service) GetDiffSummaryFor(options DiffOptions) (*types.DiffSummary, error) {
+	if options.Staged && !options.IsRevisionDiff() {
+		return s.GetDiffSummary(true)
 	}
 
-	return &types.DiffSummary{
-		Files:        files,
-		TotalAdded:   totalAdded,
-		TotalDeleted: totalDeleted,
-		TotalFiles:   len(files),
-		Timestamp:    time.Now(),
-	}, nil
+	diffOutput, err := s.GetDiffFor(options)
+	if err != nil {
+		return nil, err
+	}
+	return s.parseDiff(diffOutput)
 }
 
-// splitDiffByFile splits the diff output into sections for each file
-func (s *Service) splitDiffByFile(diffOutput string) []string {
-	var sections []string
-	var currentSection strings.Builder
-
-	scanner := bufio.NewScanner(strings.NewReader(diffOutput))
-	for scanner.Scan() {
-		line := scanner.Text()
+// GetDiffFor returns the diff output for a revision range, a branch
+// comparison or local changes, depending on options
+func (s *Service) GetDiffFor(options DiffOptions) (string, error) {
+	if options.Range != "" && options.Against != "" {
+		return "", fmt.Errorf("range and against cannot be used together")
+	}
 
-		// New file section starts with "diff --git"
-		if strings.HasPrefix(line, "diff --git") {
-			if currentSection.Len() > 0 {
-				sections = append(sections, currentSection.String())
-				currentSection.Reset()
+	switch {
+	case options.Range != "":
+		from, to, err := s.ResolveRange(options.Range)
+		if err != nil {
+			return "", err
+		}
+		return s.backend.RangeDiff(from, to)
+	case options.Against != "":
+		base, err := s.GetMergeBase(options.Against, "HEAD")
+		if err != nil {
+			return "", err
+		}
+		return s.backend.RangeDiff(base, "HEAD")
+	case options.Staged:
+		return s.GetStagedDiff()
+	default:
+		workingDiff, err := s.GetWorkingDiff()
+		if err != nil {
+			return "", err
+		}
+		if options.IncludeUntracked {
+			untrackedDiff, err := s.backend.UntrackedDiff()
+			if err != nil {
+				return "", err
 			}
+			workingDiff += untrackedDiff
 		}
-
-		currentSection.WriteString(line + "\n")
+		return workingDiff, nil
 	}
-
-	if currentSection.Len() > 0 {
-		sections = append(sections, currentSection.String())
-	}
-
-	return sections
 }
 
-// parseFileSection parses a single file's diff section
-func (s *Service) parseFileSection(section string) (*types.FileChange, error) {
-	lines := strings.Split(section, "\n")
-	if len(lines) < 2 {
-		return nil, fmt.Errorf("invalid file section")
+// ResolveRange splits a revision range into the two revisions to compare.
+// "A..B" compares A with B, "A...B" compares the merge base of A and B with B
+// and a single revision "A" compares A with HEAD. Empty sides default to HEAD.
+func (s *Service) ResolveRange(revRange string) (string, string, error) {
+	revRange = strings.TrimSpace(revRange)
+	if revRange == "" {
+		return "", "", fmt.Errorf("empty revision range")
 	}
 
-	fileChange := &types.FileChange{
-		Content: section,
-	}
-
-	// Parse the diff header
-	for i, line := range lines {
-		if strings.HasPrefix(line, "diff --git") {
-			// Extract file paths
-			parts := strings.Fields(line)
-			if len(parts) >= 4 {
-				fileChange.Path = strings.TrimPrefix(parts[3], "b/")
-				if parts[2] != parts[3] {
-					fileChange.OldPath = strings.TrimPrefix(parts[2], "a/")
-					fileChange.ChangeType = types.ChangeTypeRenamed
-				}
-			}
-		} else if strings.HasPrefix(line, "new file mode") {
-			fileChange.ChangeType = types.ChangeTypeAdded
-		} else if strings.HasPrefix(line, "deleted file mode") {
-			fileChange.ChangeType = types.ChangeTypeDeleted
-		} else if strings.HasPrefix(line, "@@") {
-			// Parse hunk header to get line counts
-			fileChange.LinesAdded, fileChange.LinesDeleted = s.parseHunkCounts(lines[i:])
-			break
+	if from, to, found := strings.Cut(revRange, "..."); found {
+		from, to = defaultHead(from), defaultHead(to)
+		base, err := s.GetMergeBase(from, to)
+		if err != nil {
+			return "", "", err
 		}
+		return base, to, nil
 	}
-
-	// Set default change type if not determined
-	if fileChange.ChangeType == "" {
-		fileChange.ChangeType = types.ChangeTypeModified
+	if from, to, found := strings.Cut(revRange, ".."); found {
+		return defaultHead(from), defaultHead(to), nil
 	}
+	return revRange, "HEAD", nil
+}
 
-	// Detect language
-	fileChange.Language = s.detectLanguage(fileChange.Path)
+// GetMergeBase returns the best common ancestor of two revisions
+func (s *Service) GetMergeBase(first, second string) (string, error) {
+	return s.backend.MergeBase(first, second)
+}
 
-	return fileChange, nil
+// defaultHead returns HEAD for an empty side of a revision range
+func defaultHead(rev string) string {
+	if rev == "" {
+		return "HEAD"
+	}
+	return rev
 }
 
-// parseHunkCounts counts added and deleted lines from hunk data
-func (s *Service) parseHunkCounts(hunkLines []string) (added, deleted int) {
-	for _, line := range hunkLines {
-		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-			added++
-		} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
-			deleted++
-		}
+// parseDiff parses git diff output into structured data
+func (s *Service) parseDiff(diffOutput string) (*types.DiffSummary, error) {
+	files := diff.Parse(diffOutput)
+	var totalAdded, totalDeleted int
+
+	for i := range files {
+		files[i].Language = s.detectLanguage(files[i].Path)
+		totalAdded += files[i].LinesAdded
+		totalDeleted += files[i].LinesDeleted
 	}
-	return added, deleted
+
+	return &types.DiffSummary{
+		Files:        files,
+		TotalAdded:   totalAdded,
+		TotalDeleted: totalDeleted,
+		TotalFiles:   len(files),
+		Timestamp:    time.Now(),
+	}, nil
 }
 
 // detectLanguage detects programming language from file extension
@@ -248,18 +263,13 @@ func (s *Service) detectLanguage(filePath string) string {
 
 // GetFileStats returns statistics about the repository
 func (s *Service) GetFileStats() (map[string]int, error) {
-	cmd := exec.Command("git", "ls-files")
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
+	files, err := s.backend.ListFiles()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get file list: %w", err)
+		return nil, err
 	}
 
 	stats := make(map[string]int)
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
-
-	for scanner.Scan() {
-		filePath := scanner.Text()
+	for _, filePath := range files {
 		lang := s.detectLanguage(filePath)
 		stats[lang]++
 	}
@@ -273,37 +283,10 @@ func (s *Service) GetRecentCommits(count int) ([]*types.CommitInfo, error) {
 		count = 10
 	}
 
-	cmd := exec.Command("git", "log", "--oneline", "--no-merges", fmt.Sprintf("-%d", count), "--pretty=format:%H|%s|%an|%ad|%f", "--date=iso")
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
+	commits, err := s.backend.Log(LogOptions{MaxCount: count, NoMerges: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent commits: %w", err)
 	}
-
-	var commits []*types.CommitInfo
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "|")
-		if len(parts) >= 5 {
-			dateStr := parts[3]
-			parsedDate, _ := time.Parse("2006-01-02 15:04:05 -0700", dateStr)
-
-			commit := &types.CommitInfo{
-				Hash:    parts[0],
-				Subject: parts[1],
-				Author:  parts[2],
-				Date:    parsedDate,
-				Slug:    parts[4],
-			}
-			commits = append(commits, commit)
-		}
-	}
-
 	return commits, nil
 }
 
@@ -313,148 +296,125 @@ func (s *Service) GetFileHistory(filePaths []string, count int) ([]*types.Commit
 		count = 5
 	}
 
-	args := []string{"log", "--oneline", "--no-merges", fmt.Sprintf("-%d", count), "--pretty=format:%H|%s|%an|%ad|%f", "--date=iso", "--"}
-	args = append(args, filePaths...)
-
-	cmd := exec.Command("git", args...)
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
+	commits, err := s.backend.Log(LogOptions{MaxCount: count, NoMerges: true, Paths: filePaths})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file history: %w", err)
 	}
+	return commits, nil
+}
 
-	var commits []*types.CommitInfo
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "|")
-		if len(parts) >= 5 {
-			dateStr := parts[3]
-			parsedDate, _ := time.Parse("2006-01-02 15:04:05 -0700", dateStr)
-
-			commit := &types.CommitInfo{
-				Hash:    parts[0],
-				Subject: parts[1],
-				Author:  parts[2],
-				Date:    parsedDate,
-				Slug:    parts[4],
-			}
-			commits = append(commits, commit)
-		}
+// GetCommitsInRange returns the non-merge commits in from..to, newest first.
+// An empty from returns all commits reachable from to.
+func (s *Service) GetCommitsInRange(from, to string) ([]*types.CommitInfo, error) {
+	commits, err := s.backend.Log(LogOptions{From: from, To: to, NoMerges: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits in range: %w", err)
 	}
-
 	return commits, nil
 }
 
-// GetCommitDiff returns the diff for a specific commit
-func (s *Service) GetCommitDiff(commitHash string) (string, error) {
-	cmd := exec.Command("git", "show", "--no-merges", "--format=", commitHash)
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
+// GetCommitsInRangeForPaths returns the non-merge commits in from..to that
+// touch the given paths, newest first. No paths means all commits.
+func (s *Service) GetCommitsInRangeForPaths(from, to string, paths []string) ([]*types.CommitInfo, error) {
+	commits, err := s.backend.Log(LogOptions{From: from, To: to, NoMerges: true, Paths: paths})
 	if err != nil {
-		return "", fmt.Errorf("failed to get commit diff: %w", err)
+		return nil, fmt.Errorf("failed to get commits in range: %w", err)
 	}
+	return commits, nil
+}
 
-	return string(output), nil
+// GetBranchCommits returns the commits on HEAD since it diverged from base
+func (s *Service) GetBranchCommits(base string) ([]*types.CommitInfo, error) {
+	mergeBase, err := s.GetMergeBase(base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCommitsInRange(mergeBase, "HEAD")
 }
 
-// GetCommitDiffSummary returns the diff summary for a specific commit
-func (s *Service) GetCommitDiffSummary(commitHash string) (*types.DiffSummary, error) {
-	// Get the diff for the commit
-	cmd := exec.Command("git", "show", "--numstat", "--format=", commitHash)
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
+// ListFiles returns the paths of all tracked files
+func (s *Service) ListFiles() ([]string, error) {
+	files, err := s.backend.ListFiles()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commit diff summary: %w", err)
+		return nil, fmt.Errorf("failed to list files: %w", err)
 	}
+	return files, nil
+}
 
-	// Parse the numstat output
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var files []types.FileChange
-	totalAdded, totalDeleted := 0, 0
+// ListFilesAt returns the paths of all files in the tree of a revision
+func (s *Service) ListFilesAt(rev string) ([]string, error) {
+	return s.backend.ListFilesAt(rev)
+}
 
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
+// ReadFilesAt returns the contents of files in the tree of a revision, keyed by path
+func (s *Service) ReadFilesAt(rev string, paths []string) (map[string][]byte, error) {
+	return s.backend.ReadFilesAt(rev, paths)
+}
 
-		parts := strings.Fields(line)
-		if len(parts) < 3 {
-			continue
-		}
+// GetCommitMessage returns the full message of a commit as written by its author
+func (s *Service) GetCommitMessage(rev string) (string, error) {
+	return s.backend.CommitMessage(rev)
+}
 
-		added, _ := strconv.Atoi(parts[0])
-		deleted, _ := strconv.Atoi(parts[1])
-		path := parts[2]
+// ResolveRevision returns the commit hash a revision points to
+func (s *Service) ResolveRevision(rev string) (string, error) {
+	return s.backend.ResolveRevision(rev)
+}
 
-		files = append(files, types.FileChange{
-			Path:         path,
-			ChangeType:   types.ChangeTypeModified,
-			LinesAdded:   added,
-			LinesDeleted: deleted,
-		})
+// GetCurrentBranch returns the checked-out branch, or "" when HEAD is detached
+func (s *Service) GetCurrentBranch() (string, error) {
+	return s.backend.CurrentBranch()
+}
+
+// GetUserIdentity returns the committer as "Name <email>", or "" when it is not configured
+func (s *Service) GetUserIdentity() (string, error) {
+	return s.backend.UserIdentity()
+}
 
-		totalAdded += added
-		totalDeleted += deleted
+// DetectBaseBranch returns the first existing branch among the usual default branch names
+func (s *Service) DetectBaseBranch() (string, error) {
+	for _, candidate := range []string{"main", "master", "origin/main", "origin/master", "develop"} {
+		if _, err := s.backend.ResolveRevision(candidate); err == nil {
+			return candidate, nil
+		}
 	}
+	return "", fmt.Errorf("could not detect the base branch, please specify one")
+}
 
-	return &types.DiffSummary{
-		Files:        files,
-		TotalAdded:   totalAdded,
-		TotalDeleted: totalDeleted,
-		TotalFiles:   len(files),
-		Timestamp:    time.Now(),
-		Additions:    totalAdded,
-		Deletions:    totalDeleted,
-	}, nil
+// GetCommitDiff returns the diff for a specific commit
+func (s *Service) GetCommitDiff(commitHash string) (string, error) {
+	return s.backend.CommitDiff(commitHash)
 }
 
-// GetTags returns all Git tags with version information
-func (s *Service) GetTags() ([]*types.GitTag, error) {
-	cmd := exec.Command("git", "tag", "-l", "--sort=-version:refname", "--format=%(refname:short)|%(objectname)|%(creatordate:iso)|%(contents:subject)")
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
+// GetCommitDiffSummary returns the diff summary for a specific commit
+func (s *Service) GetCommitDiffSummary(commitHash string) (*types.DiffSummary, error) {
+	diffOutput, err := s.backend.CommitDiff(commitHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tags: %w", err)
+		return nil, fmt.Errorf("failed to get commit diff summary: %w", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var tags []*types.GitTag
-
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "|")
-		if len(parts) < 4 {
-			continue
-		}
-
-		tag := &types.GitTag{
-			Name:    parts[0],
-			Hash:    parts[1],
-			Message: parts[3],
-		}
+	summary, err := s.parseDiff(diffOutput)
+	if err != nil {
+		return nil, err
+	}
+	summary.Additions = summary.TotalAdded
+	summary.Deletions = summary.TotalDeleted
 
-		// Parse date
-		if date, err := time.Parse("2006-01-02 15:04:05 -0700", parts[2]); err == nil {
-			tag.Date = date
-		}
+	return summary, nil
+}
 
-		// Check if it's an annotated tag
-		tag.IsAnnotated = s.isAnnotatedTag(tag.Name)
+// GetTags returns all Git tags with version information
+func (s *Service) GetTags() ([]*types.GitTag, error) {
+	tags, err := s.backend.Tags()
+	if err != nil {
+		return nil, err
+	}
 
-		// Try to parse as semantic version
+	// Try to parse each tag as semantic version
+	for _, tag := range tags {
 		if version, err := s.parseSemanticVersion(tag.Name); err == nil {
 			tag.Version = version
 		}
-
-		tags = append(tags, tag)
 	}
 
 	return tags, nil
@@ -462,103 +422,53 @@ func (s *Service) GetTags() ([]*types.GitTag, error) {
 
 // CreateTag creates a new Git tag
 func (s *Service) CreateTag(tagName, message string, annotated bool) error {
-	var cmd *exec.Cmd
-	if annotated {
-		cmd = exec.Command("git", "tag", "-a", tagName, "-m", message)
-	} else {
-		cmd = exec.Command("git", "tag", tagName)
-	}
-	cmd.Dir = s.repoPath
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create tag %s: %w", tagName, err)
-	}
+	return s.backend.CreateTag(tagName, message, annotated)
+}
 
-	return nil
+// DeleteTag deletes a local tag
+func (s *Service) DeleteTag(tagName string) error {
+	return s.backend.DeleteTag(tagName)
 }
 
-// HasUnstagedChanges checks if there are unstaged changes (including untracked files)
-func (s *Service) HasUnstagedChanges() (bool, error) {
-	// Check for modified files
-	cmd := exec.Command("git", "diff", "--quiet")
-	cmd.Dir = s.repoPath
-	err := cmd.Run()
-	if err != nil {
-		// git diff --quiet returns non-zero exit code if there are differences
-		if exitError, ok := err.(*exec.ExitError); ok && exitError.ExitCode() == 1 {
-			return true, nil
+// PushTag pushes a tag to the remote. With withBranch the current branch is
+// pushed in the same atomic operation, so either both references are updated
+// on the remote or neither is.
+func (s *Service) PushTag(remote, tagName string, withBranch bool) error {
+	refspecs := []string{"refs/tags/" + tagName + ":refs/tags/" + tagName}
+	if withBranch {
+		branch, err := s.GetCurrentBranch()
+		if err != nil {
+			return err
 		}
-		if exitError, ok := err.(*exec.ExitError); ok && exitError.ExitCode() != 0 {
-			return false, fmt.Errorf("failed to check unstaged changes: %w", err)
+		if branch == "" {
+			return fmt.Errorf("cannot push branch with tag %s: HEAD is detached", tagName)
 		}
+		refspecs = append([]string{"refs/heads/" + branch + ":refs/heads/" + branch}, refspecs...)
 	}
+	return s.backend.Push(remote, refspecs, len(refspecs) > 1)
+}
 
-	// Check for untracked files
-	cmd = exec.Command("git", "ls-files", "--others", "--exclude-standard")
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return false, fmt.Errorf("failed to check untracked files: %w", err)
-	}
-
-	// If there are untracked files, we have unstaged changes
-	return len(strings.TrimSpace(string(output))) > 0, nil
+// HasUnstagedChanges checks if there are unstaged changes (including untracked files)
+func (s *Service) HasUnstagedChanges() (bool, error) {
+	return s.backend.HasUnstagedChanges()
 }
 
 // AddAll stages all changes in the working directory
 func (s *Service) AddAll() error {
-	cmd := exec.Command("git", "add", ".")
-	cmd.Dir = s.repoPath
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to stage all changes: %w", err)
-	}
-	return nil
+	return s.backend.AddAll()
 }
 
-// isAnnotatedTag checks if a tag is annotated
-func (s *Service) isAnnotatedTag(tagName string) bool {
-	cmd := exec.Command("git", "cat-file", "-t", tagName)
-	cmd.Dir = s.repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	return strings.TrimSpace(string(output)) == "tag"
+// Add stages the given paths, relative to the repository root
+func (s *Service) Add(paths ...string) error {
+	return s.backend.Add(paths...)
+}
+
+// Commit creates a commit from the staged changes
+func (s *Service) Commit(message string) error {
+	return s.backend.Commit(message)
 }
 
 // parseSemanticVersion attempts to parse a tag name as semantic version
 func (s *Service) parseSemanticVersion(tagName string) (*types.SemanticVersion, error) {
-	// Remove 'v' prefix if present
-	versionStr := strings.TrimPrefix(tagName, "v")
-
-	// Basic regex for semantic version
-	re := regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([a-zA-Z]+)(?:\.(\d+))?)?$`)
-	matches := re.FindStringSubmatch(versionStr)
-
-	if len(matches) < 4 {
-		return nil, fmt.Errorf("not a semantic version")
-	}
-
-	major, _ := strconv.Atoi(matches[1])
-	minor, _ := strconv.Atoi(matches[2])
-	patch, _ := strconv.Atoi(matches[3])
-
-	version := &types.SemanticVersion{
-		Major: major,
-		Minor: minor,
-		Patch: patch,
-		Raw:   versionStr,
-	}
-
-	// Handle pre-release
-	if len(matches) > 4 && matches[4] != "" {
-		version.PreRelease = types.PreReleaseType(matches[4])
-		if len(matches) > 5 && matches[5] != "" {
-			if preNumber, err := strconv.Atoi(matches[5]); err == nil {
-				version.PreNumber = preNumber
-			}
-		}
-	}
-
-	return version, nil
+	return types.ParseSemanticVersion(tagName)
 }
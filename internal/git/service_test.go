@@ -0,0 +1,627 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// memoryRepo is an in-memory repository used to exercise the go-git backend
+type memoryRepo struct {
+	t    *testing.T
+	repo *gogit.Repository
+	fs   billy.Filesystem
+	when time.Time
+}
+
+func newMemoryRepo(t *testing.T) *memoryRepo {
+	t.Helper()
+
+	fs := memfs.New()
+	repo, err := gogit.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.User.Name = "Test User"
+	cfg.User.Email = "test@example.com"
+	require.NoError(t, repo.SetConfig(cfg))
+
+	return &memoryRepo{t: t, repo: repo, fs: fs, when: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (r *memoryRepo) write(path, content string) {
+	r.t.Helper()
+	file, err := r.fs.Create(path)
+	require.NoError(r.t, err)
+	_, err = file.Write([]byte(content))
+	require.NoError(r.t, err)
+	require.NoError(r.t, file.Close())
+}
+
+func (r *memoryRepo) add(path string) {
+	r.t.Helper()
+	worktree, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+	_, err = worktree.Add(path)
+	require.NoError(r.t, err)
+}
+
+func (r *memoryRepo) commit(message string) {
+	r.t.Helper()
+	worktree, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+
+	r.when = r.when.Add(time.Hour)
+	signature := &object.Signature{Name: "Test User", Email: "test@example.com", When: r.when}
+	_, err = worktree.Commit(message, &gogit.CommitOptions{Author: signature})
+	require.NoError(r.t, err)
+}
+
+func (r *memoryRepo) service() *Service {
+	return NewServiceWithBackend(NewGoGitBackendFromRepository(r.repo))
+}
+
+func TestNewBackend(t *testing.T) {
+	backend, err := NewBackend("", ".")
+	require.NoError(t, err)
+	assert.IsType(t, &ExecBackend{}, backend)
+
+	backend, err = NewBackend(BackendGoGit, ".")
+	require.NoError(t, err)
+	assert.IsType(t, &GoGitBackend{}, backend)
+
+	_, err = NewBackend("svn", ".")
+	assert.Error(t, err)
+}
+
+func TestGoGitBackend_NotRepository(t *testing.T) {
+	service := NewServiceWithBackend(NewGoGitBackend(t.TempDir()))
+
+	assert.False(t, service.IsGitRepository())
+	_, err := service.GetStagedDiff()
+	assert.ErrorIs(t, err, ErrNotRepository)
+}
+
+func TestGoGitBackend_StagedDiffSummary(t *testing.T) {
+	repo := newMemoryRepo(t)
+	repo.write("main.go", "package main\n\nfunc main() {}\n")
+	repo.write("README.md", "# Project\n")
+	repo.add("main.go")
+	repo.add("README.md")
+	repo.commit("feat: initial commit")
+
+	service := repo.service()
+	hasStaged, err := service.HasStagedChanges()
+	require.NoError(t, err)
+	assert.False(t, hasStaged)
+
+	repo.write("main.go", "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n")
+	repo.write("config.yaml", "debug: true\n")
+	repo.add("main.go")
+	repo.add("config.yaml")
+
+	hasStaged, err = service.HasStagedChanges()
+	require.NoError(t, err)
+	assert.True(t, hasStaged)
+
+	summary, err := service.GetDiffSummary(true)
+	require.NoError(t, err)
+	require.Len(t, summary.Files, 2)
+
+	files := make(map[string]types.FileChange)
+	for _, file := range summary.Files {
+		files[file.Path] = file
+	}
+
+	assert.Equal(t, types.ChangeTypeAdded, files["config.yaml"].ChangeType)
+	assert.Equal(t, 1, files["config.yaml"].LinesAdded)
+	assert.Equal(t, "YAML", files["config.yaml"].Language)
+
+	assert.Equal(t, types.ChangeTypeModified, files["main.go"].ChangeType)
+	assert.Equal(t, 5, files["main.go"].LinesAdded)
+	assert.Equal(t, 1, files["main.go"].LinesDeleted)
+}
+
+func TestGoGitBackend_WorkingDiffAndAddAll(t *testing.T) {
+	repo := newMemoryRepo(t)
+	repo.write("app.txt", "one\ntwo\n")
+	repo.add("app.txt")
+	repo.commit("chore: add app")
+
+	service := repo.service()
+	repo.write("app.txt", "one\nthree\n")
+	repo.write("new.txt", "untracked\n")
+
+	hasUnstaged, err := service.HasUnstagedChanges()
+	require.NoError(t, err)
+	assert.True(t, hasUnstaged)
+
+	summary, err := service.GetDiffSummary(false)
+	require.NoError(t, err)
+	require.Len(t, summary.Files, 1, "untracked files are not part of the working diff")
+	assert.Equal(t, "app.txt", summary.Files[0].Path)
+	assert.Equal(t, 1, summary.Files[0].LinesAdded)
+	assert.Equal(t, 1, summary.Files[0].LinesDeleted)
+
+	require.NoError(t, service.AddAll())
+	summary, err = service.GetDiffSummary(true)
+	require.NoError(t, err)
+	assert.Len(t, summary.Files, 2)
+
+	require.NoError(t, service.Commit("feat: add new file"))
+	commits, err := service.GetRecentCommits(10)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "feat: add new file", commits[0].Subject)
+	assert.Equal(t, "feat-add-new-file", commits[0].Slug)
+}
+
+func TestGoGitBackend_HistoryAndCommitDiff(t *testing.T) {
+	repo := newMemoryRepo(t)
+	repo.write("a.go", "package a\n")
+	repo.add("a.go")
+	repo.commit("feat: add a")
+	repo.write("b.go", "package b\n")
+	repo.add("b.go")
+	repo.commit("feat: add b")
+	repo.write("a.go", "package a\n\nvar A = 1\n")
+	repo.add("a.go")
+	repo.commit("fix: update a")
+
+	service := repo.service()
+
+	commits, err := service.GetRecentCommits(2)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "fix: update a", commits[0].Subject)
+	assert.Equal(t, "Test User", commits[0].Author)
+
+	history, err := service.GetFileHistory([]string{"a.go"}, 5)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "fix: update a", history[0].Subject)
+	assert.Equal(t, "feat: add a", history[1].Subject)
+
+	summary, err := service.GetCommitDiffSummary("HEAD")
+	require.NoError(t, err)
+	require.Len(t, summary.Files, 1)
+	assert.Equal(t, "a.go", summary.Files[0].Path)
+	assert.Equal(t, 2, summary.Additions)
+
+	root, err := service.GetCommitDiffSummary(commits[1].Hash + "~1")
+	require.NoError(t, err)
+	require.Len(t, root.Files, 1)
+	assert.Equal(t, types.ChangeTypeAdded, root.Files[0].ChangeType)
+}
+
+func TestGoGitBackend_Tags(t *testing.T) {
+	repo := newMemoryRepo(t)
+	repo.write("a.txt", "a\n")
+	repo.add("a.txt")
+	repo.commit("feat: first")
+
+	service := repo.service()
+	require.NoError(t, service.CreateTag("v1.2.0", "Release 1.2.0", true))
+	require.NoError(t, service.CreateTag("v1.10.0", "", false))
+	require.NoError(t, service.CreateTag("nightly", "", false))
+
+	tags, err := service.GetTags()
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+
+	assert.Equal(t, "v1.10.0", tags[0].Name)
+	assert.False(t, tags[0].IsAnnotated)
+	assert.Equal(t, 10, tags[0].Version.Minor)
+
+	assert.Equal(t, "v1.2.0", tags[1].Name)
+	assert.True(t, tags[1].IsAnnotated)
+	assert.Equal(t, "Release 1.2.0", tags[1].Message)
+	assert.Equal(t, tags[0].Hash, tags[1].Hash, "annotated tags resolve to the commit hash")
+
+	assert.Equal(t, "nightly", tags[2].Name)
+	assert.Nil(t, tags[2].Version)
+}
+
+func TestCompareVersionNames(t *testing.T) {
+	assert.Positive(t, compareVersionNames("v1.10.0", "v1.9.0"))
+	assert.Negative(t, compareVersionNames("v1.2.0", "v1.2.1"))
+	assert.Zero(t, compareVersionNames("v2.0.0", "v2.0.0"))
+	assert.Positive(t, compareVersionNames("v2.0.0-rc.1", "v2.0.0"))
+}
+
+// TestBackendParity checks that both backends produce the same summaries for a real repository
+func TestBackendParity(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	writeFile := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	gitCmd("init", "-q")
+	writeFile("service.go", "package service\n\nfunc Run() {}\n")
+	writeFile("notes.md", "# Notes\n\n- one\n")
+	writeFile("tài liệu.md", "# Tài liệu\n")
+	gitCmd("add", ".")
+	gitCmd("commit", "-q", "-m", "feat: initial")
+	gitCmd("tag", "-a", "v0.1.0", "-m", "Release 0.1.0")
+
+	writeFile("service.go", "package service\n\nfunc Run() error {\n\treturn nil\n}\n")
+	writeFile("added.txt", "new\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "notes.md")))
+	gitCmd("add", "-A")
+
+	execService := NewServiceWithBackend(NewExecBackend(dir))
+	goGitService := NewServiceWithBackend(NewGoGitBackend(dir))
+
+	execSummary, err := execService.GetDiffSummary(true)
+	require.NoError(t, err)
+	goGitSummary, err := goGitService.GetDiffSummary(true)
+	require.NoError(t, err)
+
+	assertSameFiles(t, execSummary, goGitSummary)
+
+	execTags, err := execService.GetTags()
+	require.NoError(t, err)
+	goGitTags, err := goGitService.GetTags()
+	require.NoError(t, err)
+	require.Len(t, execTags, 1)
+	require.Len(t, goGitTags, 1)
+	assert.Equal(t, execTags[0].Hash, goGitTags[0].Hash)
+	assert.Equal(t, execTags[0].IsAnnotated, goGitTags[0].IsAnnotated)
+	assert.Equal(t, execTags[0].Message, goGitTags[0].Message)
+
+	execCommits, err := execService.GetRecentCommits(5)
+	require.NoError(t, err)
+	goGitCommits, err := goGitService.GetRecentCommits(5)
+	require.NoError(t, err)
+	assert.Equal(t, execCommits[0].Hash, goGitCommits[0].Hash)
+	assert.Equal(t, execCommits[0].Slug, goGitCommits[0].Slug)
+
+	gitCmd("commit", "-q", "-m", "refactor: return errors")
+
+	for _, service := range []*Service{execService, goGitService} {
+		files, err := service.ListFilesAt("v0.1.0")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"notes.md", "service.go", "tài liệu.md"}, files)
+		contents, err := service.ReadFilesAt("v0.1.0", files)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{
+			"notes.md":    []byte("# Notes\n\n- one\n"),
+			"service.go":  []byte("package service\n\nfunc Run() {}\n"),
+			"tài liệu.md": []byte("# Tài liệu\n"),
+		}, contents)
+		_, err = service.ReadFilesAt("v0.1.0", []string{"service.go", "added.txt"})
+		assert.Error(t, err)
+		message, err := service.GetCommitMessage("HEAD")
+		require.NoError(t, err)
+		assert.Equal(t, "refactor: return errors", message)
+	}
+
+	writeFile("untracked.txt", "draft\nnotes\n")
+
+	for _, options := range []DiffOptions{
+		{Range: "v0.1.0..HEAD"},
+		{Range: "v0.1.0"},
+		{IncludeUntracked: true},
+	} {
+		execSummary, err := execService.GetDiffSummaryFor(options)
+		require.NoError(t, err)
+		goGitSummary, err := goGitService.GetDiffSummaryFor(options)
+		require.NoError(t, err)
+		assert.NotEmpty(t, execSummary.Files, options.Description())
+		assertSameFiles(t, execSummary, goGitSummary)
+	}
+}
+
+func TestRepositoryRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	output, err := exec.Command("git", "init", "-q", dir).CombinedOutput()
+	require.NoError(t, err, string(output))
+	subdir := filepath.Join(dir, "services", "api")
+	require.NoError(t, os.MkdirAll(subdir, 0755))
+
+	for _, service := range []*Service{
+		NewServiceWithBackend(NewExecBackend(subdir)),
+		NewServiceWithBackend(NewGoGitBackend(subdir)),
+	} {
+		root, err := service.GetRepositoryRoot()
+		require.NoError(t, err)
+		assert.Equal(t, dir, root)
+	}
+}
+
+func TestAddFromSubdirectory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	backends := map[string]func(string) Backend{
+		"exec":   func(dir string) Backend { return NewExecBackend(dir) },
+		"go-git": func(dir string) Backend { return NewGoGitBackend(dir) },
+	}
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			runGit(t, dir, "init", "-q")
+			subdir := filepath.Join(dir, "services", "api")
+			require.NoError(t, os.MkdirAll(subdir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.1.0\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(subdir, "CHANGELOG.md"), []byte("# Changelog\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(subdir, "draft.txt"), []byte("draft\n"), 0644))
+
+			service := NewServiceWithBackend(newBackend(subdir))
+			require.NoError(t, service.Add("VERSION", "services/api/CHANGELOG.md"))
+
+			staged := runGit(t, dir, "diff", "--cached", "--name-only")
+			assert.Equal(t, []string{"VERSION", "services/api/CHANGELOG.md"}, strings.Split(staged, "\n"))
+		})
+	}
+}
+
+func TestLogPathsFromSubdirectory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	subdir := filepath.Join(dir, "services", "api")
+	require.NoError(t, os.MkdirAll(subdir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# App\n"), 0644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "docs: add readme")
+	require.NoError(t, os.WriteFile(filepath.Join(subdir, "go.mod"), []byte("module example.com/api\n"), 0644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "feat(api): add module")
+
+	// Paths are relative to the repository root for both backends
+	for _, service := range []*Service{
+		NewServiceWithBackend(NewExecBackend(subdir)),
+		NewServiceWithBackend(NewGoGitBackend(subdir)),
+	} {
+		commits, err := service.GetCommitsInRangeForPaths("HEAD~1", "HEAD", []string{"services/api"})
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		assert.Equal(t, "feat(api): add module", commits[0].Subject)
+	}
+}
+
+// assertSameFiles compares the file-level results of two diff summaries
+func assertSameFiles(t *testing.T, expected, actual *types.DiffSummary) {
+	t.Helper()
+	require.Len(t, actual.Files, len(expected.Files))
+	for i := range expected.Files {
+		assert.Equal(t, expected.Files[i].Path, actual.Files[i].Path)
+		assert.Equal(t, expected.Files[i].ChangeType, actual.Files[i].ChangeType)
+		assert.Equal(t, expected.Files[i].LinesAdded, actual.Files[i].LinesAdded)
+		assert.Equal(t, expected.Files[i].LinesDeleted, actual.Files[i].LinesDeleted)
+	}
+}
+
+func TestGoGitBackend_RangesAndMergeBase(t *testing.T) {
+	repo := newMemoryRepo(t)
+	repo.write("base.txt", "base\n")
+	repo.add("base.txt")
+	repo.commit("chore: base")
+
+	head, err := repo.repo.Head()
+	require.NoError(t, err)
+	base := head.Hash()
+	require.NoError(t, repo.repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/main", base)))
+
+	repo.write("feature.go", "package feature\n")
+	repo.add("feature.go")
+	repo.commit("feat: add feature")
+	repo.write("base.txt", "base\nchanged\n")
+	repo.add("base.txt")
+	repo.commit("fix: update base")
+
+	service := repo.service()
+
+	mergeBase, err := service.GetMergeBase("main", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, base.String(), mergeBase)
+
+	from, to, err := service.ResolveRange("main...")
+	require.NoError(t, err)
+	assert.Equal(t, base.String(), from)
+	assert.Equal(t, "HEAD", to)
+
+	from, to, err = service.ResolveRange("main..HEAD~1")
+	require.NoError(t, err)
+	assert.Equal(t, "main", from)
+	assert.Equal(t, "HEAD~1", to)
+
+	_, _, err = service.ResolveRange("  ")
+	assert.Error(t, err)
+
+	for _, options := range []DiffOptions{{Range: "main..HEAD"}, {Range: "main"}, {Against: "main"}} {
+		summary, err := service.GetDiffSummaryFor(options)
+		require.NoError(t, err, options.Description())
+		require.Len(t, summary.Files, 2, options.Description())
+		assert.Equal(t, "base.txt", summary.Files[0].Path)
+		assert.Equal(t, types.ChangeTypeModified, summary.Files[0].ChangeType)
+		assert.Equal(t, "feature.go", summary.Files[1].Path)
+		assert.Equal(t, types.ChangeTypeAdded, summary.Files[1].ChangeType)
+	}
+
+	summary, err := service.GetDiffSummaryFor(DiffOptions{Range: "HEAD~1..HEAD"})
+	require.NoError(t, err)
+	require.Len(t, summary.Files, 1)
+	assert.Equal(t, "base.txt", summary.Files[0].Path)
+
+	_, err = service.GetDiffSummaryFor(DiffOptions{Range: "main..HEAD", Against: "main"})
+	assert.Error(t, err)
+
+	detected, err := service.DetectBaseBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", detected)
+
+	commits, err := service.GetBranchCommits("main")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "fix: update base", commits[0].Subject)
+	assert.Equal(t, "feat: add feature", commits[1].Subject)
+}
+
+func TestGoGitBackend_IncludeUntracked(t *testing.T) {
+	repo := newMemoryRepo(t)
+	repo.write("tracked.txt", "one\n")
+	repo.add("tracked.txt")
+	repo.commit("chore: init")
+
+	repo.write("tracked.txt", "one\ntwo\n")
+	repo.write("draft.md", "# Draft\n\nWork in progress\n")
+	service := repo.service()
+
+	summary, err := service.GetDiffSummaryFor(DiffOptions{})
+	require.NoError(t, err)
+	require.Len(t, summary.Files, 1)
+
+	summary, err = service.GetDiffSummaryFor(DiffOptions{IncludeUntracked: true})
+	require.NoError(t, err)
+	require.Len(t, summary.Files, 2)
+
+	files := make(map[string]types.FileChange)
+	for _, file := range summary.Files {
+		files[file.Path] = file
+	}
+	assert.Equal(t, types.ChangeTypeAdded, files["draft.md"].ChangeType)
+	assert.Equal(t, 3, files["draft.md"].LinesAdded)
+	assert.Equal(t, 1, files["tracked.txt"].LinesAdded)
+
+	diffOutput, err := service.GetDiffFor(DiffOptions{IncludeUntracked: true})
+	require.NoError(t, err)
+	assert.Contains(t, diffOutput, "+two")
+	assert.Contains(t, diffOutput, "+Work in progress")
+
+	hasStaged, err := service.HasStagedChanges()
+	require.NoError(t, err)
+	assert.False(t, hasStaged, "previewing untracked files must not touch the index")
+}
+
+func TestGoGitBackend_UserIdentity(t *testing.T) {
+	repo := newMemoryRepo(t)
+
+	identity, err := repo.service().GetUserIdentity()
+	require.NoError(t, err)
+	assert.Equal(t, "Test User <test@example.com>", identity)
+}
+
+// runGit runs the git binary in dir with a fixed identity and no user configuration
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return strings.TrimSpace(string(output))
+}
+
+func TestPushTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	backends := map[string]func(string) Backend{
+		BackendExec:  func(dir string) Backend { return NewExecBackend(dir) },
+		BackendGoGit: func(dir string) Backend { return NewGoGitBackend(dir) },
+	}
+
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			remote := t.TempDir()
+			work := t.TempDir()
+			runGit(t, remote, "init", "-q", "--bare")
+			runGit(t, work, "init", "-q", "-b", "main")
+			runGit(t, work, "remote", "add", "origin", remote)
+			runGit(t, work, "config", "user.name", "Test User")
+			runGit(t, work, "config", "user.email", "test@example.com")
+			require.NoError(t, os.WriteFile(filepath.Join(work, "a.txt"), []byte("a\n"), 0644))
+			runGit(t, work, "add", ".")
+			runGit(t, work, "commit", "-q", "-m", "feat: first")
+
+			service := NewServiceWithBackend(newBackend(work))
+
+			// Tag and branch are pushed together
+			require.NoError(t, service.CreateTag("v1.0.0", "Release 1.0.0", true))
+			require.NoError(t, service.PushTag("origin", "v1.0.0", true))
+			first := runGit(t, work, "rev-parse", "HEAD")
+			assert.Equal(t, first, runGit(t, remote, "rev-parse", "main"))
+			assert.Equal(t, first, runGit(t, remote, "rev-parse", "v1.0.0^{commit}"))
+
+			// Moving an existing remote tag is rejected and the branch is not updated
+			require.NoError(t, os.WriteFile(filepath.Join(work, "b.txt"), []byte("b\n"), 0644))
+			runGit(t, work, "add", ".")
+			runGit(t, work, "commit", "-q", "-m", "feat: second")
+			require.NoError(t, service.DeleteTag("v1.0.0"))
+			require.NoError(t, service.CreateTag("v1.0.0", "Release 1.0.0 again", true))
+
+			err := service.PushTag("origin", "v1.0.0", true)
+			var rejectedErr *PushRejectedError
+			require.ErrorAs(t, err, &rejectedErr)
+			assert.Equal(t, "origin", rejectedErr.Remote)
+			assert.Contains(t, rejectedErr.Rejected, RejectedRef{Ref: "refs/tags/v1.0.0", Reason: "already exists"})
+			assert.Equal(t, first, runGit(t, remote, "rev-parse", "main"))
+
+			// A missing remote is an ordinary error
+			err = service.PushTag("missing", "v1.0.0", false)
+			require.Error(t, err)
+			assert.NotErrorAs(t, err, &rejectedErr)
+		})
+	}
+}
+
+func TestParsePorcelainRejections(t *testing.T) {
+	output := "To /tmp/remote.git\n" +
+		"!\trefs/heads/main:refs/heads/main\t[rejected] (atomic push failed)\n" +
+		"!\trefs/tags/v1.0.0:refs/tags/v1.0.0\t[rejected] (already exists)\n" +
+		"!\trefs/heads/dev:refs/heads/dev\t[remote rejected] (hook declined)\n" +
+		"=\trefs/tags/v0.9.0:refs/tags/v0.9.0\t[up to date]\n" +
+		"Done\n"
+
+	assert.Equal(t, []RejectedRef{
+		{Ref: "refs/heads/main", Reason: "atomic push failed"},
+		{Ref: "refs/tags/v1.0.0", Reason: "already exists"},
+		{Ref: "refs/heads/dev", Reason: "remote rejected: hook declined"},
+	}, parsePorcelainRejections(output))
+}
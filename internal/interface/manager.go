@@ -2,14 +2,18 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/manifoldco/promptui"
 	"github.com/nguyendkn/git-generator/internal/ai"
 	"github.com/nguyendkn/git-generator/internal/config"
 	"github.com/nguyendkn/git-generator/internal/diff"
 	"github.com/nguyendkn/git-generator/internal/generator"
 	"github.com/nguyendkn/git-generator/internal/git"
 	"github.com/nguyendkn/git-generator/internal/ui"
+	"github.com/nguyendkn/git-generator/internal/validation"
 	"github.com/nguyendkn/git-generator/pkg/types"
 )
 
@@ -34,6 +38,33 @@ type GenerateRequest struct {
 	Validation   bool
 	IncludeScope bool
 	Mode         InterfaceMode
+
+	// Change selection; ranges and untracked files always produce a preview only
+	Range            string
+	Against          string
+	IncludeUntracked bool
+
+	// Trailers appended to the generated message
+	Closes    bool
+	SignOff   bool
+	CoAuthors []string
+	Trailers  []string
+}
+
+// generateOptions converts a request into generator options
+func (req GenerateRequest) generateOptions() generator.GenerateOptions {
+	return generator.GenerateOptions{
+		Style:            req.Style,
+		IncludeStaged:    req.Staged,
+		DryRun:           req.DryRun,
+		Range:            req.Range,
+		Against:          req.Against,
+		IncludeUntracked: req.IncludeUntracked,
+		Closes:           req.Closes,
+		SignOff:          req.SignOff,
+		CoAuthors:        req.CoAuthors,
+		Trailers:         req.Trailers,
+	}
 }
 
 // Manager handles dual interface support
@@ -49,7 +80,10 @@ type Manager struct {
 
 // NewManager creates a new interface manager
 func NewManager(cfg *types.Config, cfgMgr *config.Manager, version string) (*Manager, error) {
-	gitService := git.NewService(".")
+	gitService, err := git.NewServiceFromConfig(".", cfg.Git)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git service: %w", err)
+	}
 	diffProcessor := diff.NewProcessor(cfg.Git.MaxDiffSize, 20)
 
 	aiClient, err := ai.NewGeminiClient(cfg.Gemini)
@@ -81,7 +115,7 @@ func (m *Manager) Close() error {
 // Generate generates commit message using the specified interface mode
 func (m *Manager) Generate(ctx context.Context, req GenerateRequest) (*generator.GenerateResult, error) {
 	// Validate changes first
-	if err := m.genService.ValidateChanges(req.Staged); err != nil {
+	if err := m.genService.ValidateChanges(req.generateOptions()); err != nil {
 		return nil, err
 	}
 
@@ -106,11 +140,9 @@ func (m *Manager) Generate(ctx context.Context, req GenerateRequest) (*generator
 func (m *Manager) generateCLI(ctx context.Context, req GenerateRequest) (*generator.GenerateResult, error) {
 	if req.Multiple {
 		// Generate multiple options
-		messages, err := m.genService.GenerateMultipleOptions(ctx, generator.GenerateOptions{
-			Style:         req.Style,
-			IncludeStaged: req.Staged,
-			DryRun:        true, // Always dry run for multiple options
-		}, 3)
+		options := req.generateOptions()
+		options.DryRun = true // Always dry run for multiple options
+		messages, err := m.genService.GenerateMultipleOptions(ctx, options, 3)
 		if err != nil {
 			return nil, err
 		}
@@ -126,11 +158,7 @@ func (m *Manager) generateCLI(ctx context.Context, req GenerateRequest) (*genera
 	}
 
 	// Generate single commit message
-	return m.genService.Generate(ctx, generator.GenerateOptions{
-		Style:         req.Style,
-		IncludeStaged: req.Staged,
-		DryRun:        req.DryRun,
-	})
+	return m.genService.Generate(ctx, req.generateOptions())
 }
 
 // generateInteractive handles interactive-based generation
@@ -153,12 +181,103 @@ func (m *Manager) generateInteractive(ctx context.Context, req GenerateRequest)
 
 	ui.ShowInfoMessage("Đang tạo commit message...")
 
-	// Generate commit message
-	return m.genService.Generate(ctx, generator.GenerateOptions{
-		Style:         mergedReq.Style,
-		IncludeStaged: mergedReq.Staged,
-		DryRun:        mergedReq.DryRun,
-	})
+	// Generate a first message, then review it
+	session, err := m.genService.StartSession(ctx, mergedReq.generateOptions())
+	if err != nil {
+		return nil, err
+	}
+	return m.reviewMessage(ctx, session)
+}
+
+// ErrAborted is returned when the user aborts the review of a generated message
+var ErrAborted = errors.New("commit aborted")
+
+// reviewMessage shows the generated message with the review menu until the
+// message is accepted or the review is aborted. Refinements are follow-up
+// turns of the same conversation.
+func (m *Manager) reviewMessage(ctx context.Context, session *generator.Session) (*generator.GenerateResult, error) {
+	for {
+		ui.ShowCommitMessage(session.Message(), session.Turns())
+		for _, diagnostic := range session.Result.Diagnostics {
+			if diagnostic.Severity == validation.SeverityError {
+				ui.ShowErrorMessage(diagnostic.String())
+			} else {
+				ui.ShowWarningMessage(diagnostic.String())
+			}
+		}
+
+		action, err := ui.SelectReviewAction(session.CanCommit())
+		if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+			return session.Result, ErrAborted // Ctrl-C or Ctrl-D
+		}
+		if err != nil {
+			return nil, fmt.Errorf("lỗi trong chế độ tương tác: %w", err)
+		}
+
+		switch action {
+		case ui.ReviewAccept:
+			if session.CanCommit() {
+				if err := session.Commit(); err != nil {
+					return session.Result, err
+				}
+			}
+			return session.Result, nil
+		case ui.ReviewAbort:
+			return session.Result, ErrAborted
+		case ui.ReviewViewDiff:
+			diffOutput, err := session.Diff()
+			if err != nil {
+				ui.ShowErrorMessage(err.Error())
+				continue
+			}
+			ui.PrintSubHeader("Diff")
+			fmt.Println(diffOutput)
+			continue
+		case ui.ReviewChangeScope:
+			scope, err := ui.PromptScope(session.Result.CommitMessage.Scope)
+			if err != nil {
+				continue
+			}
+			if err := session.SetScope(strings.TrimSpace(scope)); err != nil {
+				ui.ShowErrorMessage(err.Error())
+			}
+			continue
+		case ui.ReviewEdit:
+			if err := session.Edit(); err != nil {
+				ui.ShowErrorMessage(fmt.Sprintf("Lỗi khi chỉnh sửa: %v", err))
+			}
+			continue
+		}
+
+		// The remaining actions call the model
+		var instruction string
+		switch action {
+		case ui.ReviewShorter:
+			instruction = ai.InstructionShorter
+		case ui.ReviewDetailed:
+			instruction = ai.InstructionDetailed
+		case ui.ReviewChangeType:
+			commitType, err := ui.SelectCommitType(string(session.Result.CommitMessage.Type))
+			if err != nil {
+				continue
+			}
+			instruction = ai.TypeInstruction(commitType)
+		case ui.ReviewInstruction:
+			if instruction, err = ui.PromptInstruction(); err != nil {
+				continue
+			}
+		}
+
+		ui.ShowInfoMessage("Đang cập nhật commit message...")
+		if action == ui.ReviewRegenerate {
+			err = session.Regenerate(ctx)
+		} else {
+			err = session.Refine(ctx, instruction)
+		}
+		if err != nil {
+			ui.ShowErrorMessage(err.Error())
+		}
+	}
 }
 
 // detectMode automatically detects the appropriate interface mode
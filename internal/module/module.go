@@ -0,0 +1,264 @@
+package module
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// skippedDirs are never searched for go.mod files
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// Discover returns the separately released modules of the repository at root:
+// Go modules listed in go.work (or every go.mod when there is no workspace),
+// package.json workspaces and the configured modules, sorted by path.
+// Configured modules override discovered ones with the same path.
+func Discover(root string, configured []types.Module) ([]types.Module, error) {
+	byPath := make(map[string]types.Module)
+
+	goModules, err := discoverGo(root)
+	if err != nil {
+		return nil, err
+	}
+	jsModules, err := discoverJS(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range append(goModules, jsModules...) {
+		byPath[module.Path] = module
+	}
+
+	for _, module := range configured {
+		modulePath := cleanPath(module.Path)
+		if module.Name == "" {
+			module.Name = modulePath
+		}
+		if module.TagPrefix == "" {
+			module.TagPrefix = GoTagPrefix(modulePath)
+		}
+		module.Path = modulePath
+		byPath[modulePath] = module
+	}
+
+	modules := make([]types.Module, 0, len(byPath))
+	for _, module := range byPath {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Path < modules[j].Path })
+	return modules, nil
+}
+
+// Find returns the module with the given name or path
+func Find(modules []types.Module, name string) (types.Module, bool) {
+	for _, module := range modules {
+		if module.Name == name || module.Path == cleanPath(name) {
+			return module, true
+		}
+	}
+	return types.Module{}, false
+}
+
+// ReleaseFiles returns the version files and the changelog updated by a
+// release of a module, with paths relative to the repository root. They are
+// declared relative to the module directory, and repository-level files are
+// never part of a module release.
+func ReleaseFiles(module types.Module, changelogFile string) ([]types.VersionFile, string) {
+	files := make([]types.VersionFile, 0, len(module.VersionFiles))
+	for _, file := range module.VersionFiles {
+		file.Path = path.Join(module.Path, filepath.ToSlash(file.Path))
+		files = append(files, file)
+	}
+
+	changelog := module.Changelog
+	if changelog == "" {
+		changelog = path.Base(filepath.ToSlash(changelogFile))
+	}
+	return files, path.Join(module.Path, filepath.ToSlash(changelog))
+}
+
+// GoTagPrefix returns the tag prefix Go uses for a module directory:
+// "v" at the repository root and "dir/v" for nested modules
+func GoTagPrefix(dir string) string {
+	if dir == "." {
+		return "v"
+	}
+	return dir + "/v"
+}
+
+// discoverGo finds Go modules from go.work, or by walking for go.mod files
+func discoverGo(root string) ([]types.Module, error) {
+	var dirs []string
+
+	workspace, err := os.ReadFile(filepath.Join(root, "go.work"))
+	switch {
+	case err == nil:
+		dirs = parseGoWork(workspace)
+	case os.IsNotExist(err):
+		err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				name := entry.Name()
+				if file != root && (skippedDirs[name] || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if entry.Name() == "go.mod" {
+				rel, err := filepath.Rel(root, filepath.Dir(file))
+				if err != nil {
+					return err
+				}
+				dirs = append(dirs, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for go.mod files: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+
+	var modules []types.Module
+	for _, dir := range dirs {
+		dir = cleanPath(dir)
+		content, err := os.ReadFile(filepath.Join(root, dir, "go.mod"))
+		if err != nil {
+			continue
+		}
+		name := dir
+		if dir == "." {
+			name = path.Base(goModulePath(content))
+		}
+		modules = append(modules, types.Module{Name: name, Path: dir, TagPrefix: GoTagPrefix(dir)})
+	}
+	return modules, nil
+}
+
+// parseGoWork returns the directories of the use directives in a go.work file
+func parseGoWork(content []byte) []string {
+	var dirs []string
+	inBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, "//"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			dirs = append(dirs, strings.Trim(line, `"`))
+		case line == "use (":
+			inBlock = true
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+	return dirs
+}
+
+// goModulePath returns the module path declared in a go.mod file
+func goModulePath(content []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return "."
+}
+
+// packageJSON contains the package.json fields used for workspace discovery
+type packageJSON struct {
+	Name       string          `json:"name"`
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+// discoverJS finds the packages of npm, yarn and bun workspaces declared in
+// the root package.json; they are tagged as name@version
+func discoverJS(root string) ([]types.Module, error) {
+	content, err := os.ReadFile(filepath.Join(root, "package.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var manifest packageJSON
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+	patterns := workspacePatterns(manifest.Workspaces)
+
+	var modules []types.Module
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			packageContent, err := os.ReadFile(filepath.Join(match, "package.json"))
+			if err != nil {
+				continue
+			}
+			var pkg packageJSON
+			if err := json.Unmarshal(packageContent, &pkg); err != nil || pkg.Name == "" {
+				continue
+			}
+			rel, err := filepath.Rel(root, match)
+			if err != nil {
+				return nil, err
+			}
+			modules = append(modules, types.Module{Name: pkg.Name, Path: cleanPath(rel), TagPrefix: pkg.Name + "@"})
+		}
+	}
+	return modules, nil
+}
+
+// workspacePatterns reads "workspaces" as either a list of globs or an object with "packages"
+func workspacePatterns(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var patterns []string
+	if err := json.Unmarshal(raw, &patterns); err == nil {
+		return patterns
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil {
+		return object.Packages
+	}
+	return nil
+}
+
+// cleanPath normalizes a module directory to a slash-separated relative path
+func cleanPath(dir string) string {
+	dir = path.Clean(filepath.ToSlash(dir))
+	return strings.TrimPrefix(dir, "./")
+}
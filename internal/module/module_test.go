@@ -0,0 +1,95 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDiscoverGoWork(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.work", "go 1.22\n\nuse (\n\t./api\n\t\"./tools/cli\" // command line\n)\n\nuse .\n")
+	writeFile(t, root, "go.mod", "module example.com/shop\n")
+	writeFile(t, root, "api/go.mod", "module example.com/shop/api\n")
+	writeFile(t, root, "tools/cli/go.mod", "module example.com/shop/tools/cli\n")
+	writeFile(t, root, "unused/go.mod", "module example.com/unused\n")
+
+	modules, err := Discover(root, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Module{
+		{Name: "shop", Path: ".", TagPrefix: "v"},
+		{Name: "api", Path: "api", TagPrefix: "api/v"},
+		{Name: "tools/cli", Path: "tools/cli", TagPrefix: "tools/cli/v"},
+	}, modules)
+}
+
+func TestDiscoverGoModWithoutWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/shop\n")
+	writeFile(t, root, "api/go.mod", "module example.com/shop/api\n")
+	writeFile(t, root, "vendor/example.com/dep/go.mod", "module example.com/dep\n")
+	writeFile(t, root, ".cache/go.mod", "module cache\n")
+
+	modules, err := Discover(root, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Module{
+		{Name: "shop", Path: ".", TagPrefix: "v"},
+		{Name: "api", Path: "api", TagPrefix: "api/v"},
+	}, modules)
+}
+
+func TestDiscoverJSWorkspacesAndConfig(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "package.json", `{"name": "monorepo", "workspaces": {"packages": ["packages/*", "!packages/ignored"]}}`)
+	writeFile(t, root, "packages/ui/package.json", `{"name": "@acme/ui"}`)
+	writeFile(t, root, "packages/docs/README.md", "no package.json")
+
+	modules, err := Discover(root, []types.Module{
+		{Path: "./services/billing"},
+		{Name: "ui", Path: "packages/ui", TagPrefix: "ui-v"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []types.Module{
+		{Name: "ui", Path: "packages/ui", TagPrefix: "ui-v"},
+		{Name: "services/billing", Path: "services/billing", TagPrefix: "services/billing/v"},
+	}, modules)
+
+	found, ok := Find(modules, "./services/billing")
+	assert.True(t, ok)
+	assert.Equal(t, "services/billing", found.Name)
+
+	_, ok = Find(modules, "missing")
+	assert.False(t, ok)
+}
+
+func TestReleaseFiles(t *testing.T) {
+	api := types.Module{
+		Name:         "api",
+		Path:         "services/api",
+		VersionFiles: []types.VersionFile{{Path: "package.json"}, {Path: "./deploy/Chart.yaml", Key: "appVersion"}},
+	}
+
+	files, changelog := ReleaseFiles(api, "docs/CHANGELOG.md")
+	assert.Equal(t, []types.VersionFile{
+		{Path: "services/api/package.json"},
+		{Path: "services/api/deploy/Chart.yaml", Key: "appVersion"},
+	}, files)
+	assert.Equal(t, "services/api/CHANGELOG.md", changelog)
+
+	// Repository-level version files are not bumped for a module without its own
+	files, changelog = ReleaseFiles(types.Module{Name: "web", Path: "web", Changelog: "HISTORY.md"}, "CHANGELOG.md")
+	assert.Empty(t, files)
+	assert.Equal(t, "web/HISTORY.md", changelog)
+}
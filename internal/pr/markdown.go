@@ -0,0 +1,235 @@
+package pr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// section identifies a part of the generated pull request body
+type section int
+
+const (
+	sectionSummary section = iota
+	sectionChanges
+	sectionTesting
+	sectionBreaking
+)
+
+// sectionTitles are the headings used when no template is available
+var sectionTitles = map[section]string{
+	sectionSummary:  "Summary",
+	sectionChanges:  "Changes",
+	sectionTesting:  "Testing",
+	sectionBreaking: "Breaking Changes",
+}
+
+// sectionKeywords map template headings to sections; breaking is checked first
+// because "Breaking changes" also contains "changes"
+var sectionKeywords = []struct {
+	section  section
+	keywords []string
+}{
+	{sectionBreaking, []string{"breaking", "thay đổi phá vỡ"}},
+	{sectionTesting, []string{"test", "verif", "kiểm thử"}},
+	{sectionChanges, []string{"change", "what's new", "thay đổi"}},
+	{sectionSummary, []string{"summary", "description", "overview", "what", "why", "motivation", "context", "tóm tắt", "mô tả"}},
+}
+
+// templatePaths are the locations GitHub looks for a pull request template
+var templatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// RenderMarkdown renders the pull request body as Markdown
+func RenderMarkdown(pr *types.PullRequest) string {
+	var body strings.Builder
+	for _, s := range []section{sectionSummary, sectionChanges, sectionTesting, sectionBreaking} {
+		content := renderSection(pr, s, 3)
+		if content == "" {
+			continue
+		}
+		if body.Len() > 0 {
+			body.WriteString("\n")
+		}
+		body.WriteString(fmt.Sprintf("## %s\n\n%s\n", sectionTitles[s], content))
+	}
+	return body.String()
+}
+
+// FindTemplate returns the path of the repository's pull request template, if any.
+// Multiple templates in .github/PULL_REQUEST_TEMPLATE/ resolve to the first one alphabetically.
+func FindTemplate(root string) (string, bool) {
+	for _, path := range templatePaths {
+		fullPath := filepath.Join(root, path)
+		if info, err := os.Stat(fullPath); err == nil && !info.IsDir() {
+			return fullPath, true
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(root, ".github", "PULL_REQUEST_TEMPLATE", "*.md"))
+	if len(matches) > 0 {
+		sort.Strings(matches)
+		return matches[0], true
+	}
+	return "", false
+}
+
+// FillTemplate fills the sections of a Markdown pull request template.
+// Sections whose heading matches a known section are replaced with generated
+// content; other sections, and sections containing task lists, are kept as
+// they are. Generated content without a matching heading is appended at the end.
+func FillTemplate(template string, pr *types.PullRequest) string {
+	lines := strings.Split(strings.ReplaceAll(template, "\r\n", "\n"), "\n")
+	levels, titles := headings(lines)
+	filled := make(map[section]bool)
+
+	var out []string
+	for i := 0; i < len(lines); {
+		level := levels[i]
+		if level == 0 {
+			out = append(out, lines[i])
+			i++
+			continue
+		}
+
+		// Find the end of this section: the next heading of the same or higher level
+		end := i + 1
+		for end < len(lines) && (levels[end] == 0 || levels[end] > level) {
+			end++
+		}
+
+		s, ok := classifyHeading(titles[i])
+		content := ""
+		if ok && !filled[s] && !hasTaskList(lines[i+1:end]) {
+			content = renderSection(pr, s, level+1)
+		}
+		if content == "" {
+			out = append(out, lines[i:end]...)
+			i = end
+			continue
+		}
+
+		filled[s] = true
+		out = append(out, lines[i], "", content, "")
+		i = end
+	}
+
+	result := strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+	for _, s := range []section{sectionSummary, sectionChanges, sectionTesting, sectionBreaking} {
+		if filled[s] {
+			continue
+		}
+		if content := renderSection(pr, s, 3); content != "" {
+			result += fmt.Sprintf("\n## %s\n\n%s\n", sectionTitles[s], content)
+		}
+	}
+	return result
+}
+
+// renderSection renders the content of one section; headingLevel is used for scope headings
+func renderSection(pr *types.PullRequest, s section, headingLevel int) string {
+	var content strings.Builder
+	switch s {
+	case sectionSummary:
+		content.WriteString(pr.Summary)
+	case sectionChanges:
+		for i, group := range pr.Changes {
+			if len(group.Items) == 0 {
+				continue
+			}
+			if i > 0 {
+				content.WriteString("\n")
+			}
+			if len(pr.Changes) > 1 || group.Scope != generalScope {
+				content.WriteString(fmt.Sprintf("%s %s\n\n", strings.Repeat("#", min(headingLevel, 6)), group.Scope))
+			}
+			writeBullets(&content, group.Items)
+		}
+	case sectionTesting:
+		writeBullets(&content, pr.Testing)
+	case sectionBreaking:
+		writeBullets(&content, pr.BreakingChanges)
+	}
+	return strings.TrimRight(content.String(), "\n")
+}
+
+// writeBullets writes items as a Markdown list, indenting continuation lines
+func writeBullets(content *strings.Builder, items []string) {
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		content.WriteString("- " + strings.ReplaceAll(item, "\n", "\n  ") + "\n")
+	}
+}
+
+// headings returns the heading level (0 for other lines) and title of each line, skipping fenced code blocks
+func headings(lines []string) ([]int, []string) {
+	levels := make([]int, len(lines))
+	titles := make([]string, len(lines))
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			levels[i], titles[i] = parseHeading(line)
+		}
+	}
+	return levels, titles
+}
+
+// parseHeading returns the level and text of an ATX Markdown heading, or 0 if the line is not one
+func parseHeading(line string) (int, string) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return 0, ""
+	}
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(trimmed) && trimmed[level] != ' ') {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(strings.Trim(strings.TrimSpace(trimmed[level:]), "#"))
+}
+
+// hasTaskList reports whether lines contain Markdown task list items meant for the author
+func hasTaskList(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		for _, marker := range []string{"- [ ]", "- [x]", "* [ ]", "* [x]"} {
+			if strings.HasPrefix(strings.ToLower(trimmed), marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// classifyHeading maps a template heading to a known section
+func classifyHeading(title string) (section, bool) {
+	title = strings.ToLower(title)
+	for _, candidate := range sectionKeywords {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(title, keyword) {
+				return candidate.section, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,99 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func samplePullRequest() *types.PullRequest {
+	return &types.PullRequest{
+		Title:   "feat(api): add pagination",
+		Summary: "Adds pagination to list endpoints.",
+		Changes: []types.ScopeChanges{
+			{Scope: "api", Items: []string{"add page parameters"}},
+			{Scope: "general", Items: []string{"update docs"}},
+		},
+		Testing:         []string{"go test ./..."},
+		BreakingChanges: []string{"list responses are wrapped in an object"},
+	}
+}
+
+func TestGroupByScope(t *testing.T) {
+	// Commits are newest first, as returned by git log
+	commits := []*types.CommitInfo{
+		{Subject: "docs: update README"},
+		{Subject: "fix(api): handle empty page"},
+		{Subject: "Initial work"},
+		{Subject: "feat(api): add pagination"},
+		{Subject: "feat(cli): add --page flag"},
+	}
+
+	groups := GroupByScope(commits)
+
+	assert.Equal(t, []types.ScopeChanges{
+		{Scope: "api", Items: []string{"add pagination", "handle empty page"}},
+		{Scope: "cli", Items: []string{"add --page flag"}},
+		{Scope: "general", Items: []string{"Initial work", "update README"}},
+	}, groups)
+}
+
+func TestBreakingChanges(t *testing.T) {
+	commits := []*types.CommitInfo{
+		{Subject: "feat(api)!: remove v1 endpoints"},
+		{Subject: "fix: keep order", Body: "BREAKING CHANGE: results are sorted by name"},
+		{Subject: "chore: tidy"},
+	}
+
+	assert.Equal(t, []string{"results are sorted by name", "remove v1 endpoints"}, BreakingChanges(commits))
+	assert.Equal(t, []string{"a", "b"}, mergeUnique([]string{"a"}, []string{"A ", "b", ""}))
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	expected := "## Summary\n\nAdds pagination to list endpoints.\n\n" +
+		"## Changes\n\n### api\n\n- add page parameters\n\n### general\n\n- update docs\n\n" +
+		"## Testing\n\n- go test ./...\n\n" +
+		"## Breaking Changes\n\n- list responses are wrapped in an object\n"
+
+	assert.Equal(t, expected, RenderMarkdown(samplePullRequest()))
+}
+
+func TestFillTemplate(t *testing.T) {
+	template := "## Description\n\n<!-- Describe your changes -->\n\n" +
+		"```md\n## Testing\n```\n\n" +
+		"## How has this been tested?\n\nTODO\n\n" +
+		"## Checklist\n\n- [ ] Tests added\n"
+
+	filled := FillTemplate(template, samplePullRequest())
+
+	expected := "## Description\n\nAdds pagination to list endpoints.\n\n" +
+		"## How has this been tested?\n\n- go test ./...\n\n" +
+		"## Checklist\n\n- [ ] Tests added\n\n" +
+		"## Changes\n\n### api\n\n- add page parameters\n\n### general\n\n- update docs\n\n" +
+		"## Breaking Changes\n\n- list responses are wrapped in an object\n"
+
+	assert.Equal(t, expected, filled)
+}
+
+func TestFindTemplate(t *testing.T) {
+	root := t.TempDir()
+
+	_, found := FindTemplate(root)
+	assert.False(t, found)
+
+	dir := filepath.Join(root, ".github", "PULL_REQUEST_TEMPLATE")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "feature.md"), []byte("## Summary\n"), 0644))
+
+	path, found := FindTemplate(root)
+	assert.True(t, found)
+	assert.Equal(t, filepath.Join(dir, "feature.md"), path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "pull_request_template.md"), []byte("## Summary\n"), 0644))
+	path, _ = FindTemplate(root)
+	assert.Equal(t, filepath.Join(root, ".github", "pull_request_template.md"), path)
+}
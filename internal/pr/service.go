@@ -0,0 +1,145 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/ai"
+	"github.com/nguyendkn/git-generator/internal/conventional"
+	"github.com/nguyendkn/git-generator/internal/diff"
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// generalScope groups commits that have no conventional scope
+const generalScope = "general"
+
+// Service generates pull request titles and descriptions for the current branch
+type Service struct {
+	gitService    *git.Service
+	diffProcessor *diff.Processor
+	aiClient      *ai.GeminiClient
+}
+
+// Options contains options for pull request generation
+type Options struct {
+	Base     string // Base branch, detected when empty
+	Language string // Output language (vi, en)
+}
+
+// NewService creates a new pull request service
+func NewService(gitService *git.Service, diffProcessor *diff.Processor, aiClient *ai.GeminiClient) *Service {
+	return &Service{
+		gitService:    gitService,
+		diffProcessor: diffProcessor,
+		aiClient:      aiClient,
+	}
+}
+
+// Generate describes the commits and combined diff of HEAD against the base branch
+func (s *Service) Generate(ctx context.Context, options Options) (*types.PullRequest, error) {
+	if !s.gitService.IsGitRepository() {
+		return nil, fmt.Errorf("not in a Git repository")
+	}
+
+	base := options.Base
+	if base == "" {
+		detected, err := s.gitService.DetectBaseBranch()
+		if err != nil {
+			return nil, err
+		}
+		base = detected
+	}
+
+	commits, err := s.gitService.GetBranchCommits(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch commits: %w", err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits between %s and HEAD", base)
+	}
+
+	diffSummary, err := s.gitService.GetDiffSummaryFor(git.DiffOptions{Against: base})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff summary: %w", err)
+	}
+
+	processedDiff, err := s.diffProcessor.ProcessDiff(diffSummary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process diff: %w", err)
+	}
+
+	pr, err := s.aiClient.GeneratePullRequest(ctx, processedDiff, commits, options.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pr.Changes) == 0 {
+		pr.Changes = GroupByScope(commits)
+	}
+	pr.BreakingChanges = mergeUnique(BreakingChanges(commits), pr.BreakingChanges)
+	pr.BaseBranch = base
+	pr.HeadBranch, _ = s.gitService.GetCurrentBranch()
+	pr.Commits = commits
+
+	return pr, nil
+}
+
+// GroupByScope groups commit descriptions by their conventional commit scope.
+// Scopes are sorted alphabetically with unscoped commits last; commits are
+// listed oldest first as they appear in the branch history.
+func GroupByScope(commits []*types.CommitInfo) []types.ScopeChanges {
+	items := make(map[string][]string)
+	for i := len(commits) - 1; i >= 0; i-- {
+		parsed := conventional.ParseHeader(commits[i].Subject)
+		scope := parsed.Scope
+		if scope == "" {
+			scope = generalScope
+		}
+		items[scope] = append(items[scope], parsed.Description)
+	}
+
+	scopes := make([]string, 0, len(items))
+	for scope := range items {
+		scopes = append(scopes, scope)
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		if (scopes[i] == generalScope) != (scopes[j] == generalScope) {
+			return scopes[j] == generalScope
+		}
+		return scopes[i] < scopes[j]
+	})
+
+	groups := make([]types.ScopeChanges, 0, len(scopes))
+	for _, scope := range scopes {
+		groups = append(groups, types.ScopeChanges{Scope: scope, Items: items[scope]})
+	}
+	return groups
+}
+
+// BreakingChanges collects breaking changes declared in commit headers and footers
+func BreakingChanges(commits []*types.CommitInfo) []string {
+	var changes []string
+	for i := len(commits) - 1; i >= 0; i-- {
+		parsed := conventional.Parse(commits[i].Subject + "\n\n" + commits[i].Body)
+		changes = append(changes, parsed.BreakingChanges()...)
+	}
+	return changes
+}
+
+// mergeUnique appends values from extra that are not already present, ignoring case
+func mergeUnique(values, extra []string) []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, value := range append(values, extra...) {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, strings.TrimSpace(value))
+	}
+	return merged
+}
@@ -0,0 +1,49 @@
+package releasenotes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// RenderMarkdown renders release notes as Markdown, skipping empty sections.
+// Upgrade notes come right after the highlights so they are not missed.
+func RenderMarkdown(notes *types.ReleaseNotes) string {
+	var content strings.Builder
+
+	if notes.Title != "" {
+		content.WriteString(fmt.Sprintf("# %s\n", notes.Title))
+	}
+	if notes.Summary != "" {
+		if content.Len() > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(notes.Summary + "\n")
+	}
+
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"Highlights", notes.Highlights},
+		{"Upgrade Notes", notes.UpgradeNotes},
+		{"Features", notes.Features},
+		{"Fixes", notes.Fixes},
+		{"Dependencies", notes.Dependencies},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		if content.Len() > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(fmt.Sprintf("## %s\n\n", section.title))
+		for _, item := range section.items {
+			content.WriteString(fmt.Sprintf("- %s\n", strings.TrimSpace(item)))
+		}
+	}
+
+	return content.String()
+}
@@ -0,0 +1,42 @@
+package releasenotes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	notes := &types.ReleaseNotes{
+		Title:        "Faster exports",
+		Summary:      "Exports are twice as fast.",
+		Highlights:   []string{"CSV exports stream rows"},
+		Features:     []string{"Export to CSV"},
+		UpgradeNotes: []string{"Rename output.format to export.format"},
+	}
+
+	expected := `# Faster exports
+
+Exports are twice as fast.
+
+## Highlights
+
+- CSV exports stream rows
+
+## Upgrade Notes
+
+- Rename output.format to export.format
+
+## Features
+
+- Export to CSV
+`
+	assert.Equal(t, expected, RenderMarkdown(notes))
+}
+
+func TestRenderMarkdownEmptySections(t *testing.T) {
+	notes := &types.ReleaseNotes{Fixes: []string{"Handle empty repositories"}}
+	assert.Equal(t, "## Fixes\n\n- Handle empty repositories\n", RenderMarkdown(notes))
+}
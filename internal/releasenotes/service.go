@@ -0,0 +1,72 @@
+package releasenotes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nguyendkn/git-generator/internal/ai"
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/internal/version"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Service generates release notes for a range of commits
+type Service struct {
+	gitService *git.Service
+	aiClient   *ai.GeminiClient
+}
+
+// Options contains options for release notes generation
+type Options struct {
+	From     string                // Revision the release starts after, usually the previous tag
+	To       string                // Last revision of the release, HEAD when empty
+	Audience types.ReleaseAudience // Readers the notes are written for
+	Language string                // Output language (vi, en)
+}
+
+// NewService creates a new release notes service
+func NewService(gitService *git.Service, aiClient *ai.GeminiClient) *Service {
+	return &Service{
+		gitService: gitService,
+		aiClient:   aiClient,
+	}
+}
+
+// Generate writes release notes for the commits in options.From..options.To.
+// The commits are aggregated with version.AnalyzeCommits so the provider sees
+// the features, fixes, breaking changes and dependency updates explicitly.
+func (s *Service) Generate(ctx context.Context, options Options) (*types.ReleaseNotes, error) {
+	to := options.To
+	if to == "" {
+		to = "HEAD"
+	}
+	audience := options.Audience
+	if audience == "" {
+		audience = types.ReleaseAudienceDevelopers
+	}
+
+	commits, err := s.gitService.GetCommitsInRange(options.From, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits between %s and %s", options.From, to)
+	}
+
+	analysis := version.AnalyzeCommits(commits, nil)
+	notes, err := s.aiClient.GenerateReleaseNotes(ctx, commits, analysis, audience, options.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	// Breaking changes must never be lost, even if the model skips them
+	if len(notes.UpgradeNotes) == 0 {
+		notes.UpgradeNotes = analysis.BreakingChanges
+	}
+	notes.Audience = audience
+	notes.From = options.From
+	notes.To = to
+	notes.CommitCount = len(commits)
+
+	return notes, nil
+}
@@ -167,6 +167,30 @@ func (d *Detector) detectScopeForFileWithPriority(filePath string) (string, int)
 	return "", 0
 }
 
+// Scopes returns every scope the rules can assign: the fixed scopes of the
+// rules, and the scopes of capture group rules (such as internal/$1) found in
+// the given file paths
+func (d *Detector) Scopes(paths []string) []string {
+	seen := make(map[string]bool)
+	for _, rule := range d.rules {
+		if !strings.Contains(rule.Scope, "$1") {
+			seen[rule.Scope] = true
+		}
+	}
+	for _, path := range paths {
+		if scope := d.detectScopeForFile(path); scope != "" {
+			seen[scope] = true
+		}
+	}
+
+	scopes := make([]string, 0, len(seen))
+	for scope := range seen {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
 // SuggestScopeFromContent analyzes file content to suggest more specific scopes
 func (d *Detector) SuggestScopeFromContent(diffSummary *types.DiffSummary) string {
 	// Analyze content patterns for more specific scope detection
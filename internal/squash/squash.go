@@ -0,0 +1,338 @@
+package squash
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/conventional"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// maxMergedScopes is the number of distinct scopes that are still joined in the header
+const maxMergedScopes = 3
+
+// coAuthorToken is the trailer GitHub and GitLab use to credit additional authors
+const coAuthorToken = "Co-authored-by"
+
+// typePriority orders commit types by their impact on a release; earlier types win ties
+var typePriority = []types.CommitType{
+	types.CommitTypeFeat,
+	types.CommitTypeFix,
+	types.CommitTypePerf,
+	types.CommitTypeRevert,
+	types.CommitTypeRefactor,
+	types.CommitTypeDocs,
+	types.CommitTypeTest,
+	types.CommitTypeBuild,
+	types.CommitTypeCI,
+	types.CommitTypeStyle,
+	types.CommitTypeChore,
+}
+
+// releaseTypes always win over other types so squashing never hides a feature or fix from a release
+var releaseTypes = map[string]bool{"feat": true, "fix": true, "perf": true}
+
+// verbTypes infers a type for subjects that do not follow the conventional format
+var verbTypes = map[string]types.CommitType{
+	"add":         types.CommitTypeFeat,
+	"implement":   types.CommitTypeFeat,
+	"introduce":   types.CommitTypeFeat,
+	"support":     types.CommitTypeFeat,
+	"fix":         types.CommitTypeFix,
+	"resolve":     types.CommitTypeFix,
+	"correct":     types.CommitTypeFix,
+	"handle":      types.CommitTypeFix,
+	"refactor":    types.CommitTypeRefactor,
+	"simplify":    types.CommitTypeRefactor,
+	"extract":     types.CommitTypeRefactor,
+	"rename":      types.CommitTypeRefactor,
+	"document":    types.CommitTypeDocs,
+	"optimize":    types.CommitTypePerf,
+	"speed":       types.CommitTypePerf,
+	"revert":      types.CommitTypeRevert,
+	"bump":        types.CommitTypeBuild,
+	"upgrade":     types.CommitTypeBuild,
+	"remove":      types.CommitTypeRefactor,
+	"cleanup":     types.CommitTypeChore,
+	"tidy":        types.CommitTypeChore,
+	"reformat":    types.CommitTypeStyle,
+	"test":        types.CommitTypeTest,
+	"tests":       types.CommitTypeTest,
+	"improve":     types.CommitTypeRefactor,
+	"update":      types.CommitTypeChore,
+	"initial":     types.CommitTypeChore,
+	"init":        types.CommitTypeChore,
+	"prepare":     types.CommitTypeChore,
+	"release":     types.CommitTypeChore,
+	"integrate":   types.CommitTypeFeat,
+	"enable":      types.CommitTypeFeat,
+	"allow":       types.CommitTypeFeat,
+	"create":      types.CommitTypeFeat,
+	"deprecate":   types.CommitTypeRefactor,
+	"restructure": types.CommitTypeRefactor,
+}
+
+// noisePattern matches subjects that carry no information once the branch is squashed
+var noisePattern = regexp.MustCompile(`(?i)^(` +
+	`(fixup|squash|amend)!.*|` +
+	`merge (branch|pull request|remote-tracking branch) .*|` +
+	`wip\b.*|` +
+	`(fix(ed)? )?typos?|` +
+	`(fix(ed)? )?(lint|linter|formatting|format|tests?|build|ci)|` +
+	`(address(ed)? )?(review|pr|code review)( comments| feedback)?|` +
+	`(more )?cleanup|` +
+	`tmp|temp|testing|oops|minor|minor (changes|fixes)|update|updates|changes|\.+|-+` +
+	`)$`)
+
+// Message is a squash commit message synthesized from the commits of a range
+type Message struct {
+	Type        string                // Dominant commit type
+	Scope       string                // Merged scope, empty when the commits touch too many scopes
+	Breaking    bool                  // Any commit declared a breaking change
+	Description string                // Header description
+	Items       []string              // Meaningful changes listed in the body
+	Footers     []conventional.Footer // Preserved footers, deduplicated
+}
+
+// Header returns the conventional commit header
+func (m *Message) Header() string {
+	var header strings.Builder
+	header.WriteString(m.Type)
+	if m.Scope != "" {
+		header.WriteString("(" + m.Scope + ")")
+	}
+	if m.Breaking {
+		header.WriteString("!")
+	}
+	header.WriteString(": " + m.Description)
+	return header.String()
+}
+
+// String formats the complete commit message
+func (m *Message) String() string {
+	var message strings.Builder
+	message.WriteString(m.Header())
+
+	if len(m.Items) > 0 {
+		message.WriteString("\n\n")
+		for _, item := range m.Items {
+			message.WriteString("- " + item + "\n")
+		}
+	}
+
+	if len(m.Footers) > 0 {
+		if len(m.Items) == 0 {
+			message.WriteString("\n")
+		}
+		message.WriteString("\n")
+		for _, footer := range m.Footers {
+			message.WriteString(footer.String() + "\n")
+		}
+	}
+
+	return strings.TrimRight(message.String(), "\n")
+}
+
+// entry is a parsed commit of the range
+type entry struct {
+	commit    *conventional.Commit
+	typ       string
+	noise     bool
+	duplicate bool
+}
+
+// Synthesize collapses commits, newest first as returned by git log, into a
+// single conventional commit message. The header uses the dominant type, the
+// merged scope and the description of the oldest commit of that type; the body
+// lists the other meaningful changes oldest first; footers are preserved.
+func Synthesize(commits []*types.CommitInfo) *Message {
+	entries := make([]*entry, 0, len(commits))
+	seen := make(map[string]bool)
+	for i := len(commits) - 1; i >= 0; i-- {
+		parsed := conventional.Parse(commits[i].Subject + "\n\n" + commits[i].Body)
+		e := &entry{commit: parsed, typ: parsed.Type, noise: IsNoise(parsed.Description)}
+		if e.typ == "" {
+			e.typ = string(inferType(parsed.Description))
+		}
+		key := strings.ToLower(parsed.Description)
+		e.duplicate = seen[key]
+		seen[key] = true
+		entries = append(entries, e)
+	}
+
+	message := &Message{}
+	meaningful := make([]*entry, 0, len(entries))
+	for _, e := range entries {
+		if !e.noise && !e.duplicate {
+			meaningful = append(meaningful, e)
+		}
+	}
+
+	message.Type = dominantType(meaningful)
+	scopes := mergedScopes(meaningful)
+	if len(scopes) <= maxMergedScopes {
+		message.Scope = strings.Join(scopes, ",")
+	}
+
+	var headline *entry
+	for _, e := range meaningful {
+		if e.typ == message.Type {
+			headline = e
+			break
+		}
+	}
+	if headline != nil {
+		message.Description = headline.commit.Description
+	} else if len(entries) > 0 {
+		message.Description = entries[0].commit.Description
+	}
+
+	for _, e := range meaningful {
+		if e == headline {
+			continue
+		}
+		message.Items = append(message.Items, describe(e, message.Type, len(scopes) > 1))
+	}
+
+	message.Footers = mergeFooters(entries)
+	for _, footer := range message.Footers {
+		if footer.IsBreakingChange() {
+			message.Breaking = true
+		}
+	}
+
+	return message
+}
+
+// IsNoise reports whether a commit description is a work-in-progress or housekeeping note
+func IsNoise(description string) bool {
+	return noisePattern.MatchString(strings.TrimSpace(description))
+}
+
+// inferType guesses a commit type from the leading verb of a non-conventional subject
+func inferType(description string) types.CommitType {
+	fields := strings.Fields(strings.ToLower(description))
+	if len(fields) == 0 {
+		return types.CommitTypeChore
+	}
+	verb := strings.TrimRight(fields[0], ":.,")
+	candidates := []string{verb}
+	for _, suffix := range []string{"s", "es", "ed", "d"} {
+		if stem, found := strings.CutSuffix(verb, suffix); found {
+			candidates = append(candidates, stem)
+		}
+	}
+	for _, candidate := range candidates {
+		if commitType, ok := verbTypes[candidate]; ok {
+			return commitType
+		}
+	}
+	return types.CommitTypeChore
+}
+
+// dominantType picks the most impactful release type present, otherwise the most frequent type
+func dominantType(entries []*entry) string {
+	counts := make(map[string]int)
+	for _, e := range entries {
+		counts[e.typ]++
+	}
+
+	for _, commitType := range typePriority {
+		if releaseTypes[string(commitType)] && counts[string(commitType)] > 0 {
+			return string(commitType)
+		}
+	}
+
+	best, bestCount := string(types.CommitTypeChore), 0
+	for _, commitType := range typePriority {
+		if count := counts[string(commitType)]; count > bestCount {
+			best, bestCount = string(commitType), count
+		}
+	}
+	// Custom types outside the known list still count
+	custom := make([]string, 0, len(counts))
+	for commitType := range counts {
+		custom = append(custom, commitType)
+	}
+	sort.Strings(custom)
+	for _, commitType := range custom {
+		if counts[commitType] > bestCount {
+			best, bestCount = commitType, counts[commitType]
+		}
+	}
+	return best
+}
+
+// mergedScopes returns the distinct scopes in order of first appearance
+func mergedScopes(entries []*entry) []string {
+	var scopes []string
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		for _, scope := range strings.Split(e.commit.Scope, ",") {
+			scope = strings.TrimSpace(scope)
+			if scope == "" || seen[scope] {
+				continue
+			}
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// describe formats a commit as a body item, keeping its type when it differs from the header
+func describe(e *entry, headerType string, multipleScopes bool) string {
+	if e.commit.IsConventional && e.typ != headerType {
+		return e.commit.Header
+	}
+	if multipleScopes && e.commit.Scope != "" {
+		return e.commit.Scope + ": " + e.commit.Description
+	}
+	return e.commit.Description
+}
+
+// mergeFooters collects the footers of all commits, including noise commits.
+// Breaking changes come first, co-authors last, and duplicates are dropped.
+// A "!" header without a BREAKING CHANGE footer is turned into one so the
+// description is not lost with the original header.
+func mergeFooters(entries []*entry) []conventional.Footer {
+	var breaking, others, coAuthors []conventional.Footer
+	seen := make(map[string]bool)
+	add := func(list *[]conventional.Footer, footer conventional.Footer) {
+		key := strings.ToLower(footer.Token) + "\x00" + strings.ToLower(strings.TrimSpace(footer.Value))
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		*list = append(*list, footer)
+	}
+
+	for _, e := range entries {
+		hasBreakingFooter := false
+		for _, footer := range e.commit.Footers {
+			switch {
+			case footer.IsBreakingChange():
+				hasBreakingFooter = true
+				footer.Token = conventional.BreakingChangeToken
+				footer.Separator = ": "
+				add(&breaking, footer)
+			case strings.EqualFold(footer.Token, coAuthorToken):
+				footer.Token = coAuthorToken
+				add(&coAuthors, footer)
+			default:
+				add(&others, footer)
+			}
+		}
+		if e.commit.Breaking && !hasBreakingFooter {
+			add(&breaking, conventional.Footer{
+				Token:     conventional.BreakingChangeToken,
+				Separator: ": ",
+				Value:     e.commit.Description,
+			})
+		}
+	}
+
+	footers := append(breaking, others...)
+	return append(footers, coAuthors...)
+}
@@ -0,0 +1,81 @@
+package squash
+
+import (
+	"testing"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSynthesize(t *testing.T) {
+	// Newest first, as returned by git log
+	commits := []*types.CommitInfo{
+		{Subject: "fixup! feat(api): add pagination", Body: "Co-authored-by: Bob <bob@example.com>"},
+		{Subject: "wip"},
+		{Subject: "fix(api): handle empty pages", Body: "Closes #12"},
+		{Subject: "docs(cli): document --page", Body: "Co-authored-by: bob <BOB@example.com>"},
+		{Subject: "feat(api): add pagination", Body: "Adds page and size parameters.\n\nBREAKING-CHANGE: list responses are wrapped\nCloses #10"},
+	}
+
+	message := Synthesize(commits)
+
+	assert.Equal(t, "feat", message.Type)
+	assert.Equal(t, "api,cli", message.Scope)
+	assert.True(t, message.Breaking)
+	assert.Equal(t, "feat(api,cli)!: add pagination", message.Header())
+
+	expected := "feat(api,cli)!: add pagination\n\n" +
+		"- docs(cli): document --page\n" +
+		"- fix(api): handle empty pages\n\n" +
+		"BREAKING CHANGE: list responses are wrapped\n" +
+		"Closes #10\n" +
+		"Closes #12\n" +
+		"Co-authored-by: bob <BOB@example.com>"
+	assert.Equal(t, expected, message.String())
+}
+
+func TestSynthesizeDominantType(t *testing.T) {
+	tests := []struct {
+		name     string
+		subjects []string
+		expected string
+	}{
+		{"feature wins over more fixes", []string{"fix: a", "fix: b", "feat: c"}, "feat:"},
+		{"most frequent non-release type", []string{"docs: a", "refactor: b", "docs: c"}, "docs:"},
+		{"inferred from verbs", []string{"Fixed crash on start", "Update deps"}, "fix:"},
+		{"only noise", []string{"wip", "fix typo"}, "chore:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits := make([]*types.CommitInfo, 0, len(tt.subjects))
+			for _, subject := range tt.subjects {
+				commits = append(commits, &types.CommitInfo{Subject: subject})
+			}
+			assert.Contains(t, Synthesize(commits).Header(), tt.expected)
+		})
+	}
+}
+
+func TestSynthesizeSingleScopeAndBreakingHeader(t *testing.T) {
+	commits := []*types.CommitInfo{
+		{Subject: "refactor(core): rename options"},
+		{Subject: "feat(core)!: drop legacy config"},
+		{Subject: "feat(core): drop legacy config"},
+	}
+
+	message := Synthesize(commits)
+
+	assert.Equal(t, "feat(core)!: drop legacy config\n\n"+
+		"- refactor(core): rename options\n\n"+
+		"BREAKING CHANGE: drop legacy config", message.String())
+}
+
+func TestIsNoise(t *testing.T) {
+	for _, subject := range []string{"wip", "WIP: half done", "fix typo", "address review comments", "squash! feat: x", "Merge branch 'main' into feature", "..."} {
+		assert.True(t, IsNoise(subject), subject)
+	}
+	for _, subject := range []string{"add pagination", "fix typo in error message shown to users", "update dependencies"} {
+		assert.False(t, IsNoise(subject), subject)
+	}
+}
@@ -0,0 +1,278 @@
+package style
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nguyendkn/git-generator/internal/conventional"
+	"github.com/nguyendkn/git-generator/internal/validation"
+)
+
+// conventionalStyle writes Conventional Commits headers: type(scope)!: description
+type conventionalStyle struct{}
+
+// Conventional returns the Conventional Commits style
+func Conventional() Style { return conventionalStyle{} }
+
+// Info describes the style
+func (conventionalStyle) Info() Info {
+	return Info{
+		Name:         "conventional",
+		Description:  "Conventional Commits: type(scope): description",
+		Example:      "<type>[optional scope]: <description>",
+		Conventional: true,
+		Body:         true,
+	}
+}
+
+// Guidance describes the header and the commit types
+func (conventionalStyle) Guidance() []string {
+	return []string{
+		"Generate a commit message following the Conventional Commits specification:",
+		"Format: <type>[optional scope]: <description>",
+		"IMPORTANT: Choose ONLY ONE type that best represents the primary change:",
+		"Types: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert",
+		"- Use 'feat' for new features or functionality",
+		"- Use 'fix' for bug fixes",
+		"- Use 'docs' for documentation changes",
+		"- Use 'refactor' for code refactoring without changing functionality",
+		"- Use 'test' for test-related changes",
+		"- Use 'chore' for maintenance tasks, build changes, or tooling",
+		"- Use 'style' for formatting, missing semicolons, etc.",
+		"- Use 'perf' for performance improvements",
+		"- Use 'build' for build system or external dependencies",
+		"- Use 'ci' for CI configuration files and scripts",
+		"DO NOT mix multiple types in one commit message. Choose the most appropriate single type.",
+	}
+}
+
+// Parse reads a type(scope)!: description header
+func (conventionalStyle) Parse(header string) (Header, bool) {
+	return parseConventional(header)
+}
+
+// Format writes a type(scope)!: description header
+func (conventionalStyle) Format(header Header) string {
+	return formatConventional(header, true)
+}
+
+// WithScope replaces the scope, keeping the "!" marker
+func (c conventionalStyle) WithScope(header, scope string) (string, error) {
+	return withConventionalScope(c, header, scope)
+}
+
+// Configure requires Conventional Commits headers
+func (conventionalStyle) Configure(config *validation.ValidationConfig) {
+	config.RequireConventional = true
+}
+
+// angularStyle follows the Angular commit message guidelines: a lowercase
+// summary, a body for every type but docs, and breaking changes described in
+// a footer
+type angularStyle struct{}
+
+// angularTypes are the types of the Angular guidelines
+var angularTypes = []string{"build", "ci", "docs", "feat", "fix", "perf", "refactor", "test", "revert"}
+
+// Angular returns the Angular style
+func Angular() Style { return angularStyle{} }
+
+// Info describes the style
+func (angularStyle) Info() Info {
+	return Info{
+		Name:         "angular",
+		Description:  "Angular guidelines: type(scope): lowercase summary, body required",
+		Example:      "<type>(<scope>): <short summary>",
+		Conventional: true,
+		Body:         true,
+	}
+}
+
+// Guidance describes the Angular header, body and footer
+func (angularStyle) Guidance() []string {
+	return []string{
+		"Generate a commit message following the Angular commit message guidelines:",
+		"Format: <type>(<scope>): <short summary>",
+		"Types: " + strings.Join(angularTypes, ", "),
+		"- Write the summary in the imperative, present tense; do not capitalize the first letter and do not end with a period",
+		"- The scope is the name of the affected package or component",
+		"- A body explaining the motivation of the change is required for every type except docs",
+		"- Describe breaking changes in a 'BREAKING CHANGE: <description>' footer",
+	}
+}
+
+// Parse reads a type(scope): summary header
+func (angularStyle) Parse(header string) (Header, bool) {
+	return parseConventional(header)
+}
+
+// Format writes a type(scope): summary header with a lowercase summary;
+// breaking changes are marked by their footer
+func (angularStyle) Format(header Header) string {
+	header.Description = lowerFirst(header.Description)
+	return formatConventional(header, false)
+}
+
+// WithScope replaces the scope, keeping a "!" marker written by hand
+func (a angularStyle) WithScope(header, scope string) (string, error) {
+	return withConventionalScope(a, header, scope)
+}
+
+// Configure requires Conventional Commits headers with the Angular types, a
+// lowercase summary and a body for every type but docs
+func (angularStyle) Configure(config *validation.ValidationConfig) {
+	config.RequireConventional = true
+	config.EnforceCapitalization = false
+	config.OmitBreakingMarker = true
+	config.AllowedTypes = slices.Clone(angularTypes)
+	config.SubjectCase = validation.CaseRule{Never: true, Cases: []string{"sentence-case", "start-case", "pascal-case", "upper-case"}}
+	for _, commitType := range angularTypes {
+		if commitType != "docs" && !slices.Contains(config.RequireBodyFor, commitType) {
+			config.RequireBodyFor = append(config.RequireBodyFor, commitType)
+		}
+	}
+}
+
+// traditionalStyle writes a capitalized summary without a type, followed by a body
+type traditionalStyle struct{}
+
+// Traditional returns the traditional Git style
+func Traditional() Style { return traditionalStyle{} }
+
+// Info describes the style
+func (traditionalStyle) Info() Info {
+	return Info{
+		Name:        "traditional",
+		Description: "Traditional Git: capitalized summary and a body explaining why",
+		Example:     "<capitalized summary of what changed>",
+		Body:        true,
+	}
+}
+
+// Guidance describes the summary line and body
+func (traditionalStyle) Guidance() []string {
+	return []string{
+		"Generate a traditional Git commit message:",
+		"- Start with a capitalized summary line in the imperative mood, without a type prefix and without a trailing period",
+		"- Follow with a body that explains what changed and why",
+	}
+}
+
+// Parse accepts any header as the summary
+func (traditionalStyle) Parse(header string) (Header, bool) {
+	header = strings.TrimSpace(header)
+	return Header{Description: header}, header != ""
+}
+
+// Format writes the summary
+func (traditionalStyle) Format(header Header) string {
+	return header.Description
+}
+
+// WithScope fails, traditional headers have no scope
+func (t traditionalStyle) WithScope(string, string) (string, error) {
+	return "", errNoScope(t)
+}
+
+// Configure accepts headers without a type and requires a capital letter
+func (traditionalStyle) Configure(config *validation.ValidationConfig) {
+	config.RequireConventional = false
+	config.EnforceCapitalization = true
+}
+
+// minimalStyle writes a single summary line
+type minimalStyle struct{}
+
+// Minimal returns the minimal style
+func Minimal() Style { return minimalStyle{} }
+
+// Info describes the style
+func (minimalStyle) Info() Info {
+	return Info{
+		Name:        "minimal",
+		Description: "Minimal: a single short summary line",
+		Example:     "<short summary>",
+	}
+}
+
+// Guidance asks for a single line
+func (minimalStyle) Guidance() []string {
+	return []string{
+		"Generate a minimal commit message: a single short summary line in the imperative mood.",
+		"- Do not add a type prefix, a body or footers",
+	}
+}
+
+// Parse accepts any header as the summary
+func (minimalStyle) Parse(header string) (Header, bool) {
+	return traditionalStyle{}.Parse(header)
+}
+
+// Format writes the summary
+func (minimalStyle) Format(header Header) string {
+	return header.Description
+}
+
+// WithScope fails, minimal headers have no scope
+func (m minimalStyle) WithScope(string, string) (string, error) {
+	return "", errNoScope(m)
+}
+
+// Configure accepts headers without a type and messages without a body
+func (minimalStyle) Configure(config *validation.ValidationConfig) {
+	config.RequireConventional = false
+	config.RequireBody = false
+	config.RequireBodyFor = nil
+}
+
+// parseConventional reads a Conventional Commits header
+func parseConventional(header string) (Header, bool) {
+	commit := conventional.ParseHeader(header)
+	if !commit.IsConventional {
+		return Header{Description: commit.Description}, false
+	}
+	return Header{Type: commit.Type, Scope: commit.Scope, Description: commit.Description, Breaking: commit.Breaking}, true
+}
+
+// conventionalScopePattern matches the type, scope and marker of a
+// Conventional Commits header
+var conventionalScopePattern = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!?:)`)
+
+// withConventionalScope replaces the scope of a type(scope): description header
+func withConventionalScope(style Style, header, scope string) (string, error) {
+	matches := conventionalScopePattern.FindStringSubmatchIndex(header)
+	if matches == nil {
+		return "", errNotInStyle(style, header)
+	}
+	if scope != "" {
+		scope = "(" + scope + ")"
+	}
+	return header[:matches[3]] + scope + header[matches[6]:], nil
+}
+
+// formatConventional writes type(scope): description, with "!" for breaking
+// changes when bang is set
+func formatConventional(header Header, bang bool) string {
+	prefix := header.Type
+	if header.Scope != "" {
+		prefix += "(" + header.Scope + ")"
+	}
+	if header.Breaking && bang {
+		prefix += "!"
+	}
+	return prefix + ": " + header.Description
+}
+
+// lowerFirst lowercases the first letter of a text, keeping acronyms such as
+// "API" and names such as "OAuth"
+func lowerFirst(text string) string {
+	first, _, _ := strings.Cut(text, " ")
+	r, size := utf8.DecodeRuneInString(first)
+	if !unicode.IsUpper(r) || strings.IndexFunc(first[size:], unicode.IsUpper) >= 0 {
+		return text
+	}
+	return string(unicode.ToLower(r)) + text[size:]
+}
@@ -0,0 +1,156 @@
+package style
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/validation"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// gitmoji is an emoji of the gitmoji convention with its shortcode and the
+// commit type it stands for
+type gitmoji struct {
+	Emoji     string
+	Shortcode string
+	Type      string
+	Meaning   string
+}
+
+// gitmojis map commit types to gitmojis; the first gitmoji of a type is used
+// when formatting
+var gitmojis = []gitmoji{
+	{Emoji: "✨", Shortcode: ":sparkles:", Type: "feat", Meaning: "Introduce new features"},
+	{Emoji: "🐛", Shortcode: ":bug:", Type: "fix", Meaning: "Fix a bug"},
+	{Emoji: "🚑️", Shortcode: ":ambulance:", Type: "fix", Meaning: "Critical hotfix"},
+	{Emoji: "📝", Shortcode: ":memo:", Type: "docs", Meaning: "Add or update documentation"},
+	{Emoji: "🎨", Shortcode: ":art:", Type: "style", Meaning: "Improve structure or format of the code"},
+	{Emoji: "♻️", Shortcode: ":recycle:", Type: "refactor", Meaning: "Refactor code"},
+	{Emoji: "⚡️", Shortcode: ":zap:", Type: "perf", Meaning: "Improve performance"},
+	{Emoji: "✅", Shortcode: ":white_check_mark:", Type: "test", Meaning: "Add, update, or pass tests"},
+	{Emoji: "📦️", Shortcode: ":package:", Type: "build", Meaning: "Add or update compiled files or packages"},
+	{Emoji: "👷", Shortcode: ":construction_worker:", Type: "ci", Meaning: "Add or update CI build system"},
+	{Emoji: "🔧", Shortcode: ":wrench:", Type: "chore", Meaning: "Add or update configuration files"},
+	{Emoji: "⏪️", Shortcode: ":rewind:", Type: "revert", Meaning: "Revert changes"},
+	{Emoji: "🔒️", Shortcode: ":lock:", Type: "security", Meaning: "Fix security or privacy issues"},
+	{Emoji: "⬆️", Shortcode: ":arrow_up:", Type: "deps", Meaning: "Upgrade dependencies"},
+	{Emoji: "🔥", Shortcode: ":fire:", Type: "chore", Meaning: "Remove code or files"},
+	{Emoji: "💥", Shortcode: ":boom:", Meaning: "Introduce breaking changes"},
+}
+
+// breakingGitmoji marks breaking changes
+const breakingGitmoji = "💥"
+
+// gitmojiHeaderPattern matches "<gitmoji> [(scope):] description", with the
+// gitmoji as an emoji or a :shortcode:
+var gitmojiHeaderPattern = regexp.MustCompile(`^(:[a-z0-9_+-]+:|[^\s\w(]+)\s+(?:\(([^()\s]+)\):?\s+)?(\S.*)$`)
+
+// gitmojiStyle starts headers with a gitmoji for the intention of the change
+type gitmojiStyle struct{}
+
+// Gitmoji returns the gitmoji style
+func Gitmoji() Style { return gitmojiStyle{} }
+
+// Info describes the style
+func (gitmojiStyle) Info() Info {
+	return Info{
+		Name:        "gitmoji",
+		Description: "Gitmoji: an emoji for the intention, then the description",
+		Example:     "<gitmoji> [(scope):] <description>",
+		Body:        true,
+	}
+}
+
+// Guidance lists the gitmojis to choose from
+func (gitmojiStyle) Guidance() []string {
+	guidance := []string{
+		"Generate a commit message following the gitmoji convention:",
+		"Format: <gitmoji> [(scope):] <description>",
+		"IMPORTANT: Start with ONLY ONE gitmoji, written as the emoji character, that best represents the intention of the change:",
+	}
+	for _, g := range gitmojis {
+		guidance = append(guidance, fmt.Sprintf("- %s %s", g.Emoji, g.Meaning))
+	}
+	return guidance
+}
+
+// Parse reads a gitmoji header; the gitmoji gives the commit type
+func (gitmojiStyle) Parse(header string) (Header, bool) {
+	matches := gitmojiHeaderPattern.FindStringSubmatch(strings.TrimSpace(header))
+	if matches == nil {
+		return Header{Description: strings.TrimSpace(header)}, false
+	}
+	g, ok := lookupGitmoji(matches[1])
+	if !ok {
+		return Header{Description: strings.TrimSpace(header)}, false
+	}
+	return Header{Type: g.Type, Scope: matches[2], Description: matches[3], Breaking: g.Emoji == breakingGitmoji}, true
+}
+
+// Format writes the gitmoji of the type, 💥 for breaking changes, then the
+// scope and description
+func (gitmojiStyle) Format(header Header) string {
+	emoji := emojiFor(header.Type)
+	if header.Breaking {
+		emoji = breakingGitmoji
+	}
+	if header.Scope != "" {
+		return emoji + " (" + header.Scope + "): " + header.Description
+	}
+	return emoji + " " + header.Description
+}
+
+// WithScope replaces the scope, keeping the gitmoji as written
+func (g gitmojiStyle) WithScope(header, scope string) (string, error) {
+	matches := gitmojiHeaderPattern.FindStringSubmatch(strings.TrimSpace(header))
+	if matches == nil {
+		return "", errNotInStyle(g, header)
+	}
+	if scope != "" {
+		return matches[1] + " (" + scope + "): " + matches[3], nil
+	}
+	return matches[1] + " " + matches[3], nil
+}
+
+// Configure accepts headers without a type and requires a known gitmoji
+func (gitmojiStyle) Configure(config *validation.ValidationConfig) {
+	config.RequireConventional = false
+
+	alternatives := make([]string, 0, 2*len(gitmojis))
+	for _, g := range gitmojis {
+		alternatives = append(alternatives, regexp.QuoteMeta(strings.TrimSuffix(g.Emoji, variationSelector))+variationSelector+"?", regexp.QuoteMeta(g.Shortcode))
+	}
+	config.CustomRules = append(config.CustomRules, types.CustomRule{
+		ID:      "gitmoji",
+		Pattern: `^(?:` + strings.Join(alternatives, "|") + `) `,
+		Field:   "subject",
+		Require: true,
+		Message: "Start the header with a gitmoji, e.g. '✨ Add login'",
+	})
+}
+
+// variationSelector asks for the emoji presentation of a character; gitmojis
+// are matched with or without it
+const variationSelector = "\ufe0f"
+
+// lookupGitmoji finds a gitmoji by emoji or shortcode
+func lookupGitmoji(text string) (gitmoji, bool) {
+	text = strings.TrimSuffix(text, variationSelector)
+	for _, g := range gitmojis {
+		if text == strings.TrimSuffix(g.Emoji, variationSelector) || text == g.Shortcode {
+			return g, true
+		}
+	}
+	return gitmoji{}, false
+}
+
+// emojiFor returns the gitmoji of a commit type, or 🔧 for other types
+func emojiFor(commitType string) string {
+	for _, g := range gitmojis {
+		if g.Type != "" && g.Type == commitType {
+			return g.Emoji
+		}
+	}
+	return "🔧"
+}
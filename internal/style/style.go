@@ -0,0 +1,141 @@
+package style
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/validation"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Info describes a message style
+type Info struct {
+	Name         string
+	Description  string
+	Example      string // Header showing the format, used in prompts
+	Conventional bool   // Headers follow Conventional Commits: type(scope): description
+	Body         bool   // Messages have a body explaining the change
+}
+
+// Header holds the parts of a header line
+type Header struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// Style defines how commit messages are written: the instructions given to
+// the model, how headers are parsed and formatted, and the validation rules
+// that come with the format
+type Style interface {
+	// Info describes the style
+	Info() Info
+	// Guidance returns the prompt instructions describing the format
+	Guidance() []string
+	// Parse reads a header written in the style; ok is false when the header
+	// does not follow it
+	Parse(header string) (Header, bool)
+	// Format writes a header
+	Format(header Header) string
+	// WithScope replaces the scope of a header, or removes it when empty,
+	// keeping the rest of the header as written
+	WithScope(header, scope string) (string, error)
+	// Configure adjusts validation rules to the style. It runs on top of a
+	// commitlint configuration and before the validation section of the
+	// configuration file.
+	Configure(config *validation.ValidationConfig)
+}
+
+// errNoScope is returned when setting the scope of a style without scopes
+func errNoScope(style Style) error {
+	return fmt.Errorf("the %s style has no scope", style.Info().Name)
+}
+
+// errNotInStyle is returned for a header that does not follow a style
+func errNotInStyle(style Style, header string) error {
+	return fmt.Errorf("header %q does not follow the %s style", header, style.Info().Name)
+}
+
+// Default is the style used when none is selected
+const Default = "conventional"
+
+// aliases map the style names accepted by earlier versions
+var aliases = map[string]string{
+	"simple":   "traditional",
+	"detailed": "traditional",
+}
+
+// Registry holds the available styles by name
+type Registry struct {
+	styles map[string]Style
+}
+
+// NewRegistry creates an empty style registry
+func NewRegistry() *Registry {
+	return &Registry{styles: make(map[string]Style)}
+}
+
+// Builtin returns a registry with the built-in styles, and the template
+// style when output.template has a header
+func Builtin(template types.HeaderTemplate) (*Registry, error) {
+	registry := NewRegistry()
+	for _, style := range []Style{Conventional(), Angular(), Gitmoji(), Traditional(), Minimal()} {
+		if err := registry.Register(style); err != nil {
+			return nil, err
+		}
+	}
+	if template.Header != "" {
+		style, err := NewTemplate(template)
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(style); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Register adds a style. Names must be unique.
+func (r *Registry) Register(style Style) error {
+	name := strings.ToLower(style.Info().Name)
+	if name == "" {
+		return fmt.Errorf("style must have a name")
+	}
+	if _, exists := r.styles[name]; exists {
+		return fmt.Errorf("style %s is already registered", name)
+	}
+	r.styles[name] = style
+	return nil
+}
+
+// Lookup returns the style with the given name; an empty name selects the
+// default style
+func (r *Registry) Lookup(name string) (Style, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = Default
+	}
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	if style, ok := r.styles[name]; ok {
+		return style, nil
+	}
+	if name == "template" {
+		return nil, fmt.Errorf("style template needs output.template.header")
+	}
+	return nil, fmt.Errorf("unknown style %q (use %s)", name, strings.Join(r.Names(), ", "))
+}
+
+// Names returns the registered style names, sorted
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.styles))
+	for name := range r.styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
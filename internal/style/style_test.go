@@ -0,0 +1,245 @@
+package style
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nguyendkn/git-generator/internal/validation"
+	"github.com/nguyendkn/git-generator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	registry, err := Builtin(types.HeaderTemplate{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"", "conventional"},
+		{"conventional", "conventional"},
+		{"Gitmoji", "gitmoji"},
+		{"angular", "angular"},
+		{"simple", "traditional"},
+		{"detailed", "traditional"},
+		{"minimal", "minimal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := registry.Lookup(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selected.Info().Name)
+		})
+	}
+
+	_, err = registry.Lookup("template")
+	assert.ErrorContains(t, err, "output.template.header")
+	_, err = registry.Lookup("fancy")
+	assert.ErrorContains(t, err, "angular, conventional, gitmoji, minimal, traditional")
+	assert.Error(t, registry.Register(Gitmoji()))
+}
+
+func TestFormatAndParse(t *testing.T) {
+	header := Header{Type: "feat", Scope: "auth", Description: "Add login"}
+
+	tests := []struct {
+		style    Style
+		header   Header
+		expected string
+		parsed   Header
+	}{
+		{Conventional(), header, "feat(auth): Add login", header},
+		{Conventional(), Header{Type: "fix", Description: "Drop v1", Breaking: true}, "fix!: Drop v1", Header{Type: "fix", Description: "Drop v1", Breaking: true}},
+		{Angular(), header, "feat(auth): add login", Header{Type: "feat", Scope: "auth", Description: "add login"}},
+		{Angular(), Header{Type: "fix", Description: "Handle API errors"}, "fix: handle API errors", Header{Type: "fix", Description: "handle API errors"}},
+		{Gitmoji(), header, "✨ (auth): Add login", header},
+		{Gitmoji(), Header{Type: "fix", Description: "Fix crash"}, "🐛 Fix crash", Header{Type: "fix", Description: "Fix crash"}},
+		{Gitmoji(), Header{Type: "feat", Description: "Drop v1", Breaking: true}, "💥 Drop v1", Header{Description: "Drop v1", Breaking: true}},
+		{Traditional(), header, "Add login", Header{Description: "Add login"}},
+		{Minimal(), header, "Add login", Header{Description: "Add login"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style.Info().Name+"/"+tt.expected, func(t *testing.T) {
+			formatted := tt.style.Format(tt.header)
+			assert.Equal(t, tt.expected, formatted)
+
+			parsed, ok := tt.style.Parse(formatted)
+			assert.True(t, ok)
+			assert.Equal(t, tt.parsed, parsed)
+		})
+	}
+}
+
+func TestGitmojiParse(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected Header
+		ok       bool
+	}{
+		{"⚡️ Cache parsed templates", Header{Type: "perf", Description: "Cache parsed templates"}, true},
+		{"⚡ Cache parsed templates", Header{Type: "perf", Description: "Cache parsed templates"}, true},
+		{":bug: Fix crash on start", Header{Type: "fix", Description: "Fix crash on start"}, true},
+		{"♻ (api) Split handlers", Header{Type: "refactor", Scope: "api", Description: "Split handlers"}, true},
+		{"feat: Add login", Header{Description: "feat: Add login"}, false},
+		{":unknown: Add login", Header{Description: ":unknown: Add login"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			parsed, ok := Gitmoji().Parse(tt.header)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	selected, err := NewTemplate(types.HeaderTemplate{Header: "[{{.Type}}] {{.Description}}"})
+	require.NoError(t, err)
+
+	header := Header{Type: "feat", Description: "Add login"}
+	assert.Equal(t, "[feat] Add login", selected.Format(header))
+	assert.Equal(t, "[<type>] <description>", selected.Info().Example)
+
+	parsed, ok := selected.Parse("[fix] Handle empty diffs")
+	assert.True(t, ok)
+	assert.Equal(t, Header{Type: "fix", Description: "Handle empty diffs"}, parsed)
+
+	_, ok = selected.Parse("fix: Handle empty diffs")
+	assert.False(t, ok)
+}
+
+func TestTemplateWithPattern(t *testing.T) {
+	selected, err := NewTemplate(types.HeaderTemplate{
+		Header:  "{{.Emoji}} {{if .Scope}}{{.Scope}}: {{end}}{{.Description}}",
+		Pattern: `^(?P<emoji>\S+) (?:(?P<scope>[a-z]+): )?(?P<description>.+)$`,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "✨ auth: Add login", selected.Format(Header{Type: "feat", Scope: "auth", Description: "Add login"}))
+	parsed, ok := selected.Parse("🐛 Fix crash")
+	assert.True(t, ok)
+	assert.Equal(t, Header{Type: "fix", Description: "Fix crash"}, parsed)
+}
+
+func TestNewTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template types.HeaderTemplate
+		expected string
+	}{
+		{"invalid header", types.HeaderTemplate{Header: "{{.Type"}, "invalid template header"},
+		{"header needs pattern", types.HeaderTemplate{Header: "{{if .Scope}}{{.Scope}}{{end}} {{.Description}}"}, "needs output.template.pattern"},
+		{"unknown field", types.HeaderTemplate{Header: "{{.Ticket}} {{.Description}}"}, "needs output.template.pattern"},
+		{"invalid pattern", types.HeaderTemplate{Header: "{{.Description}}", Pattern: "("}, "invalid template pattern"},
+		{"no description group", types.HeaderTemplate{Header: "{{.Description}}", Pattern: "^(?P<type>.+)$"}, "(?P<description>...)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTemplate(tt.template)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	template, err := NewTemplate(types.HeaderTemplate{Header: "[{{.Type}}] {{.Description}}", SubjectOnly: true})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		style    Style
+		message  string
+		expected []string // Rules reported
+	}{
+		{"conventional", Conventional(), "feat: Add login", nil},
+		{"conventional rejects gitmoji", Conventional(), "✨ Add login", []string{"conventional_format"}},
+		{"gitmoji", Gitmoji(), "✨ Add login", nil},
+		{"gitmoji without emoji", Gitmoji(), "Add login", []string{"gitmoji"}},
+		{"angular", Angular(), "feat(auth): add login\n\nUsers sign in with their email.", nil},
+		{"angular needs a body", Angular(), "feat(auth): add login", []string{"body_required"}},
+		{"angular sentence case", Angular(), "docs: Describe styles", []string{"subject_case"}},
+		{"traditional", Traditional(), "Add login", nil},
+		{"template", template, "[feat] Add login", nil},
+		{"template mismatch", template, "feat: Add login", []string{"template-header"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validation.DefaultConfig()
+			config.RequireConventional = true
+			tt.style.Configure(&config)
+
+			var rules []string
+			for _, diagnostic := range validation.NewValidator(config).Lint(tt.message) {
+				rules = append(rules, diagnostic.Rule)
+			}
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestConfigureBreakingMarker(t *testing.T) {
+	message := "feat(api): drop v1\n\nUsers must migrate.\n\nBREAKING CHANGE: v1 endpoints are gone"
+
+	tests := []struct {
+		style    Style
+		expected string
+	}{
+		{Conventional(), "feat(api)!: drop v1"},
+		{Angular(), "feat(api): drop v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style.Info().Name, func(t *testing.T) {
+			config := validation.DefaultConfig()
+			tt.style.Configure(&config)
+
+			fixed, _ := validation.NewValidator(config).Fix(message)
+			header, _, _ := strings.Cut(fixed, "\n")
+			assert.Equal(t, tt.expected, header)
+		})
+	}
+}
+
+func TestWithScope(t *testing.T) {
+	withScope, err := NewTemplate(types.HeaderTemplate{Header: "[{{.Type}}] {{if .Scope}}{{.Scope}}: {{end}}{{.Description}}", Pattern: `^\[(?P<type>\w+)\] (?:(?P<scope>[a-z]+): )?(?P<description>.+)$`})
+	require.NoError(t, err)
+	withoutScope, err := NewTemplate(types.HeaderTemplate{Header: "[{{.Type}}] {{.Description}}"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		style    Style
+		header   string
+		scope    string
+		expected string
+		err      string
+	}{
+		{"conventional", Conventional(), "fix: Handle empty input", "parser", "fix(parser): Handle empty input", ""},
+		{"conventional keeps the marker", Conventional(), "feat(api)!: Drop v1", "", "feat!: Drop v1", ""},
+		{"angular keeps the marker", Angular(), "feat(api)!: drop v1", "server", "feat(server)!: drop v1", ""},
+		{"gitmoji keeps the shortcode", Gitmoji(), ":fire: Remove dead code", "cli", ":fire: (cli): Remove dead code", ""},
+		{"template", withScope, "[fix] Handle empty input", "parser", "[fix] parser: Handle empty input", ""},
+		{"template without scope", withoutScope, "[fix] Handle empty input", "parser", "", "has no scope"},
+		{"traditional", Traditional(), "Handle empty input", "parser", "", "the traditional style has no scope"},
+		{"not in the style", Angular(), "Handle empty input", "parser", "", "does not follow the angular style"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, err := tt.style.WithScope(tt.header, tt.scope)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, header)
+		})
+	}
+}
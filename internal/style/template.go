@@ -0,0 +1,184 @@
+package style
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/nguyendkn/git-generator/internal/validation"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// templateField matches a field of a header template, such as {{.Type}}
+var templateField = regexp.MustCompile(`\{\{-?\s*\.(\w+)\s*-?\}\}`)
+
+// templateGroups are the patterns of the fields a header pattern is derived from
+var templateGroups = map[string]string{
+	"Type":        `(?P<type>[a-z]+)`,
+	"Scope":       `(?P<scope>[^()\s]*)`,
+	"Description": `(?P<description>.+)`,
+	"Emoji":       `(?P<emoji>\S+)`,
+}
+
+// templateStyle formats headers with a template from output.template
+type templateStyle struct {
+	config  types.HeaderTemplate
+	header  *template.Template
+	pattern *regexp.Regexp
+}
+
+// templateData is the value a header template is executed with
+type templateData struct {
+	Header
+	Emoji string // Gitmoji of the type
+}
+
+// NewTemplate creates the template style. A pattern parsing headers is
+// derived from headers made only of text and fields; other headers need
+// output.template.pattern.
+func NewTemplate(config types.HeaderTemplate) (Style, error) {
+	header, err := template.New("header").Option("missingkey=error").Parse(config.Header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template header %q: %w", config.Header, err)
+	}
+
+	source := config.Pattern
+	if source == "" {
+		if source, err = derivePattern(config.Header); err != nil {
+			return nil, err
+		}
+	}
+	pattern, err := regexp.Compile(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template pattern %q: %w", source, err)
+	}
+	if !slices.Contains(pattern.SubexpNames(), "description") {
+		return nil, fmt.Errorf("template pattern %q needs a (?P<description>...) group", source)
+	}
+
+	return &templateStyle{config: config, header: header, pattern: pattern}, nil
+}
+
+// derivePattern builds the pattern of a header made of text and fields
+func derivePattern(header string) (string, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, match := range templateField.FindAllStringSubmatchIndex(header, -1) {
+		text := header[last:match[0]]
+		if strings.Contains(text, "{{") {
+			return "", fmt.Errorf("template header %q needs output.template.pattern", header)
+		}
+		group, ok := templateGroups[header[match[2]:match[3]]]
+		if !ok {
+			return "", fmt.Errorf("template header %q needs output.template.pattern", header)
+		}
+		pattern.WriteString(regexp.QuoteMeta(text) + group)
+		last = match[1]
+	}
+	rest := header[last:]
+	if strings.Contains(rest, "{{") {
+		return "", fmt.Errorf("template header %q needs output.template.pattern", header)
+	}
+	pattern.WriteString(regexp.QuoteMeta(rest) + "$")
+	return pattern.String(), nil
+}
+
+// Info describes the style
+func (s *templateStyle) Info() Info {
+	return Info{
+		Name:        "template",
+		Description: "Template: headers written with output.template.header",
+		Example:     s.Format(Header{Type: "<type>", Scope: "<scope>", Description: "<description>"}),
+		Body:        !s.config.SubjectOnly,
+	}
+}
+
+// Guidance describes the header template and adds the configured instructions
+func (s *templateStyle) Guidance() []string {
+	guidance := []string{
+		"Generate a commit message whose header follows this template exactly:",
+		"Format: " + s.Info().Example,
+	}
+	if s.config.SubjectOnly {
+		guidance = append(guidance, "- Do not add a body or footers")
+	}
+	for _, line := range s.config.Guidance {
+		guidance = append(guidance, "- "+line)
+	}
+	return guidance
+}
+
+// Parse reads a header with the template pattern
+func (s *templateStyle) Parse(header string) (Header, bool) {
+	header = strings.TrimSpace(header)
+	matches := s.pattern.FindStringSubmatch(header)
+	if matches == nil {
+		return Header{Description: header}, false
+	}
+
+	var parsed Header
+	for i, name := range s.pattern.SubexpNames() {
+		switch name {
+		case "type":
+			parsed.Type = strings.ToLower(matches[i])
+		case "scope":
+			parsed.Scope = matches[i]
+		case "description":
+			parsed.Description = strings.TrimSpace(matches[i])
+		case "breaking":
+			parsed.Breaking = matches[i] != ""
+		case "emoji":
+			if g, ok := lookupGitmoji(matches[i]); ok {
+				if parsed.Type == "" {
+					parsed.Type = g.Type
+				}
+				parsed.Breaking = parsed.Breaking || g.Emoji == breakingGitmoji
+			}
+		}
+	}
+	return parsed, true
+}
+
+// Format executes the header template. A template that fails to execute
+// falls back to a Conventional Commits header.
+func (s *templateStyle) Format(header Header) string {
+	var formatted strings.Builder
+	if err := s.header.Execute(&formatted, templateData{Header: header, Emoji: emojiFor(header.Type)}); err != nil {
+		return formatConventional(header, true)
+	}
+	return formatted.String()
+}
+
+// WithScope parses the header and writes it again with the scope, when the
+// template has one
+func (s *templateStyle) WithScope(header, scope string) (string, error) {
+	if s.Format(Header{Scope: "scope"}) == s.Format(Header{}) {
+		return "", errNoScope(s)
+	}
+	parsed, ok := s.Parse(header)
+	if !ok {
+		return "", errNotInStyle(s, header)
+	}
+	parsed.Scope = scope
+	return s.Format(parsed), nil
+}
+
+// Configure accepts headers without a type and requires headers matching the
+// template pattern
+func (s *templateStyle) Configure(config *validation.ValidationConfig) {
+	config.RequireConventional = false
+	if s.config.SubjectOnly {
+		config.RequireBody = false
+		config.RequireBodyFor = nil
+	}
+	config.CustomRules = append(config.CustomRules, types.CustomRule{
+		ID:      "template-header",
+		Pattern: s.pattern.String(),
+		Field:   "subject",
+		Require: true,
+		Message: fmt.Sprintf("Header must follow the template '%s'", s.config.Header),
+	})
+}
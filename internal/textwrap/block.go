@@ -0,0 +1,115 @@
+package textwrap
+
+import (
+	"regexp"
+	"strings"
+)
+
+// listItemPattern matches the marker of a bullet or numbered list item
+var listItemPattern = regexp.MustCompile(`^(\s*)([-*+•]|\d+[.)])\s+`)
+
+// Block is a paragraph of a message body, or a code block
+type Block struct {
+	Lines []string
+	Code  bool // Fenced or indented code, kept as written
+}
+
+// IsList reports whether a paragraph contains list items
+func (b Block) IsList() bool {
+	for _, line := range b.Lines {
+		if listItemPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitBlocks splits a body into paragraphs separated by blank lines. Fenced
+// code blocks, which may contain blank lines, and paragraphs indented as code
+// are marked as code.
+func SplitBlocks(body string) []Block {
+	var blocks []Block
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, Block{Lines: current, Code: isIndentedCode(current)})
+			current = nil
+		}
+	}
+
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			if !inFence {
+				flush()
+			}
+			current = append(current, line)
+			if inFence {
+				blocks = append(blocks, Block{Lines: current, Code: true})
+				current = nil
+			}
+			inFence = !inFence
+		case inFence:
+			current = append(current, line)
+		case trimmed == "":
+			flush()
+		default:
+			current = append(current, line)
+		}
+	}
+
+	// An unterminated fence runs to the end of the body
+	if inFence {
+		blocks = append(blocks, Block{Lines: current, Code: true})
+	} else {
+		flush()
+	}
+	return blocks
+}
+
+// isIndentedCode reports whether every line is indented by a tab or four spaces
+func isIndentedCode(lines []string) bool {
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "    ") {
+			return false
+		}
+	}
+	return true
+}
+
+// WrapList wraps each item of a list with a hanging indent under its text.
+// Indented lines that do not start an item continue the previous item, and
+// lines before the first item are wrapped as a paragraph.
+func WrapList(lines []string, maxWidth int) string {
+	var intro []string
+	var items [][2]string // Marker with its indentation, and text
+	for _, line := range lines {
+		if marker := listItemPattern.FindString(line); marker != "" {
+			items = append(items, [2]string{marker, strings.TrimSpace(line[len(marker):])})
+		} else if len(items) > 0 {
+			items[len(items)-1][1] += " " + strings.TrimSpace(line)
+		} else {
+			intro = append(intro, strings.TrimSpace(line))
+		}
+	}
+
+	var result []string
+	if len(intro) > 0 {
+		result = append(result, Wrap(strings.Join(intro, " "), maxWidth))
+	}
+	for _, item := range items {
+		marker := strings.TrimRight(item[0], " \t") + " "
+		indent := strings.Repeat(" ", Width(marker))
+		wrapped := strings.Split(Wrap(item[1], maxWidth-Width(marker)), "\n")
+		for i, line := range wrapped {
+			if i == 0 {
+				result = append(result, marker+line)
+			} else {
+				result = append(result, indent+line)
+			}
+		}
+	}
+	return strings.Join(result, "\n")
+}
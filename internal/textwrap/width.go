@@ -0,0 +1,162 @@
+// Package textwrap measures and wraps text by terminal display width, so
+// that Vietnamese, East Asian and emoji text lines up like ASCII text.
+package textwrap
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// noLineStart are closing punctuation marks that stay attached to the
+// character before them instead of starting a line
+const noLineStart = "、。，．！？：；）」』】〕〉》”’…"
+
+// runeWidth returns the number of terminal columns of a rune: two for East
+// Asian wide and fullwidth characters, zero for combining marks and
+// zero-width characters, one otherwise
+func runeWidth(r rune) int {
+	// Combining marks, variation selectors, zero-width spaces and joiners
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cc, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// Width returns the number of terminal columns a string takes
+func Width(text string) int {
+	total := 0
+	for _, r := range text {
+		total += runeWidth(r)
+	}
+	return total
+}
+
+// isWide reports whether a rune is an East Asian wide character. Lines may
+// break before and after wide characters even without a space.
+func isWide(r rune) bool {
+	return runeWidth(r) == 2
+}
+
+// segment is a piece of text a line can end after
+type segment struct {
+	text  string
+	space bool // Separated from the previous segment by a space
+}
+
+// segments splits text at spaces and around East Asian wide characters.
+// Closing punctuation stays with the character before it, and words such as
+// URLs are never split.
+func segments(text string) []segment {
+	var result []segment
+	for _, word := range strings.Fields(text) {
+		space := true
+		var current strings.Builder
+		previousWide := false
+		for _, r := range word {
+			wide := isWide(r)
+			attach := strings.ContainsRune(noLineStart, r)
+			if current.Len() > 0 && (wide || previousWide) && !attach {
+				result = append(result, segment{text: current.String(), space: space})
+				current.Reset()
+				space = false
+			}
+			current.WriteRune(r)
+			previousWide = wide
+		}
+		result = append(result, segment{text: current.String(), space: space})
+	}
+	return result
+}
+
+// Truncate cuts text to at most maxWidth columns without splitting a rune
+func Truncate(text string, maxWidth int) string {
+	total := 0
+	for i, r := range text {
+		if total+runeWidth(r) > maxWidth {
+			return text[:i]
+		}
+		total += runeWidth(r)
+	}
+	return text
+}
+
+// Wrap wraps text to a display width. Lines break at spaces and between
+// East Asian wide characters; a word wider than a line, such as a URL, gets
+// a line of its own.
+func Wrap(text string, maxLength int) string {
+	if Width(text) <= maxLength {
+		return text
+	}
+
+	var lines []string
+	var currentLine strings.Builder
+	currentWidth := 0
+
+	for _, segment := range segments(text) {
+		segmentWidth := Width(segment.text)
+		separator := 0
+		if segment.space && currentLine.Len() > 0 {
+			separator = 1
+		}
+
+		// Check if adding this segment would exceed line length
+		if currentLine.Len() == 0 || currentWidth+separator+segmentWidth <= maxLength {
+			if separator > 0 {
+				currentLine.WriteString(" ")
+			}
+			currentLine.WriteString(segment.text)
+			currentWidth += separator + segmentWidth
+			continue
+		}
+
+		// Start new line
+		lines = append(lines, currentLine.String())
+		currentLine.Reset()
+		currentLine.WriteString(segment.text)
+		currentWidth = segmentWidth
+	}
+
+	if currentLine.Len() > 0 {
+		lines = append(lines, currentLine.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// TruncateAtWord truncates text to a display width at a word boundary, or
+// between East Asian wide characters
+func TruncateAtWord(text string, maxLength int) string {
+	if Width(text) <= maxLength {
+		return text
+	}
+
+	var truncated strings.Builder
+	currentWidth := 0
+	for _, segment := range segments(text) {
+		separator := 0
+		if segment.space && truncated.Len() > 0 {
+			separator = 1
+		}
+		if currentWidth+separator+Width(segment.text) > maxLength {
+			break
+		}
+		if separator > 0 {
+			truncated.WriteString(" ")
+		}
+		truncated.WriteString(segment.text)
+		currentWidth += separator + Width(segment.text)
+	}
+
+	if truncated.Len() > 0 {
+		return truncated.String()
+	}
+
+	// No boundary found, hard truncate
+	return Truncate(text, maxLength-3) + "..."
+}
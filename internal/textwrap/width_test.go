@@ -0,0 +1,110 @@
+package textwrap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{text: "fix login", expected: 9},
+		{text: "sửa lỗi đăng nhập", expected: 17},
+		{text: "l\u00f4\u0303i", expected: 3}, // Decomposed tone marks are combining characters
+		{text: "修复登录问题", expected: 12},
+		{text: "ログインを修正", expected: 14},
+		{text: "로그인 수정", expected: 11},
+		{text: "ｆｕｌｌ", expected: 8},
+		{text: "a\u200bb", expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Width(tt.text))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected string
+	}{
+		{
+			name:     "english",
+			text:     "Move the session cache out of the request handler",
+			width:    20,
+			expected: "Move the session\ncache out of the\nrequest handler",
+		},
+		{
+			name:     "vietnamese counts characters, not bytes",
+			text:     "Chuyển bộ nhớ đệm phiên ra khỏi trình xử lý yêu cầu",
+			width:    24,
+			expected: "Chuyển bộ nhớ đệm phiên\nra khỏi trình xử lý yêu\ncầu",
+		},
+		{
+			name:     "chinese breaks between characters",
+			text:     "将会话缓存移出请求处理程序。",
+			width:    10,
+			expected: "将会话缓存\n移出请求处\n理程序。",
+		},
+		{
+			name:     "closing punctuation does not start a line",
+			text:     "修复登录问题。更新文档",
+			width:    12,
+			expected: "修复登录问\n题。更新文档",
+		},
+		{
+			name:     "japanese mixed with latin words",
+			text:     "APIクライアントのtimeoutを修正",
+			width:    16,
+			expected: "APIクライアント\nのtimeoutを修正",
+		},
+		{
+			name:     "urls are not split",
+			text:     "See https://example.com/a/very/long/path/to/the/issue for details",
+			width:    20,
+			expected: "See\nhttps://example.com/a/very/long/path/to/the/issue\nfor details",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := Wrap(tt.text, tt.width)
+			assert.Equal(t, tt.expected, wrapped)
+			for _, line := range strings.Split(wrapped, "\n") {
+				if !strings.Contains(line, "://") {
+					assert.LessOrEqual(t, Width(line), tt.width, line)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateAtWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected string
+	}{
+		{name: "fits", text: "add login", width: 20, expected: "add login"},
+		{name: "english", text: "add login page with remember me", width: 20, expected: "add login page with"},
+		{name: "vietnamese", text: "thêm trang đăng nhập có ghi nhớ", width: 20, expected: "thêm trang đăng nhập"},
+		{name: "chinese", text: "添加登录页面和记住我选项", width: 9, expected: "添加登录"},
+		{name: "hard truncate", text: "internationalization", width: 10, expected: "interna..."},
+		{name: "hard truncate keeps runes whole", text: "đđđđđđđđđđđđ", width: 8, expected: "đđđđđ..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TruncateAtWord(tt.text, tt.width))
+		})
+	}
+}
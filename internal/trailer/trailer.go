@@ -0,0 +1,275 @@
+package trailer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Well-known trailer tokens
+const (
+	TokenRefs         = "Refs"
+	TokenCloses       = "Closes"
+	TokenSignedOffBy  = "Signed-off-by"
+	TokenCoAuthoredBy = "Co-authored-by"
+)
+
+// tokenPattern matches a trailer line "Token: value", or the conventional commits
+// form "Token #value"; "BREAKING CHANGE" is the only token with a space
+var tokenPattern = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9][A-Za-z0-9-]*)(?:\s*:\s?(.*)| (#.*))$`)
+
+// standardNames are names of standards, such as UTF-8 and ISO-8601, that look
+// like issue keys but never are
+var standardNames = map[string]bool{"UTF": true, "ISO": true, "RFC": true, "SHA": true}
+
+// Builder computes the trailers for a commit from configuration and per-commit options
+type Builder struct {
+	config   types.TrailerConfig
+	patterns []*regexp.Regexp
+}
+
+// Options contains the per-commit inputs for trailer generation
+type Options struct {
+	Branch    string   // Current branch, used to extract issue keys
+	Closes    bool     // Use Closes instead of the configured issue token
+	SignOff   bool     // Add Signed-off-by even if not enabled in config
+	Identity  string   // "Name <email>" of the committer, required for sign-off
+	CoAuthors []string // Roster aliases or literal "Name <email>" values
+	Custom    []string // Additional "Token: value" trailers
+}
+
+// NewBuilder creates a new trailer builder
+func NewBuilder(config types.TrailerConfig) (*Builder, error) {
+	patterns := make([]*regexp.Regexp, 0, len(config.IssuePatterns))
+	for _, pattern := range config.IssuePatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, compiled)
+	}
+	return &Builder{config: config, patterns: patterns}, nil
+}
+
+// SignOffEnabled reports whether a Signed-off-by trailer will be added
+func (b *Builder) SignOffEnabled(options Options) bool {
+	return options.SignOff || b.config.SignOff
+}
+
+// Build returns the trailers for a commit in the order they should appear:
+// issue references, custom trailers, co-authors and finally the sign-off
+func (b *Builder) Build(options Options) ([]types.Trailer, error) {
+	var trailers []types.Trailer
+
+	issueToken := b.config.IssueToken
+	if issueToken == "" {
+		issueToken = TokenRefs
+	}
+	if options.Closes {
+		issueToken = TokenCloses
+	}
+	for _, key := range b.IssueKeys(options.Branch) {
+		trailers = append(trailers, types.Trailer{Token: issueToken, Value: key})
+	}
+
+	for _, custom := range append(append([]string{}, b.config.Custom...), options.Custom...) {
+		trailer, err := Parse(custom)
+		if err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, trailer)
+	}
+
+	for _, coAuthor := range options.CoAuthors {
+		identity, err := b.resolveCoAuthor(coAuthor)
+		if err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, types.Trailer{Token: TokenCoAuthoredBy, Value: identity})
+	}
+
+	if b.SignOffEnabled(options) {
+		if options.Identity == "" {
+			return nil, fmt.Errorf("sign-off requires git user.name and user.email to be configured")
+		}
+		trailers = append(trailers, types.Trailer{Token: TokenSignedOffBy, Value: options.Identity})
+	}
+
+	return trailers, nil
+}
+
+// IssueKeys extracts issue keys from a branch name in order of appearance.
+// Patterns with a capture group use the first group; numeric keys get a "#" prefix.
+// Names of standards such as UTF-8 are skipped.
+func (b *Builder) IssueKeys(branch string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, pattern := range b.patterns {
+		for _, match := range pattern.FindAllStringSubmatch(branch, -1) {
+			key := match[0]
+			if len(match) > 1 && match[1] != "" {
+				key = match[1]
+			}
+			if isNumeric(key) {
+				key = "#" + key
+			}
+			if project, _, found := strings.Cut(key, "-"); found && standardNames[project] {
+				continue
+			}
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// resolveCoAuthor looks up an alias in the team roster or accepts a literal "Name <email>"
+func (b *Builder) resolveCoAuthor(coAuthor string) (string, error) {
+	coAuthor = strings.TrimSpace(coAuthor)
+	for alias, identity := range b.config.Team {
+		if strings.EqualFold(alias, coAuthor) {
+			return identity, nil
+		}
+	}
+	if strings.Contains(coAuthor, "<") && strings.HasSuffix(coAuthor, ">") {
+		return coAuthor, nil
+	}
+	return "", fmt.Errorf("unknown co-author %q: add it to trailers.team or use \"Name <email>\"", coAuthor)
+}
+
+// Parse parses a "Token: value" or "Token=value" trailer, as accepted by git commit --trailer
+func Parse(text string) (types.Trailer, error) {
+	separator := strings.IndexAny(text, ":=")
+	if separator <= 0 {
+		return types.Trailer{}, fmt.Errorf("invalid trailer %q: expected \"Token: value\"", text)
+	}
+	token := strings.TrimSpace(text[:separator])
+	value := strings.TrimSpace(text[separator+1:])
+	if !tokenPattern.MatchString(token+": ") || value == "" {
+		return types.Trailer{}, fmt.Errorf("invalid trailer %q: expected \"Token: value\"", text)
+	}
+	return types.Trailer{Token: token, Value: value}, nil
+}
+
+// Merge adds trailers to a commit message following git interpret-trailers
+// semantics with --if-exists addIfDifferent and --where end: trailers are
+// appended to the message's trailer block (created if missing), tokens are
+// compared case-insensitively, and a trailer is skipped when the same token
+// and value are already present.
+func Merge(message string, trailers []types.Trailer) string {
+	message = strings.TrimRight(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	if len(trailers) == 0 {
+		return message
+	}
+
+	lines := strings.Split(message, "\n")
+	blockStart := trailerBlockStart(lines)
+
+	var existing []types.Trailer
+	if blockStart >= 0 {
+		existing = parseBlock(lines[blockStart:])
+	}
+
+	var added []string
+	for _, trailer := range trailers {
+		if containsTrailer(existing, trailer) {
+			continue
+		}
+		existing = append(existing, trailer)
+		added = append(added, trailer.String())
+	}
+	if len(added) == 0 {
+		return message
+	}
+
+	if blockStart < 0 {
+		return message + "\n\n" + strings.Join(added, "\n")
+	}
+	return message + "\n" + strings.Join(added, "\n")
+}
+
+// trailerBlockStart returns the index of the first line of the trailing
+// trailer block, or -1 if the last paragraph is not made of trailers.
+// The subject line never counts as a trailer block.
+func trailerBlockStart(lines []string) int {
+	start := len(lines)
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start == 0 || start == len(lines) {
+		return -1
+	}
+
+	for i, line := range lines[start:] {
+		if isContinuation(line) && i > 0 {
+			continue
+		}
+		if !tokenPattern.MatchString(line) {
+			return -1
+		}
+	}
+	return start
+}
+
+// IsBlock reports whether a paragraph is made of trailers, such as the
+// "Refs: #12" and "Signed-off-by: ..." lines at the end of a message
+func IsBlock(paragraph string) bool {
+	lines := strings.Split(strings.TrimRight(paragraph, "\n"), "\n")
+	for i, line := range lines {
+		if isContinuation(line) && i > 0 {
+			continue
+		}
+		if !tokenPattern.MatchString(line) {
+			return false
+		}
+	}
+	return paragraph != ""
+}
+
+// parseBlock parses the trailers of a trailer block, joining continuation lines
+func parseBlock(lines []string) []types.Trailer {
+	var trailers []types.Trailer
+	for _, line := range lines {
+		if isContinuation(line) && len(trailers) > 0 {
+			last := &trailers[len(trailers)-1]
+			last.Value += " " + strings.TrimSpace(line)
+			continue
+		}
+		if matches := tokenPattern.FindStringSubmatch(line); matches != nil {
+			trailers = append(trailers, types.Trailer{Token: matches[1], Value: strings.TrimSpace(matches[2] + matches[3])})
+		}
+	}
+	return trailers
+}
+
+// containsTrailer reports whether trailers already has the same token and value
+func containsTrailer(trailers []types.Trailer, trailer types.Trailer) bool {
+	for _, existing := range trailers {
+		if strings.EqualFold(existing.Token, trailer.Token) && existing.Value == strings.TrimSpace(trailer.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isContinuation reports whether a line continues the previous trailer value
+func isContinuation(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// isNumeric reports whether s consists only of ASCII digits
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package trailer
+
+import (
+	"testing"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var defaultPatterns = []string{
+	`\b([A-Z][A-Z0-9]+-\d+)\b`,
+	`(?:^|/)(?:feature|feat|fix|bugfix|hotfix|issues?)/(\d+)(?:[-_/]|$)`,
+}
+
+func TestIssueKeys(t *testing.T) {
+	builder, err := NewBuilder(types.TrailerConfig{IssuePatterns: defaultPatterns})
+	require.NoError(t, err)
+
+	tests := []struct {
+		branch   string
+		expected []string
+	}{
+		{"feature/PROJ-123-add-login", []string{"PROJ-123"}},
+		{"PROJ-1-and-OPS-22", []string{"PROJ-1", "OPS-22"}},
+		{"fix/42-crash-on-start", []string{"#42"}},
+		{"feature/issues/7", []string{"#7"}},
+		{"hotfix/PROJ-9-and-12", []string{"PROJ-9"}},
+		{"123", nil},
+		{"release/1.2", nil},
+		{"release/2024", nil},
+		{"renovate/node-20", nil},
+		{"fix/UTF-8-decoding", nil},
+		{"docs/ISO-8601-dates", nil},
+		{"main", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			assert.Equal(t, tt.expected, builder.IssueKeys(tt.branch))
+		})
+	}
+}
+
+func TestNewBuilderInvalidPattern(t *testing.T) {
+	_, err := NewBuilder(types.TrailerConfig{IssuePatterns: []string{"("}})
+	assert.Error(t, err)
+}
+
+func TestBuild(t *testing.T) {
+	builder, err := NewBuilder(types.TrailerConfig{
+		IssuePatterns: defaultPatterns,
+		IssueToken:    "Refs",
+		Team:          map[string]string{"alice": "Alice Nguyen <alice@example.com>"},
+		Custom:        []string{"Reviewed-by: Team Lead <lead@example.com>"},
+	})
+	require.NoError(t, err)
+
+	trailers, err := builder.Build(Options{
+		Branch:    "feature/PROJ-7-export",
+		Closes:    true,
+		SignOff:   true,
+		Identity:  "Bob Tran <bob@example.com>",
+		CoAuthors: []string{"Alice", "Carol <carol@example.com>"},
+		Custom:    []string{"Change-Id=I123"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.Trailer{
+		{Token: "Closes", Value: "PROJ-7"},
+		{Token: "Reviewed-by", Value: "Team Lead <lead@example.com>"},
+		{Token: "Change-Id", Value: "I123"},
+		{Token: "Co-authored-by", Value: "Alice Nguyen <alice@example.com>"},
+		{Token: "Co-authored-by", Value: "Carol <carol@example.com>"},
+		{Token: "Signed-off-by", Value: "Bob Tran <bob@example.com>"},
+	}, trailers)
+
+	_, err = builder.Build(Options{CoAuthors: []string{"dave"}})
+	assert.Error(t, err)
+
+	_, err = builder.Build(Options{SignOff: true})
+	assert.Error(t, err)
+
+	_, err = builder.Build(Options{Custom: []string{"not a trailer"}})
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	trailer, err := Parse("Reviewed-by: Jane <jane@example.com>")
+	require.NoError(t, err)
+	assert.Equal(t, types.Trailer{Token: "Reviewed-by", Value: "Jane <jane@example.com>"}, trailer)
+
+	for _, invalid := range []string{"", ": value", "Token:", "Two words: value"} {
+		_, err := Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestIsBlock(t *testing.T) {
+	assert.True(t, IsBlock("Refs: #12\nSigned-off-by: Jane <jane@example.com>"))
+	assert.True(t, IsBlock("BREAKING CHANGE: tokens expire\n  after one hour"))
+	assert.False(t, IsBlock("Refs: #12\nThis is a sentence."))
+	assert.False(t, IsBlock(""))
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		trailers []types.Trailer
+		expected string
+	}{
+		{
+			name:     "creates trailer block",
+			message:  "feat: add export\n\nExports reports as CSV.\n",
+			trailers: []types.Trailer{{Token: "Refs", Value: "PROJ-7"}},
+			expected: "feat: add export\n\nExports reports as CSV.\n\nRefs: PROJ-7",
+		},
+		{
+			name:     "subject only",
+			message:  "fix: handle nil",
+			trailers: []types.Trailer{{Token: "Signed-off-by", Value: "Bob <bob@example.com>"}},
+			expected: "fix: handle nil\n\nSigned-off-by: Bob <bob@example.com>",
+		},
+		{
+			name:    "appends to existing block and skips duplicates",
+			message: "feat: add export\n\nBody.\n\nrefs: PROJ-7\nBREAKING CHANGE: output format changed\n  for all reports",
+			trailers: []types.Trailer{
+				{Token: "Refs", Value: "PROJ-7"},
+				{Token: "Refs", Value: "PROJ-8"},
+				{Token: "Refs", Value: "PROJ-8"},
+			},
+			expected: "feat: add export\n\nBody.\n\nrefs: PROJ-7\nBREAKING CHANGE: output format changed\n  for all reports\nRefs: PROJ-8",
+		},
+		{
+			name:     "conventional hash separator",
+			message:  "fix: crash\n\nCloses #42",
+			trailers: []types.Trailer{{Token: "Closes", Value: "#42"}, {Token: "Signed-off-by", Value: "Bob <bob@example.com>"}},
+			expected: "fix: crash\n\nCloses #42\nSigned-off-by: Bob <bob@example.com>",
+		},
+		{
+			name:     "bullet body is not a trailer block",
+			message:  "feat: add export\n\n- Note: something",
+			trailers: []types.Trailer{{Token: "Refs", Value: "#1"}},
+			expected: "feat: add export\n\n- Note: something\n\nRefs: #1",
+		},
+		{
+			name:     "no trailers",
+			message:  "docs: update readme",
+			expected: "docs: update readme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Merge(tt.message, tt.trailers))
+		})
+	}
+}
@@ -103,6 +103,8 @@ func selectCommitStyle() (types.CommitMessageStyle, error) {
 		Label: "📝 Chọn style commit message",
 		Items: []string{
 			"🔧 Conventional Commits (feat:, fix:, docs:, ...)",
+			"✨ Gitmoji (Emoji cho mục đích thay đổi)",
+			"🅰️ Angular (type(scope): tóm tắt chữ thường, bắt buộc body)",
 			"📋 Traditional (Mô tả truyền thống)",
 			"📖 Detailed (Chi tiết với context)",
 			"⚡ Minimal (Ngắn gọn)",
@@ -124,10 +126,14 @@ func selectCommitStyle() (types.CommitMessageStyle, error) {
 	case 0:
 		return types.StyleConventional, nil
 	case 1:
-		return types.StyleTraditional, nil
+		return types.StyleGitmoji, nil
 	case 2:
-		return types.StyleDetailed, nil
+		return types.StyleAngular, nil
 	case 3:
+		return types.StyleTraditional, nil
+	case 4:
+		return types.StyleDetailed, nil
+	case 5:
 		return types.StyleMinimal, nil
 	default:
 		return types.StyleConventional, nil
@@ -315,6 +321,12 @@ func getStyleDisplay(style types.CommitMessageStyle) string {
 	switch style {
 	case types.StyleConventional:
 		return "🔧 Conventional Commits"
+	case types.StyleGitmoji:
+		return "✨ Gitmoji"
+	case types.StyleAngular:
+		return "🅰️ Angular"
+	case types.StyleTemplate:
+		return "🧩 Template"
 	case types.StyleTraditional:
 		return "📋 Traditional"
 	case types.StyleDetailed:
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// ReviewAction is an action of the menu shown after a commit message is generated
+type ReviewAction int
+
+const (
+	ReviewAccept      ReviewAction = iota // Commit the message, or keep it for previews
+	ReviewEdit                            // Edit the message in $EDITOR
+	ReviewRegenerate                      // Generate a new message from scratch
+	ReviewShorter                         // Ask for a shorter message
+	ReviewDetailed                        // Ask for a more detailed message
+	ReviewChangeType                      // Ask for another commit type
+	ReviewInstruction                     // Send a custom instruction
+	ReviewChangeScope                     // Set or remove the scope
+	ReviewViewDiff                        // Show the diff of the changes
+	ReviewAbort                           // Stop without committing
+)
+
+// reviewActions are the menu items in display order
+var reviewActions = []struct {
+	action ReviewAction
+	label  string
+}{
+	{ReviewAccept, "✅ Chấp nhận và commit"},
+	{ReviewEdit, "📝 Chỉnh sửa trong $EDITOR"},
+	{ReviewRegenerate, "🔄 Tạo lại từ đầu"},
+	{ReviewShorter, "➖ Ngắn gọn hơn"},
+	{ReviewDetailed, "➕ Chi tiết hơn"},
+	{ReviewChangeType, "🔖 Đổi loại commit"},
+	{ReviewInstruction, "💬 Yêu cầu chỉnh sửa khác"},
+	{ReviewChangeScope, "🎯 Đổi scope"},
+	{ReviewViewDiff, "🔍 Xem diff"},
+	{ReviewAbort, "❌ Hủy"},
+}
+
+// commitTypes are the types offered when changing the commit type
+var commitTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// SelectReviewAction asks what to do with the generated message. When the
+// changes cannot be committed, accepting only keeps the message.
+func SelectReviewAction(canCommit bool) (ReviewAction, error) {
+	items := make([]string, len(reviewActions))
+	for i, item := range reviewActions {
+		items[i] = item.label
+	}
+	if !canCommit {
+		items[0] = "✅ Chấp nhận (không commit)"
+	}
+
+	prompt := promptui.Select{
+		Label: "🧐 Bạn muốn làm gì với commit message này",
+		Items: items,
+		Size:  len(items),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "▶ {{ . | cyan }}",
+			Inactive: "  {{ . | white }}",
+			Selected: "✅ {{ . | green }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if err != nil {
+		return ReviewAbort, err
+	}
+	return reviewActions[index].action, nil
+}
+
+// SelectCommitType asks for the commit type the message should use
+func SelectCommitType(current string) (string, error) {
+	cursor := 0
+	for i, commitType := range commitTypes {
+		if commitType == current {
+			cursor = i
+		}
+	}
+
+	prompt := promptui.Select{
+		Label:     "🔖 Chọn loại commit",
+		Items:     commitTypes,
+		CursorPos: cursor,
+		Size:      len(commitTypes),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}?",
+			Active:   "▶ {{ . | cyan }}",
+			Inactive: "  {{ . | white }}",
+			Selected: "✅ {{ . | green }}",
+		},
+	}
+
+	_, commitType, err := prompt.Run()
+	return commitType, err
+}
+
+// PromptScope asks for the scope of the message; an empty answer removes it
+func PromptScope(current string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     "🎯 Scope (để trống để bỏ scope)",
+		Default:   current,
+		AllowEdit: true,
+	}
+	return prompt.Run()
+}
+
+// PromptInstruction asks for a free-form instruction refining the message
+func PromptInstruction() (string, error) {
+	prompt := promptui.Prompt{
+		Label: "💬 Bạn muốn chỉnh commit message thế nào",
+		Validate: func(input string) error {
+			if len(strings.TrimSpace(input)) == 0 {
+				return fmt.Errorf("yêu cầu không được để trống")
+			}
+			return nil
+		},
+	}
+	return prompt.Run()
+}
+
+// ShowCommitMessage displays the message under review
+func ShowCommitMessage(message string, turns int) {
+	PrintSubHeader(fmt.Sprintf("Commit message (lượt hội thoại %d)", turns))
+	fmt.Printf("%s%s%s\n", ColorGreen, message, ColorReset)
+	PrintSeparator()
+}
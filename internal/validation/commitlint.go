@@ -0,0 +1,326 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// commitlintFiles are the commitlint configuration files read from the
+// repository root, in lookup order. JavaScript configs cannot be evaluated.
+var commitlintFiles = []string{".commitlintrc", ".commitlintrc.json", ".commitlintrc.yaml", ".commitlintrc.yml", "package.json"}
+
+// conventionalPreset is the rule set of @commitlint/config-conventional
+var conventionalPreset = map[string]CommitlintRule{
+	"type-enum":              {Level: 2, Applicable: "always", Value: []any{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}},
+	"type-empty":             {Level: 2, Applicable: "never"},
+	"subject-case":           {Level: 2, Applicable: "never", Value: []any{"sentence-case", "start-case", "pascal-case", "upper-case"}},
+	"subject-empty":          {Level: 2, Applicable: "never"},
+	"subject-full-stop":      {Level: 2, Applicable: "never", Value: "."},
+	"header-max-length":      {Level: 2, Applicable: "always", Value: 100},
+	"body-leading-blank":     {Level: 1, Applicable: "always"},
+	"body-max-line-length":   {Level: 2, Applicable: "always", Value: 100},
+	"footer-max-line-length": {Level: 2, Applicable: "always", Value: 100},
+}
+
+// CommitlintConfig is a commitlint configuration (https://commitlint.js.org)
+type CommitlintConfig struct {
+	Extends []string
+	Rules   map[string]CommitlintRule
+}
+
+// CommitlintRule is a commitlint rule setting, written [level, applicable, value]
+type CommitlintRule struct {
+	Level      int    // 0 disables the rule, 1 reports a warning, 2 an error
+	Applicable string // "always" or "never"
+	Value      any
+}
+
+// LoadConfig returns the validation rules for the repository in dir: the
+// defaults adjusted by the output settings, then the rules of a commitlint
+// configuration if the repository has one. The path of that configuration is
+// returned, or "" without one.
+func LoadConfig(dir string, subjectLength int, language string) (ValidationConfig, string, error) {
+	config := DefaultConfig()
+	if subjectLength > 0 {
+		config.MaxSubjectLength = subjectLength
+	}
+	if language != "" {
+		config.Language = language
+	}
+	config.MessageLanguage = language
+
+	commitlint, path, err := LoadCommitlintConfig(dir)
+	if err != nil || commitlint == nil {
+		return config, "", err
+	}
+	if err := commitlint.Apply(&config); err != nil {
+		return config, "", fmt.Errorf("invalid commitlint configuration %s: %w", path, err)
+	}
+	return config, path, nil
+}
+
+// LoadCommitlintConfig reads the first commitlint configuration found in dir.
+// It returns nil without error when there is none.
+func LoadCommitlintConfig(dir string) (*CommitlintConfig, string, error) {
+	for _, name := range commitlintFiles {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if name == "package.json" {
+			var pkg struct {
+				Commitlint yaml.Node `yaml:"commitlint"`
+			}
+			if err := yaml.Unmarshal(data, &pkg); err != nil {
+				return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			if pkg.Commitlint.IsZero() {
+				continue
+			}
+			if data, err = yaml.Marshal(&pkg.Commitlint); err != nil {
+				return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+		}
+
+		config, err := ParseCommitlintConfig(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return config, path, nil
+	}
+	return nil, "", nil
+}
+
+// ParseCommitlintConfig parses a commitlint configuration in JSON or YAML
+func ParseCommitlintConfig(data []byte) (*CommitlintConfig, error) {
+	var raw struct {
+		Extends any              `yaml:"extends"`
+		Rules   map[string][]any `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	config := &CommitlintConfig{Rules: make(map[string]CommitlintRule)}
+	switch extends := raw.Extends.(type) {
+	case string:
+		config.Extends = []string{extends}
+	case []any:
+		for _, preset := range extends {
+			config.Extends = append(config.Extends, fmt.Sprint(preset))
+		}
+	}
+
+	for name, setting := range raw.Rules {
+		if len(setting) == 0 {
+			return nil, fmt.Errorf("rule %s: missing level", name)
+		}
+		level, ok := setting[0].(int)
+		if !ok || level < 0 || level > 2 {
+			return nil, fmt.Errorf("rule %s: level must be 0, 1 or 2", name)
+		}
+		rule := CommitlintRule{Level: level, Applicable: "always"}
+		if len(setting) > 1 {
+			if rule.Applicable, ok = setting[1].(string); !ok || (rule.Applicable != "always" && rule.Applicable != "never") {
+				return nil, fmt.Errorf("rule %s: applicable must be always or never", name)
+			}
+		}
+		if len(setting) > 2 {
+			rule.Value = setting[2]
+		}
+		config.Rules[name] = rule
+	}
+	return config, nil
+}
+
+// rules returns the rules of the configuration on top of the presets it
+// extends. Only @commitlint/config-conventional is known; other presets are
+// npm packages that cannot be resolved here.
+func (c *CommitlintConfig) rules() map[string]CommitlintRule {
+	rules := make(map[string]CommitlintRule)
+	for _, preset := range c.Extends {
+		if strings.HasSuffix(preset, "config-conventional") {
+			for name, rule := range conventionalPreset {
+				rules[name] = rule
+			}
+		}
+	}
+	for name, rule := range c.Rules {
+		rules[name] = rule
+	}
+	return rules
+}
+
+// commitlintRules maps the supported commitlint rules to validator rule types
+var commitlintRules = map[string]string{
+	"type-enum":              "invalid_type",
+	"type-empty":             "conventional_format",
+	"scope-enum":             "invalid_scope",
+	"subject-case":           "subject_case",
+	"subject-empty":          "empty_subject",
+	"subject-full-stop":      "trailing_period",
+	"header-max-length":      "subject_length",
+	"body-leading-blank":     "body_separation",
+	"body-max-line-length":   "body_line_length",
+	"footer-max-line-length": "footer_line_length",
+}
+
+// Apply maps the supported commitlint rules onto a validation config: type-enum,
+// type-empty, scope-enum, subject-case, subject-empty, subject-full-stop,
+// header-max-length, body-leading-blank, body-max-line-length and
+// footer-max-line-length. Other rules are ignored, as are conditions that have
+// no validator equivalent (such as type-enum with "never").
+func (c *CommitlintConfig) Apply(config *ValidationConfig) error {
+	if config.Severities == nil {
+		config.Severities = make(map[string]string)
+	}
+
+	for name, rule := range c.rules() {
+		ruleType, supported := commitlintRules[name]
+		if !supported {
+			continue
+		}
+		if rule.Level == 0 {
+			config.Severities[ruleType] = SeverityOff
+			continue
+		}
+
+		never := rule.Applicable == "never"
+		var err error
+		switch name {
+		case "type-enum":
+			if never {
+				continue
+			}
+			config.AllowedTypes, err = stringList(rule.Value)
+		case "type-empty", "subject-empty":
+			if !never {
+				continue
+			}
+			if name == "type-empty" {
+				config.RequireConventional = true
+			}
+		case "scope-enum":
+			if never {
+				continue
+			}
+			config.AllowedScopes, err = stringList(rule.Value)
+		case "subject-case":
+			var cases []string
+			cases, err = stringList(rule.Value)
+			config.SubjectCase = CaseRule{Never: never, Cases: cases}
+			config.EnforceCapitalization = false
+		case "subject-full-stop":
+			if !never || rule.Value != "." {
+				continue
+			}
+		case "header-max-length":
+			if never {
+				continue
+			}
+			config.MaxSubjectLength, err = positiveInt(rule.Value)
+		case "body-leading-blank":
+			if never {
+				continue
+			}
+		case "body-max-line-length":
+			if never {
+				continue
+			}
+			config.MaxBodyLineLength, err = positiveInt(rule.Value)
+		case "footer-max-line-length":
+			if never {
+				continue
+			}
+			config.MaxFooterLineLength, err = positiveInt(rule.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", name, err)
+		}
+		config.Severities[ruleType] = [...]string{SeverityOff, SeverityWarning, SeverityError}[rule.Level]
+	}
+	return nil
+}
+
+// stringList converts a rule value to a list of strings
+func stringList(value any) ([]string, error) {
+	switch value := value.(type) {
+	case string:
+		return []string{value}, nil
+	case []any:
+		list := make([]string, 0, len(value))
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %v", value)
+			}
+			list = append(list, text)
+		}
+		return list, nil
+	case []string:
+		return value, nil
+	}
+	return nil, fmt.Errorf("expected a list of strings, got %v", value)
+}
+
+// positiveInt converts a rule value to a positive length
+func positiveInt(value any) (int, error) {
+	number, ok := value.(int)
+	if !ok || number <= 0 {
+		return 0, fmt.Errorf("expected a positive number, got %v", value)
+	}
+	return number, nil
+}
+
+// matchesAnyCase reports whether text is written in one of the commitlint cases
+func matchesAnyCase(text string, cases []string) bool {
+	for _, name := range cases {
+		if matchesCase(text, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesCase reports whether text is written in a commitlint case
+func matchesCase(text, name string) bool {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return false
+	}
+	first := []rune(text)[0]
+	switch name {
+	case "lower-case", "lowercase":
+		return text == strings.ToLower(text)
+	case "upper-case", "uppercase":
+		return text == strings.ToUpper(text)
+	case "sentence-case", "sentencecase":
+		return unicode.IsUpper(first)
+	case "start-case", "startcase":
+		for _, word := range words {
+			if r := []rune(word)[0]; unicode.IsLetter(r) && !unicode.IsUpper(r) {
+				return false
+			}
+		}
+		return true
+	case "pascal-case", "pascalcase":
+		return len(words) == 1 && unicode.IsUpper(first) && !strings.ContainsAny(text, "-_")
+	case "camel-case", "camelcase":
+		return len(words) == 1 && unicode.IsLower(first) && !strings.ContainsAny(text, "-_") && text != strings.ToLower(text)
+	case "kebab-case", "kebabcase":
+		return len(words) == 1 && text == strings.ToLower(text) && strings.Contains(text, "-") && !strings.Contains(text, "_")
+	case "snake-case", "snakecase":
+		return len(words) == 1 && text == strings.ToLower(text) && strings.Contains(text, "_") && !strings.Contains(text, "-")
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	config, source, err := LoadConfig(dir, 60, "en")
+	require.NoError(t, err)
+	assert.Empty(t, source)
+	assert.Equal(t, 60, config.MaxSubjectLength)
+	assert.Equal(t, "en", config.Language)
+
+	// Tabs and JSON numbers must be accepted in package.json
+	pkg := "{\n\t\"name\": \"web\",\n\t\"commitlint\": {\n\t\t\"rules\": {\"header-max-length\": [1, \"always\", 72]}\n\t}\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkg), 0644))
+	config, source, err = LoadConfig(dir, 60, "en")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "package.json"), source)
+	assert.Equal(t, 72, config.MaxSubjectLength)
+	assert.Equal(t, SeverityWarning, config.Severity("subject_length", SeverityError))
+
+	// .commitlintrc files take precedence over package.json
+	rc := `extends: ["@commitlint/config-conventional"]
+rules:
+  type-enum: [2, always, [feat, fix, docs]]
+  scope-enum: [2, always, [api, web]]
+  body-leading-blank: [0]
+  header-max-length: [0]
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".commitlintrc.yml"), []byte(rc), 0644))
+	config, source, err = LoadConfig(dir, 60, "en")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".commitlintrc.yml"), source)
+	assert.Equal(t, []string{"feat", "fix", "docs"}, config.AllowedTypes)
+	assert.Equal(t, []string{"api", "web"}, config.AllowedScopes)
+	assert.Equal(t, 100, config.MaxFooterLineLength)
+	assert.Equal(t, SeverityOff, config.Severity("subject_length", SeverityError))
+	assert.Equal(t, CaseRule{Never: true, Cases: []string{"sentence-case", "start-case", "pascal-case", "upper-case"}}, config.SubjectCase)
+	assert.False(t, config.EnforceCapitalization)
+	assert.True(t, config.RequireConventional)
+	assert.Equal(t, SeverityOff, config.Severity("body_separation", SeverityWarning))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".commitlintrc.yml"), []byte("rules:\n  header-max-length: [3, always, 72]\n"), 0644))
+	_, _, err = LoadConfig(dir, 60, "en")
+	assert.ErrorContains(t, err, "level must be 0, 1 or 2")
+}
+
+func TestCommitlintRules(t *testing.T) {
+	commitlint, err := ParseCommitlintConfig([]byte(`{
+  "rules": {
+    "type-enum": [2, "always", ["feat", "fix"]],
+    "scope-enum": [1, "always", ["api"]],
+    "subject-case": [2, "always", "lower-case"],
+    "header-max-length": [2, "always", 40],
+    "body-leading-blank": [2, "always"],
+    "footer-max-line-length": [1, "always", 30]
+  }
+}`))
+	require.NoError(t, err)
+	config := DefaultConfig()
+	config.Language = "en"
+	require.NoError(t, commitlint.Apply(&config))
+	validator := NewValidator(config)
+
+	tests := []struct {
+		name     string
+		message  string
+		expected []string
+	}{
+		{
+			name:    "valid message",
+			message: "fix(api): handle empty input\n\nRefs: #12",
+		},
+		{
+			name:     "type and scope",
+			message:  "docs(web): describe setup",
+			expected: []string{"1:1:invalid_type", "1:6:invalid_scope"},
+		},
+		{
+			name:     "subject case",
+			message:  "feat: Add OAuth login",
+			expected: []string{"1:7:subject_case"},
+		},
+		{
+			name:     "header length",
+			message:  "feat: add a login form with remember me option",
+			expected: []string{"1:41:subject_length"},
+		},
+		{
+			name:     "body leading blank is an error",
+			message:  "fix: handle empty input\nReturn early.",
+			expected: []string{"2:1:body_separation"},
+		},
+		{
+			name:     "footer line length",
+			message:  "fix: handle empty input\n\nReturn early.\n\n\nReviewed-by: Someone With A Long Name",
+			expected: []string{"6:31:footer_line_length"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var found []string
+			for _, diagnostic := range validator.Lint(tt.message) {
+				found = append(found, formatPosition(diagnostic))
+			}
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+
+	severities := map[string]string{}
+	for _, diagnostic := range validator.Lint("docs(web): Describe setup\nMore text") {
+		severities[diagnostic.Rule] = diagnostic.Severity
+	}
+	assert.Equal(t, map[string]string{
+		"invalid_type":    SeverityError,
+		"invalid_scope":   SeverityWarning,
+		"subject_case":    SeverityError,
+		"body_separation": SeverityError,
+	}, severities)
+
+	assert.Contains(t, config.PromptGuidance(), "Use only these commit types: feat, fix")
+	assert.Contains(t, config.PromptGuidance(), "Write the description in lower-case")
+}
+
+func TestMatchesCase(t *testing.T) {
+	tests := []struct {
+		text     string
+		name     string
+		expected bool
+	}{
+		{"add login", "lower-case", true},
+		{"add OAuth", "lower-case", false},
+		{"Add login", "sentence-case", true},
+		{"Add Login Form", "start-case", true},
+		{"Add login form", "start-case", false},
+		{"AddLogin", "pascal-case", true},
+		{"addLogin", "camel-case", true},
+		{"add-login", "kebab-case", true},
+		{"add_login", "snake-case", true},
+		{"ADD LOGIN", "upper-case", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, matchesCase(tt.text, tt.name), "%s %s", tt.text, tt.name)
+	}
+}
@@ -0,0 +1,189 @@
+package validation
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/nguyendkn/git-generator/internal/textwrap"
+	"github.com/nguyendkn/git-generator/internal/trailer"
+)
+
+var (
+	// headerPrefixPattern matches a conventional prefix with loose spacing and case: "Feat (api) !:"
+	headerPrefixPattern = regexp.MustCompile(`^([A-Za-z]+)\s*(\([^)]*\))?\s*(!)?\s*:\s*`)
+	// breakingFooterPattern matches the spellings of a breaking change footer token
+	breakingFooterPattern = regexp.MustCompile(`(?i)^breaking[ -]changes?\s*:\s*`)
+)
+
+// Fix applies the safe automatic fixes to a raw commit message and returns
+// the fixed message with the rule types that were fixed: lowercase type and
+// normalized breaking marker (breaking_change_marker), trailing period,
+// description case, blank line after the subject and body lines that are
+// too long. Fixes never change the meaning of the message, so the imperative
+// mood is only reported with a suggestion. Lines starting with "#" are kept,
+// since the message is committed as is; use StripComments for text edited in
+// $EDITOR.
+func (v *Validator) Fix(message string) (string, []string) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	if message == "" || IsIgnored(message) {
+		return message, nil
+	}
+
+	var fixed []string
+	lines := strings.Split(message, "\n")
+	header := lines[0]
+
+	// Breaking change footers are written "BREAKING CHANGE:" and the header
+	// marks them with "!". Only the trailer block at the end of the message
+	// holds footers; "Breaking changes: none" in the body is prose.
+	hasBreakingFooter := false
+	start := len(lines)
+	for start > 1 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	footers := slices.Clone(lines[start:])
+	for i, line := range footers {
+		if match := breakingFooterPattern.FindString(line); match != "" {
+			footers[i] = "BREAKING CHANGE: " + line[len(match):]
+			hasBreakingFooter = true
+		}
+	}
+	if hasBreakingFooter && trailer.IsBlock(strings.Join(footers, "\n")) {
+		if !slices.Equal(footers, lines[start:]) {
+			copy(lines[start:], footers)
+			fixed = append(fixed, "breaking_change_marker")
+		}
+	} else {
+		hasBreakingFooter = false
+	}
+
+	// Only headers with a known type are normalized, "Note: ..." is not a conventional header
+	if match := headerPrefixPattern.FindStringSubmatch(header); match != nil && v.isValidCommitType(strings.ToLower(match[1])) {
+		commitType := strings.ToLower(match[1])
+		if commitType != match[1] {
+			fixed = append(fixed, "type_case")
+		}
+		marker := match[3]
+		switch {
+		case hasBreakingFooter && v.config.OmitBreakingMarker:
+			marker = "" // The footer alone marks the change, as in Angular
+		case hasBreakingFooter:
+			marker = "!"
+		}
+		prefix := commitType + match[2] + marker + ": "
+		switch {
+		case marker != match[3]:
+			fixed = append(fixed, "breaking_change_marker")
+		case !strings.EqualFold(match[0], prefix):
+			fixed = append(fixed, "conventional_format") // Spacing such as "feat (api) :"
+		}
+		header = prefix + v.fixDescription(header[len(match[0]):], true, &fixed)
+	} else {
+		header = v.fixDescription(header, false, &fixed)
+	}
+	lines[0] = header
+
+	// Blank line between subject and body
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		lines = append([]string{lines[0], ""}, lines[1:]...)
+		fixed = append(fixed, "body_separation")
+	}
+
+	// Wrap paragraphs and lists with long lines, leaving code and footers alone
+	layout := splitMessage(strings.Join(lines, "\n"))
+	bodyEnd := len(lines)
+	if layout.footerLine > 0 {
+		bodyEnd = layout.footerLine - 1
+	}
+	for bodyEnd > 2 && strings.TrimSpace(lines[bodyEnd-1]) == "" {
+		bodyEnd-- // Keep the blank lines before the footers
+	}
+	if bodyEnd > 2 {
+		if body, ok := v.wrapBody(strings.Join(lines[2:bodyEnd], "\n")); ok {
+			lines = slices.Concat(lines[:2], strings.Split(body, "\n"), lines[bodyEnd:])
+			fixed = append(fixed, "body_line_length")
+		}
+	}
+
+	return strings.Join(lines, "\n"), dedupe(fixed)
+}
+
+// wrapBody rewraps the paragraphs and lists of a body that have lines
+// wider than the limit, like the formatter does. Code blocks, lines that
+// cannot be broken such as long URLs, and a final trailer block are kept.
+func (v *Validator) wrapBody(body string) (string, bool) {
+	blocks := textwrap.SplitBlocks(body)
+	paragraphs := make([]string, 0, len(blocks))
+	changed := false
+	for i, block := range blocks {
+		paragraph := strings.Join(block.Lines, "\n")
+		tooLong := slices.ContainsFunc(block.Lines, func(line string) bool {
+			return textwrap.Width(line) > v.config.MaxBodyLineLength
+		})
+		if block.Code || !tooLong || (i == len(blocks)-1 && trailer.IsBlock(paragraph)) {
+			paragraphs = append(paragraphs, paragraph)
+			continue
+		}
+
+		var wrapped string
+		if block.IsList() {
+			wrapped = textwrap.WrapList(block.Lines, v.config.MaxBodyLineLength)
+		} else {
+			wrapped = textwrap.Wrap(strings.Join(strings.Fields(paragraph), " "), v.config.MaxBodyLineLength)
+		}
+		changed = changed || wrapped != paragraph
+		paragraphs = append(paragraphs, wrapped)
+	}
+	return strings.Join(paragraphs, "\n\n"), changed
+}
+
+// fixDescription fixes the trailing period of a description, and its case
+// when it follows a conventional prefix
+func (v *Validator) fixDescription(description string, conventional bool, fixed *[]string) string {
+	description = strings.TrimSpace(description)
+
+	if trimmed := strings.TrimRight(description, ". "); trimmed != description && !strings.HasSuffix(description, "...") {
+		description = trimmed
+		*fixed = append(*fixed, "trailing_period")
+	}
+
+	if cased := v.fixCase(description); conventional && cased != description {
+		description = cased
+		*fixed = append(*fixed, "subject_case")
+	}
+
+	return description
+}
+
+// fixCase lowercases the first letter of a description when the case rule
+// forbids sentence case or requires lower case. Acronyms such as "OAuth" or
+// "API" are kept.
+func (v *Validator) fixCase(description string) string {
+	rule := v.config.SubjectCase
+	if len(rule.Cases) == 0 || rule.Never != matchesAnyCase(description, rule.Cases) {
+		return description
+	}
+
+	runes := []rune(description)
+	if len(runes) < 2 || !unicode.IsUpper(runes[0]) || unicode.IsUpper(runes[1]) {
+		return description
+	}
+	lowered := string(unicode.ToLower(runes[0])) + string(runes[1:])
+	if rule.Never == matchesAnyCase(lowered, rule.Cases) {
+		return description // Lowercasing the first letter is not enough
+	}
+	return lowered
+}
+
+// dedupe removes repeated rule types, keeping the first occurrence
+func dedupe(rules []string) []string {
+	var result []string
+	for _, rule := range rules {
+		if !slices.Contains(result, rule) {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFix(t *testing.T) {
+	config := DefaultConfig()
+	config.Language = "en"
+	config.MaxBodyLineLength = 30
+	config.SubjectCase = CaseRule{Never: true, Cases: []string{"sentence-case", "start-case", "pascal-case", "upper-case"}}
+	validator := NewValidator(config)
+
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+		fixed    []string
+	}{
+		{
+			name:     "valid message is unchanged",
+			message:  "fix(api): handle empty input\n\nReturn early.",
+			expected: "fix(api): handle empty input\n\nReturn early.",
+		},
+		{
+			name:     "type case and trailing period",
+			message:  "Fix(API): handle empty input.",
+			expected: "fix(API): handle empty input",
+			fixed:    []string{"type_case", "trailing_period"},
+		},
+		{
+			name:     "description case keeps the mood",
+			message:  "feat: Added login form",
+			expected: "feat: added login form",
+			fixed:    []string{"subject_case"},
+		},
+		{
+			name:     "words that only look like verb forms are kept",
+			message:  "fix: missing status for unused aliases",
+			expected: "fix: missing status for unused aliases",
+		},
+		{
+			name:     "acronyms keep their case",
+			message:  "feat: OAuth login",
+			expected: "feat: OAuth login",
+		},
+		{
+			name:     "breaking change marker",
+			message:  "feat(api): drop v1\n\nBreaking-Change: v1 endpoints are gone",
+			expected: "feat(api)!: drop v1\n\nBREAKING CHANGE: v1 endpoints are gone",
+			fixed:    []string{"breaking_change_marker"},
+		},
+		{
+			name:     "breaking changes in body prose",
+			message:  "feat(api): add v2 endpoints\n\nBreaking changes: none\nv1 keeps working.\n\nRefs: #12",
+			expected: "feat(api): add v2 endpoints\n\nBreaking changes: none\nv1 keeps working.\n\nRefs: #12",
+		},
+		{
+			name:     "header spacing",
+			message:  "docs (readme) : describe setup",
+			expected: "docs(readme): describe setup",
+			fixed:    []string{"conventional_format"},
+		},
+		{
+			name:     "blank line and wrapped body",
+			message:  "fix: handle empty input\nReturn early when the input is empty.\n- skip parsing entirely for blank input\n\nRefs: #12345 with a long footer line that stays",
+			expected: "fix: handle empty input\n\nReturn early when the input is\nempty.\n- skip parsing entirely for\n  blank input\n\nRefs: #12345 with a long footer line that stays",
+			fixed:    []string{"body_separation", "body_line_length"},
+		},
+		{
+			name:     "code, list continuations and trailers are not rewrapped",
+			message:  "fix: handle empty input\n\n```\nif input == \"\" { return nil, errEmptyInputNotAllowed }\n```\n\n    return parseEverythingAtOnce(input)\n\n- skip parsing\n  for blank input\n\nSigned-off-by: Jane Doe <jane.doe@example.com>",
+			expected: "fix: handle empty input\n\n```\nif input == \"\" { return nil, errEmptyInputNotAllowed }\n```\n\n    return parseEverythingAtOnce(input)\n\n- skip parsing\n  for blank input\n\nSigned-off-by: Jane Doe <jane.doe@example.com>",
+		},
+		{
+			name:     "vietnamese lines are measured by display width",
+			message:  "fix: handle empty input\n\nSửa lỗi khi đầu vào trống",
+			expected: "fix: handle empty input\n\nSửa lỗi khi đầu vào trống",
+		},
+		{
+			name:     "unknown types are not conventional headers",
+			message:  "Note: Something changed",
+			expected: "Note: Something changed",
+		},
+		{
+			name:     "lines starting with # are kept",
+			message:  "fix: handle empty input\n\n# Cause\n#123 came from a nil map.",
+			expected: "fix: handle empty input\n\n# Cause\n#123 came from a nil map.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, fixed := validator.Fix(tt.message)
+			assert.Equal(t, tt.expected, message)
+			assert.Equal(t, tt.fixed, fixed)
+		})
+	}
+}
+
+func TestFixWithoutBreakingMarker(t *testing.T) {
+	config := DefaultConfig()
+	config.OmitBreakingMarker = true
+	validator := NewValidator(config)
+
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+		fixed    []string
+	}{
+		{
+			name:     "footer is normalized without adding the marker",
+			message:  "feat(api): drop v1\n\nBreaking-Change: v1 endpoints are gone",
+			expected: "feat(api): drop v1\n\nBREAKING CHANGE: v1 endpoints are gone",
+			fixed:    []string{"breaking_change_marker"},
+		},
+		{
+			name:     "marker is removed when a footer describes the change",
+			message:  "feat(api)!: drop v1\n\nBREAKING CHANGE: v1 endpoints are gone",
+			expected: "feat(api): drop v1\n\nBREAKING CHANGE: v1 endpoints are gone",
+			fixed:    []string{"breaking_change_marker"},
+		},
+		{
+			name:     "marker is kept without a footer",
+			message:  "feat(api)!: drop v1",
+			expected: "feat(api)!: drop v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, fixed := validator.Fix(tt.message)
+			assert.Equal(t, tt.expected, message)
+			assert.Equal(t, tt.fixed, fixed)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Language holds the linguistic rules of the language a commit message is
+// written in, used by the imperative mood rule and its fix
+type Language interface {
+	// Code returns the code used by output.language, such as "en"
+	Code() string
+	// Detect reports whether a text looks written in the language
+	Detect(text string) bool
+	// Imperative rewrites a description in the imperative mood, returning it
+	// unchanged when it already is
+	Imperative(description string) string
+}
+
+// Dictionary holds the word lists of a language. Each language uses the
+// lists that apply to it.
+type Dictionary struct {
+	Irregular  map[string]string // Inflected words and their imperative form: "wrote" -> "write"
+	Exceptions []string          // Words that look inflected but are not: "need", "string", "docs"
+	Verbs      []string          // Base forms of regular verbs; other words are never rewritten
+	Markers    []string          // Tense and aspect words dropped from the start of a description
+	Endings    []string          // Words dropped from the end of a description
+}
+
+// EnglishDictionary is the dictionary of the built-in English rules
+var EnglishDictionary = Dictionary{
+	Irregular: map[string]string{
+		"made": "make", "makes": "make", "making": "make",
+		"wrote": "write", "written": "write", "rewrote": "rewrite", "rewritten": "rewrite",
+		"built": "build", "rebuilt": "rebuild", "ran": "run", "began": "begin", "brought": "bring",
+		"kept": "keep", "left": "leave", "took": "take", "gave": "give", "got": "get", "went": "go",
+		"did": "do", "does": "do", "done": "do", "has": "have", "had": "have", "having": "have",
+		"goes": "go", "sent": "send", "found": "find", "held": "hold", "threw": "throw", "thrown": "throw",
+		"chose": "choose", "chosen": "choose", "hid": "hide", "hidden": "hide", "led": "lead", "bound": "bind",
+		"embedded": "embed", "embedding": "embed", "dropped": "drop", "shipped": "ship",
+	},
+	Exceptions: []string{
+		// Base forms ending in -ed
+		"need", "embed", "feed", "seed", "speed", "shed", "proceed", "exceed", "succeed", "breed", "bleed", "shred", "weed",
+		// Base forms and nouns ending in -ing
+		"bring", "string", "ping", "swing", "sting", "spring", "thing", "nothing", "something", "everything", "during",
+		"ring", "sing", "wing", "king",
+		// Nouns and adverbs ending in -s
+		"docs", "tests", "deps", "specs", "utils", "stats", "metrics", "analytics", "settings", "series", "news",
+		"always", "perhaps", "towards", "sometimes", "unless", "thus", "yes", "this", "its", "was", "is", "as",
+	},
+	Verbs: []string{
+		"access", "adapt", "add", "address", "adjust", "align", "allow", "announce", "append", "apply", "archive",
+		"arrange", "assert", "attach", "avoid", "bump", "bundle", "cache", "call", "capture", "change", "check",
+		"clarify", "clean", "clear", "close", "collect", "combine", "commit", "compile", "complete", "compress",
+		"compute", "configure", "connect", "consolidate", "continue", "convert", "copy", "correct", "create",
+		"debounce", "declare", "decode", "decouple", "decrease", "dedupe", "define", "delay", "delete", "deploy",
+		"deprecate", "describe", "destroy", "detach", "detect", "disable", "display", "document", "downgrade",
+		"download", "drop", "emit", "emphasize", "enable", "encode", "enforce", "ensure", "escape", "evaluate",
+		"exclude", "exercise", "expand", "expect", "explore", "export", "expose", "extend", "extract", "fetch",
+		"file", "filter", "finalize", "finish", "fix", "flag", "flush", "focus", "force", "format", "generate",
+		"group", "guide", "handle", "harden", "hide", "highlight", "hook", "ignore", "implement", "import",
+		"improve", "include", "increase", "index", "initialize", "inject", "inline", "insert", "inspect",
+		"install", "integrate", "introduce", "invoke", "isolate", "iterate", "join", "label", "launch", "limit",
+		"link", "lint", "list", "load", "localize", "lock", "log", "lower", "maintain", "make", "map", "mark",
+		"match", "measure", "merge", "migrate", "minimize", "mock", "modify", "monitor", "mount", "move",
+		"mute", "nest", "normalize", "note", "notify", "omit", "open", "optimize", "order", "pack", "parse",
+		"pass", "patch", "pause", "persist", "pin", "plan", "poll", "populate", "prefer", "prefix", "prepare",
+		"preserve", "prevent", "print", "prioritize", "process", "profile", "prompt", "propagate", "protect",
+		"provide", "prune", "publish", "pull", "push", "query", "quote", "raise", "rebase", "record", "recover",
+		"redirect", "reduce", "refactor", "refine", "reformat", "refresh", "regenerate", "register", "reject",
+		"release", "reload", "relocate", "remove", "rename", "render", "reorder", "repair", "repeat", "replace",
+		"reply", "report", "request", "require", "reset", "resize", "resolve", "restart", "restore", "restrict",
+		"restructure", "retain", "retire", "retrieve", "retry", "return", "reuse", "revert", "review", "revise",
+		"reword", "rework", "rewrite", "route", "sanitize", "save", "scan", "schedule", "scope", "search",
+		"select", "separate", "serialize", "serve", "share", "ship", "shorten", "show", "sign", "simplify",
+		"size", "skip", "sort", "source", "specify", "split", "stage", "standardize", "start", "stop", "store",
+		"stream", "strip", "structure", "stub", "style", "submit", "subscribe", "support", "suppress", "swap",
+		"switch", "sync", "tag", "test", "toggle", "trace", "track", "transform", "translate", "trigger",
+		"trim", "truncate", "tune", "tweak", "type", "unblock", "unify", "uninstall", "unlock", "unmute",
+		"unpack", "unpin", "unset", "unsubscribe", "unwrap", "update", "upgrade", "upload", "use", "validate",
+		"verify", "wait", "warn", "watch", "wire", "wrap", "write",
+	},
+}
+
+// VietnameseDictionary is the dictionary of the built-in Vietnamese rules
+var VietnameseDictionary = Dictionary{
+	Markers: []string{"đã", "đang", "sẽ", "vừa mới", "vừa", "mới"},
+	Endings: []string{"xong rồi", "rồi", "xong"},
+}
+
+// languages are the known languages in detection order; English is the fallback
+var languages = []Language{NewVietnamese(VietnameseDictionary), NewEnglish(EnglishDictionary)}
+
+// RegisterLanguage adds a language, or replaces the language with the same
+// code. Registered languages are detected before the built-in ones.
+func RegisterLanguage(language Language) {
+	languages = slices.DeleteFunc(languages, func(existing Language) bool {
+		return existing.Code() == language.Code()
+	})
+	languages = append([]Language{language}, languages...)
+}
+
+// LookupLanguage returns the language with the given code
+func LookupLanguage(code string) (Language, bool) {
+	for _, language := range languages {
+		if strings.EqualFold(language.Code(), code) {
+			return language, true
+		}
+	}
+	return nil, false
+}
+
+// DetectLanguage returns the first language a text looks written in, or English
+func DetectLanguage(text string) Language {
+	for _, language := range languages {
+		if language.Detect(text) {
+			return language
+		}
+	}
+	english, _ := LookupLanguage("en")
+	return english
+}
+
+// english recognizes inflected forms of the known verbs by their suffix
+type english struct {
+	dictionary Dictionary
+}
+
+// NewEnglish creates the English rules with a dictionary
+func NewEnglish(dictionary Dictionary) Language {
+	return &english{dictionary: dictionary}
+}
+
+// Code returns "en"
+func (e *english) Code() string { return "en" }
+
+// Detect accepts any text, English is the fallback language
+func (e *english) Detect(string) bool { return true }
+
+// Imperative replaces an inflected first word (added, adding, adds) by its
+// base form, keeping its case
+func (e *english) Imperative(description string) string {
+	first, rest, _ := strings.Cut(description, " ")
+	if first == "" || isIdentifier(first) || (utf8.RuneCountInString(first) > 1 && first == strings.ToUpper(first)) {
+		return description // Identifiers and acronyms such as "getUsers" or "API"
+	}
+
+	lower := strings.ToLower(first)
+	base := e.baseForm(lower)
+	if base == lower {
+		return description
+	}
+	if r, _ := utf8.DecodeRuneInString(first); unicode.IsUpper(r) {
+		base = strings.ToUpper(base[:1]) + base[1:]
+	}
+	if rest == "" {
+		return base
+	}
+	return base + " " + rest
+}
+
+// baseForm returns the base form of a lowercase word, or the word itself
+// when it is not an inflected form of a known verb: "status", "alias" or
+// "unused" only look inflected
+func (e *english) baseForm(word string) string {
+	if base, ok := e.dictionary.Irregular[word]; ok {
+		return base
+	}
+	if slices.Contains(e.dictionary.Exceptions, word) {
+		return word
+	}
+
+	var candidates []string
+	switch {
+	case len(word) > 4 && (strings.HasSuffix(word, "ied") || strings.HasSuffix(word, "ies")):
+		candidates = []string{word[:len(word)-3] + "y"} // applied, applies
+	case strings.HasSuffix(word, "ing"):
+		candidates = stems(word[:len(word)-3])
+	case strings.HasSuffix(word, "ed"):
+		candidates = stems(word[:len(word)-2])
+	case strings.HasSuffix(word, "es"):
+		candidates = []string{word[:len(word)-1], word[:len(word)-2]} // updates, fixes
+	case strings.HasSuffix(word, "s"):
+		candidates = []string{word[:len(word)-1]}
+	}
+
+	for _, candidate := range candidates {
+		if slices.Contains(e.dictionary.Verbs, candidate) {
+			return candidate
+		}
+	}
+	return word
+}
+
+// stems returns the possible base forms of the stem left by removing -ed or
+// -ing: the stem itself (added), with a final "e" (updat -> update) and
+// without a doubled consonant (stopp -> stop)
+func stems(stem string) []string {
+	candidates := []string{stem, stem + "e"}
+	if n := len(stem); n >= 3 && stem[n-1] == stem[n-2] {
+		candidates = append(candidates, stem[:n-1])
+	}
+	return candidates
+}
+
+// vietnamese drops tense and aspect words: Vietnamese verbs are not
+// inflected, a description is imperative when it starts with the verb
+type vietnamese struct {
+	dictionary Dictionary
+}
+
+// NewVietnamese creates the Vietnamese rules with a dictionary
+func NewVietnamese(dictionary Dictionary) Language {
+	return &vietnamese{dictionary: dictionary}
+}
+
+// Code returns "vi"
+func (vn *vietnamese) Code() string { return "vi" }
+
+// Detect looks for letters only used by Vietnamese: đ, ă, ơ, ư and the
+// letters with tone marks of the Latin Extended Additional block
+func (vn *vietnamese) Detect(text string) bool {
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("đĐăĂơƠưƯ", r):
+			return true
+		case r >= 0x1EA0 && r <= 0x1EF9:
+			return true
+		}
+	}
+	return false
+}
+
+// Imperative drops tense markers such as "đã" and "đang" from the start of a
+// description and completion words such as "rồi" from its end
+func (vn *vietnamese) Imperative(description string) string {
+	result := description
+	for changed := true; changed; {
+		changed = false
+		for _, marker := range vn.dictionary.Markers {
+			if rest, ok := cutPrefixFold(result, marker+" "); ok && strings.TrimSpace(rest) != "" {
+				result, changed = strings.TrimSpace(rest), true
+			}
+		}
+	}
+	for _, ending := range vn.dictionary.Endings {
+		if rest, ok := cutSuffixFold(result, " "+ending); ok && strings.TrimSpace(rest) != "" {
+			result = strings.TrimSpace(rest)
+			break
+		}
+	}
+	if result == description {
+		return description
+	}
+
+	// Keep the case of the first letter
+	if first, _ := utf8.DecodeRuneInString(description); unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(result)
+		result = string(unicode.ToUpper(r)) + result[size:]
+	}
+	return result
+}
+
+// cutPrefixFold removes a prefix, ignoring case
+func cutPrefixFold(text, prefix string) (string, bool) {
+	if len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+		return text[len(prefix):], true
+	}
+	return text, false
+}
+
+// cutSuffixFold removes a suffix, ignoring case
+func cutSuffixFold(text, suffix string) (string, bool) {
+	if len(text) >= len(suffix) && strings.EqualFold(text[len(text)-len(suffix):], suffix) {
+		return text[:len(text)-len(suffix)], true
+	}
+	return text, false
+}
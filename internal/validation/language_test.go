@@ -0,0 +1,178 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestEnglishImperative(t *testing.T) {
+	english := NewEnglish(EnglishDictionary)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Added login", expected: "Add login"},
+		{input: "adding tests", expected: "add tests"},
+		{input: "fixes crash on exit", expected: "fix crash on exit"},
+		{input: "Updated README", expected: "Update README"},
+		{input: "updates docs", expected: "update docs"},
+		{input: "removing dead code", expected: "remove dead code"},
+		{input: "applied patch", expected: "apply patch"},
+		{input: "stopped the worker", expected: "stop the worker"},
+		{input: "validated input", expected: "validate input"},
+		{input: "normalizing paths", expected: "normalize paths"},
+		{input: "pushes tags", expected: "push tags"},
+		{input: "wrote migration", expected: "write migration"},
+		{input: "Built the image", expected: "Build the image"},
+		{input: "implemented retries", expected: "implement retries"},
+		// Already imperative, or words that only look inflected
+		{input: "add login", expected: "add login"},
+		{input: "need a token", expected: "need a token"},
+		{input: "embed assets", expected: "embed assets"},
+		{input: "string helpers", expected: "string helpers"},
+		{input: "process queue", expected: "process queue"},
+		{input: "focus input", expected: "focus input"},
+		{input: "docs for setup", expected: "docs for setup"},
+		{input: "API changes", expected: "API changes"},
+		{input: "getUsers returns nil", expected: "getUsers returns nil"},
+		// Nouns and adjectives are not verbs
+		{input: "status of jobs", expected: "status of jobs"},
+		{input: "alias for push", expected: "alias for push"},
+		{input: "existing users", expected: "existing users"},
+		{input: "unused imports", expected: "unused imports"},
+		{input: "missing config", expected: "missing config"},
+		{input: "canvas size", expected: "canvas size"},
+		{input: "kubernetes manifests", expected: "kubernetes manifests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, english.Imperative(tt.input))
+		})
+	}
+}
+
+func TestVietnameseImperative(t *testing.T) {
+	vietnamese := NewVietnamese(VietnameseDictionary)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Đã sửa lỗi đăng nhập", expected: "Sửa lỗi đăng nhập"},
+		{input: "đang thêm kiểm thử", expected: "thêm kiểm thử"},
+		{input: "Vừa mới cập nhật tài liệu", expected: "Cập nhật tài liệu"},
+		{input: "sẽ xóa mã thừa", expected: "xóa mã thừa"},
+		{input: "Thêm trang cài đặt xong rồi", expected: "Thêm trang cài đặt"},
+		{input: "Thêm tính năng mới", expected: "Thêm tính năng mới"},
+		{input: "Đã", expected: "Đã"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, vietnamese.Imperative(tt.input))
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	assert.Equal(t, "vi", DetectLanguage("feat: thêm trang đăng nhập").Code())
+	assert.Equal(t, "vi", DetectLanguage("fix: sửa lỗi").Code())
+	assert.Equal(t, "en", DetectLanguage("feat: add login page").Code())
+	assert.Equal(t, "en", DetectLanguage("").Code())
+
+	language, ok := LookupLanguage("VI")
+	require.True(t, ok)
+	assert.Equal(t, "vi", language.Code())
+	_, ok = LookupLanguage("fr")
+	assert.False(t, ok)
+}
+
+// pirate is a language registered by a test
+type pirate struct{}
+
+func (pirate) Code() string                  { return "pirate" }
+func (pirate) Detect(text string) bool       { return len(text) > 4 && text[:4] == "Arr " }
+func (pirate) Imperative(text string) string { return text }
+
+func TestRegisterLanguage(t *testing.T) {
+	saved := languages
+	defer func() { languages = saved }()
+
+	RegisterLanguage(pirate{})
+	assert.Equal(t, "pirate", DetectLanguage("Arr fixed the sails").Code())
+	assert.Equal(t, "en", DetectLanguage("fixed the sails").Code())
+
+	// A language replaces the one with the same code
+	RegisterLanguage(NewEnglish(Dictionary{Exceptions: []string{"added"}}))
+	english, _ := LookupLanguage("en")
+	assert.Equal(t, "added login", english.Imperative("added login"))
+	assert.Len(t, languages, 3)
+}
+
+func TestImperativeMoodByLanguage(t *testing.T) {
+	config := DefaultConfig()
+	config.Language = "en"
+
+	tests := []struct {
+		name            string
+		messageLanguage string
+		subject         string
+		suggestion      string
+	}{
+		{name: "detected english", subject: "feat: added login", suggestion: "feat: add login"},
+		{name: "detected vietnamese", subject: "feat: đã thêm trang đăng nhập", suggestion: "feat: thêm trang đăng nhập"},
+		{name: "vietnamese imperative", subject: "fix: sửa lỗi đăng nhập"},
+		{name: "configured language", messageLanguage: "vi", subject: "feat: added login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.MessageLanguage = tt.messageLanguage
+			result := NewValidator(config).ValidateCommitMessage(&types.CommitMessage{Subject: tt.subject})
+
+			var suggestion string
+			for _, warning := range result.Warnings {
+				if warning.Type == "imperative_mood" {
+					suggestion = warning.Suggestion
+				}
+			}
+			assert.Equal(t, tt.suggestion, suggestion)
+		})
+	}
+}
+
+func TestCapitalization(t *testing.T) {
+	config := DefaultConfig()
+	config.Language = "en"
+	validator := NewValidator(config)
+
+	tests := []struct {
+		subject    string
+		suggestion string
+	}{
+		{subject: "add login", suggestion: "Add login"},
+		{subject: "đổi tên biến", suggestion: "Đổi tên biến"},
+		{subject: "Add login"},
+		{subject: "iOS build fix"},
+		{subject: "go.mod cleanup"},
+		{subject: "feat: add login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			var suggestion string
+			for _, warning := range validator.ValidateCommitMessage(&types.CommitMessage{Subject: tt.subject}).Warnings {
+				if warning.Type == "capitalization" {
+					suggestion = warning.Suggestion
+				}
+			}
+			assert.Equal(t, tt.suggestion, suggestion)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/conventional"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// scissorsLine marks the end of the message in files prepared by git commit --verbose
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// ignoredPrefixes are subjects written by git itself, exempt from linting
+var ignoredPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+// Diagnostic is a rule violation found at a position of a commit message
+type Diagnostic struct {
+	Rule       string `json:"rule"`
+	RuleID     string `json:"rule_id"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// String formats the diagnostic as "line:column: severity: message [rule]"
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s [%s]", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// IsIgnored reports whether a message was generated by git (merges, reverts,
+// fixup! and squash! commits) and is therefore not linted
+func IsIgnored(message string) bool {
+	subject, _, _ := strings.Cut(strings.TrimLeft(message, "\n"), "\n")
+	for _, prefix := range ignoredPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMessage parses an existing commit message into a CommitMessage. The
+// subject is the first line, the footer is the trailing paragraph of
+// git trailers and the body is everything in between.
+func ParseMessage(message string) *types.CommitMessage {
+	layout := splitMessage(message)
+	header := conventional.ParseHeader(layout.header)
+	return &types.CommitMessage{
+		Type:        types.CommitType(header.Type),
+		Scope:       header.Scope,
+		Description: header.Description,
+		Subject:     layout.header,
+		Body:        layout.body,
+		Footer:      layout.footer,
+		Breaking:    conventional.Parse(message).Breaking,
+	}
+}
+
+// StripComments removes comment lines and the scissors section of a raw
+// message, such as a message edited in $EDITOR, like git commit does
+func StripComments(raw string) string {
+	message, _ := cleanMessage(raw)
+	return strings.TrimSpace(message)
+}
+
+// Lint runs every rule on a raw commit message, such as the file passed to a
+// commit-msg hook. Comment lines and the scissors section are ignored like
+// git commit does; positions refer to the lines of the raw message.
+func (v *Validator) Lint(raw string) []Diagnostic {
+	message, lineNumbers := cleanMessage(raw)
+	if IsIgnored(message) {
+		return nil
+	}
+
+	layout := splitMessage(message)
+	parsed := ParseMessage(message)
+	result := v.validate(parsed, message)
+
+	var diagnostics []Diagnostic
+	for _, validationError := range result.Errors {
+		diagnostics = append(diagnostics, Diagnostic{
+			Rule:     validationError.Type,
+			RuleID:   validationError.RuleID,
+			Severity: SeverityError,
+			Message:  validationError.Message,
+			Line:     validationError.Line,
+			Column:   validationError.Column,
+		})
+	}
+	for _, warning := range result.Warnings {
+		diagnostics = append(diagnostics, Diagnostic{
+			Rule:       warning.Type,
+			RuleID:     warning.RuleID,
+			Severity:   SeverityWarning,
+			Message:    warning.Message,
+			Line:       warning.Line,
+			Column:     warning.Column,
+			Suggestion: warning.Suggestion,
+		})
+	}
+	// Rules assume the body starts on line 3 and the footer one blank line after it
+	footerLine := footerFirstLine(parsed)
+	for i := range diagnostics {
+		line := diagnostics[i].Line
+		switch {
+		case layout.footer != "" && line >= footerLine:
+			line += layout.footerLine - footerLine
+		case line >= bodyFirstLine:
+			line += layout.bodyLine - bodyFirstLine
+		}
+		diagnostics[i].Line = originalLine(lineNumbers, line)
+		if diagnostics[i].Column == 0 {
+			diagnostics[i].Column = 1
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics
+}
+
+// messageLayout locates the parts of a commit message
+type messageLayout struct {
+	header     string
+	body       string
+	bodyLine   int // Line of the first body or footer line, 0 without one
+	footer     string
+	footerLine int // Line of the first footer, 0 without footers
+}
+
+// splitMessage splits a message into subject, body and footer, keeping the
+// body lines as written so that diagnostics point at the right line
+func splitMessage(message string) messageLayout {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	layout := messageLayout{header: strings.TrimSpace(lines[0])}
+
+	start := 1
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	end := len(lines)
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	if start >= end {
+		return layout
+	}
+	layout.bodyLine = start + 1
+
+	rest := lines[start:end]
+	if len(conventional.Parse(message).Footers) > 0 {
+		// The footers are the last paragraph
+		footerStart := 0
+		for i := len(rest) - 1; i >= 0; i-- {
+			if strings.TrimSpace(rest[i]) == "" {
+				footerStart = i + 1
+				break
+			}
+		}
+		layout.footer = strings.Join(rest[footerStart:], "\n")
+		layout.footerLine = layout.bodyLine + footerStart
+		rest = rest[:footerStart]
+	}
+	layout.body = strings.TrimRight(strings.Join(rest, "\n"), "\n ")
+	return layout
+}
+
+// cleanMessage removes comment lines and the scissors section of a raw
+// message, returning the cleaned message and the raw line number of each of
+// its lines
+func cleanMessage(raw string) (string, []int) {
+	var lines []string
+	var lineNumbers []int
+	for i, line := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(lines) == 0 && strings.TrimSpace(line) == "" {
+			continue // Leading blank lines
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+		lineNumbers = append(lineNumbers, i+1)
+	}
+	return strings.Join(lines, "\n"), lineNumbers
+}
+
+// originalLine maps a line of the cleaned message back to the raw message
+func originalLine(lineNumbers []int, line int) int {
+	if line >= 1 && line <= len(lineNumbers) {
+		return lineNumbers[line-1]
+	}
+	if len(lineNumbers) > 0 {
+		return lineNumbers[len(lineNumbers)-1] + line - len(lineNumbers)
+	}
+	return line
+}
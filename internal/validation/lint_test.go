@@ -0,0 +1,144 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestParseMessage(t *testing.T) {
+	message := ParseMessage("feat(api)!: drop v1 endpoints\n\nClients must use v2.\n\nSecond paragraph.\n\nBREAKING CHANGE: v1 is gone\nRefs: #12")
+
+	assert.Equal(t, types.CommitType("feat"), message.Type)
+	assert.Equal(t, "api", message.Scope)
+	assert.Equal(t, "drop v1 endpoints", message.Description)
+	assert.Equal(t, "feat(api)!: drop v1 endpoints", message.Subject)
+	assert.Equal(t, "Clients must use v2.\n\nSecond paragraph.", message.Body)
+	assert.Equal(t, "BREAKING CHANGE: v1 is gone\nRefs: #12", message.Footer)
+	assert.True(t, message.Breaking)
+
+	plain := ParseMessage("Update readme")
+	assert.Empty(t, plain.Type)
+	assert.Equal(t, "Update readme", plain.Description)
+	assert.Empty(t, plain.Body)
+}
+
+func TestStripComments(t *testing.T) {
+	raw := "# Please enter the commit message\n\nfeat: add login\n# 1:1: error: header too long\n\nUsers sign in with email.\n\n" +
+		scissorsLine + "\ndiff --git a/main.go b/main.go\n"
+
+	message := StripComments(raw)
+	assert.Equal(t, "feat: add login\n\nUsers sign in with email.", message)
+	assert.Equal(t, "Users sign in with email.", ParseMessage(message).Body)
+	assert.Empty(t, StripComments("# Only comments\n\n"))
+}
+
+func TestLint(t *testing.T) {
+	config := DefaultConfig()
+	config.RequireConventional = true
+	config.Language = "en"
+	validator := NewValidator(config)
+
+	tests := []struct {
+		name     string
+		message  string
+		expected []string
+	}{
+		{
+			name:    "valid message",
+			message: "fix(parser): handle empty input\n\nReturn an empty result instead of panicking.\n",
+		},
+		{
+			name:     "subject errors",
+			message:  "feature: Added a very long subject line that goes past the limit.",
+			expected: []string{"1:1:invalid_type", "1:10:imperative_mood", "1:51:subject_length", "1:65:trailing_period"},
+		},
+		{
+			name:     "not conventional",
+			message:  "Update readme",
+			expected: []string{"1:1:conventional_format"},
+		},
+		{
+			name:     "body without blank line",
+			message:  "docs: describe setup\nInstall the tool first.",
+			expected: []string{"2:1:body_separation"},
+		},
+		{
+			name:     "long body line",
+			message:  "docs: describe setup\n\nShort line.\n" + strings.Repeat("x", 80),
+			expected: []string{"4:73:body_line_length"},
+		},
+		{
+			name:     "breaking change without footer",
+			message:  "feat!: drop v1 endpoints\n\nRefs: #12",
+			expected: []string{"1:5:breaking_change_footer"},
+		},
+		{
+			name:     "comments and scissors are ignored",
+			message:  "# Please enter the commit message\n\nfix: handle empty input\n# comment\n\n" + scissorsLine + "\n" + strings.Repeat("x", 80),
+			expected: nil,
+		},
+		{
+			name:     "positions follow comment lines",
+			message:  "# header comment\nfix: handle empty input\n\n# comment\n" + strings.Repeat("y", 80),
+			expected: []string{"5:73:body_line_length"},
+		},
+		{
+			name:    "merge commits are ignored",
+			message: "Merge branch 'main' into feature",
+		},
+		{
+			name:     "empty message",
+			message:  "# only comments\n",
+			expected: []string{"1:1:empty_subject"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var found []string
+			for _, diagnostic := range validator.Lint(tt.message) {
+				found = append(found, formatPosition(diagnostic))
+			}
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+}
+
+func TestLengthsUseDisplayWidth(t *testing.T) {
+	subject := "fix(auth): sửa lỗi đăng nhập khi hết phiên" // 42 characters, 53 bytes
+
+	config := DefaultConfig()
+	config.Language = "en"
+	config.MaxSubjectLength = 45
+	config.MaxBodyLineLength = 45
+	config.EnforceCapitalization = false
+	assert.Empty(t, NewValidator(config).Lint(subject+"\n\n"+strings.TrimPrefix(subject, "fix(auth): ")))
+
+	config.MaxSubjectLength = 30
+	result := NewValidator(config).ValidateCommitMessage(&types.CommitMessage{Subject: subject})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Subject line is 42 characters, should be 30 or fewer", result.Errors[0].Message)
+	assert.Equal(t, 31, result.Errors[0].Column)
+	require.NotEmpty(t, result.Suggestions)
+	assert.Equal(t, "fix(auth): sửa lỗi đăng nhập", result.Suggestions[0].Suggested)
+}
+
+func TestDiagnosticString(t *testing.T) {
+	config := DefaultConfig()
+	config.Language = "en"
+	diagnostics := NewValidator(config).Lint("fix: added empty input check")
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "1:6: warning: Use imperative mood (e.g., 'Fix bug' not 'Fixed bug') [imperative_mood]", diagnostics[0].String())
+	assert.Equal(t, "fix: add empty input check", diagnostics[0].Suggestion)
+}
+
+// formatPosition formats the position and rule of a diagnostic
+func formatPosition(d Diagnostic) string {
+	return fmt.Sprintf("%d:%d:%s", d.Line, d.Column, d.Rule)
+}
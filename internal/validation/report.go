@@ -0,0 +1,290 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Output formats of validation reports
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// sarifSchema is the JSON schema of SARIF 2.1.0 logs
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// commitMessageFile is the artifact SARIF results of messages that were not
+// read from a file point at: the file git writes commit messages to
+const commitMessageFile = ".git/COMMIT_EDITMSG"
+
+// ParseFormat checks a report format name, accepting an empty name as text
+func ParseFormat(format string) (string, error) {
+	switch format = strings.ToLower(strings.TrimSpace(format)); format {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatSARIF:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported format %q (use text, json or sarif)", format)
+}
+
+// Report collects the diagnostics of one or more commit messages
+type Report struct {
+	Tool     string          `json:"tool"`
+	Version  string          `json:"version"`
+	Valid    bool            `json:"valid"`
+	Errors   int             `json:"errors"`
+	Warnings int             `json:"warnings"`
+	Messages []MessageReport `json:"messages"`
+}
+
+// MessageReport holds the diagnostics of a commit message
+type MessageReport struct {
+	Source      string       `json:"source"`           // Short commit hash, file path, "stdin" or "generated"
+	Commit      string       `json:"commit,omitempty"` // Full hash when the message belongs to a commit
+	Subject     string       `json:"subject"`
+	Message     string       `json:"message"`
+	Valid       bool         `json:"valid"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	Fixes       []string     `json:"fixes,omitempty"`
+}
+
+// NewReport creates an empty report for the given tool version
+func NewReport(version string) *Report {
+	return &Report{Tool: "git-generator", Version: version, Valid: true, Messages: []MessageReport{}}
+}
+
+// Add records the diagnostics of a message and returns its entry
+func (r *Report) Add(source, commit, message string, diagnostics []Diagnostic) *MessageReport {
+	cleaned, _ := cleanMessage(message)
+	cleaned = strings.TrimRight(cleaned, "\n")
+	subject, _, _ := strings.Cut(cleaned, "\n")
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+
+	entry := MessageReport{
+		Source:      source,
+		Commit:      commit,
+		Subject:     subject,
+		Message:     cleaned,
+		Valid:       true,
+		Diagnostics: diagnostics,
+	}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			r.Errors++
+			entry.Valid = false
+		} else {
+			r.Warnings++
+		}
+	}
+	r.Valid = r.Valid && entry.Valid
+	r.Messages = append(r.Messages, entry)
+	return &r.Messages[len(r.Messages)-1]
+}
+
+// Write writes the report in the given format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, r)
+	case FormatSARIF:
+		return writeJSON(w, r.sarif())
+	case FormatText, "":
+		return r.writeText(w)
+	}
+	return fmt.Errorf("unsupported format %q (use text, json or sarif)", format)
+}
+
+// writeText prints one "source:line:column: severity: message [rule]" line per
+// diagnostic, followed by its suggestion
+func (r *Report) writeText(w io.Writer) error {
+	for _, message := range r.Messages {
+		for _, diagnostic := range message.Diagnostics {
+			if _, err := fmt.Fprintf(w, "%s:%s\n", message.Source, diagnostic); err != nil {
+				return err
+			}
+			if diagnostic.Suggestion != "" {
+				if _, err := fmt.Fprintf(w, "    → %s\n", diagnostic.Suggestion); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeJSON writes an indented JSON document
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// SARIF 2.1.0 log, limited to the properties written by the report
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           *int              `json:"ruleIndex,omitempty"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// sarif converts the report to a SARIF log. Messages read from a file are
+// located in that file, other messages in .git/COMMIT_EDITMSG. Results for a
+// commit also name it as a logical location and keep its hash in the
+// partial fingerprints, so that results of different commits stay distinct.
+func (r *Report) sarif() sarifLog {
+	driver := sarifDriver{
+		Name:           r.Tool,
+		Version:        r.Version,
+		InformationURI: "https://github.com/nguyendkn/git-generator",
+		Rules:          make([]sarifRule, 0, len(Rules)),
+	}
+	ruleIndex := make(map[string]int)
+	for i, rule := range Rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Type,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.DefaultSeverity)},
+		})
+		ruleIndex[rule.ID] = i
+	}
+
+	results := []sarifResult{}
+	for _, message := range r.Messages {
+		for _, diagnostic := range message.Diagnostics {
+			id := diagnostic.RuleID
+			if id == "" {
+				id = RuleID(diagnostic.Rule)
+			}
+			text := diagnostic.Message
+			if diagnostic.Suggestion != "" {
+				text += " (" + diagnostic.Suggestion + ")"
+			}
+			result := sarifResult{
+				RuleID:  id,
+				Level:   sarifLevel(diagnostic.Severity),
+				Message: sarifMessage{Text: text},
+			}
+			index, known := ruleIndex[id]
+			if !known {
+				// Custom rules are described by the diagnostics that report them
+				index = len(driver.Rules)
+				driver.Rules = append(driver.Rules, sarifRule{
+					ID:                   id,
+					Name:                 diagnostic.Rule,
+					ShortDescription:     sarifMessage{Text: diagnostic.Message},
+					DefaultConfiguration: sarifConfiguration{Level: sarifLevel(diagnostic.Severity)},
+				})
+				ruleIndex[id] = index
+			}
+			result.RuleIndex = &index
+
+			location := sarifLocation{PhysicalLocation: &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: message.Source},
+				Region:           sarifRegion{StartLine: diagnostic.Line, StartColumn: diagnostic.Column},
+			}}
+			if message.Commit != "" || message.Source == "stdin" || message.Source == "generated" {
+				location.PhysicalLocation.ArtifactLocation.URI = commitMessageFile
+			}
+			if message.Commit != "" {
+				location.LogicalLocations = []sarifLogicalLocation{{Name: message.Commit, Kind: "commit"}}
+				result.PartialFingerprints = map[string]string{"commitSha/v1": message.Commit}
+				result.Properties = map[string]string{"commit": message.Commit, "subject": message.Subject}
+			}
+			result.Locations = []sarifLocation{location}
+			results = append(results, result)
+		}
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
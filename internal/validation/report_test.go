@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleCatalog(t *testing.T) {
+	ids := make(map[string]bool)
+	types := make(map[string]bool)
+	for _, rule := range Rules {
+		assert.Regexp(t, `^GG[1-5]\d\d$`, rule.ID)
+		assert.False(t, ids[rule.ID], "duplicate rule ID %s", rule.ID)
+		assert.False(t, types[rule.Type], "duplicate rule type %s", rule.Type)
+		assert.Contains(t, []string{SeverityError, SeverityWarning}, rule.DefaultSeverity)
+		ids[rule.ID], types[rule.Type] = true, true
+	}
+
+	// Every rule the validator reports has an ID
+	for ruleType := range commitlintRules {
+		assert.True(t, types[commitlintRules[ruleType]], "rule %s has no ID", commitlintRules[ruleType])
+	}
+
+	assert.Equal(t, "GG101", RuleID("subject_length"))
+	assert.Equal(t, "custom_rule", RuleID("custom_rule"))
+	info, ok := LookupRule("gg402")
+	require.True(t, ok)
+	assert.Equal(t, "invalid_type", info.Type)
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{input: "", expected: FormatText},
+		{input: "text", expected: FormatText},
+		{input: "JSON", expected: FormatJSON},
+		{input: " sarif ", expected: FormatSARIF},
+		{input: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			format, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func lintReport(t *testing.T) *Report {
+	t.Helper()
+	config := DefaultConfig()
+	config.RequireConventional = true
+	config.Language = "en"
+	validator := NewValidator(config)
+
+	report := NewReport("1.2.3")
+	valid := "feat: add login"
+	invalid := "# Please enter the commit message\nwip: added login."
+	report.Add("0123456", "0123456789abcdef", valid, validator.Lint(valid))
+	report.Add(".git/COMMIT_EDITMSG", "", invalid, validator.Lint(invalid))
+	return report
+}
+
+func TestReportJSON(t *testing.T) {
+	report := lintReport(t)
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.Write(&buffer, FormatJSON))
+
+	var decoded struct {
+		Valid    bool `json:"valid"`
+		Errors   int  `json:"errors"`
+		Messages []struct {
+			Source      string `json:"source"`
+			Subject     string `json:"subject"`
+			Valid       bool   `json:"valid"`
+			Diagnostics []struct {
+				Rule   string `json:"rule"`
+				RuleID string `json:"rule_id"`
+				Line   int    `json:"line"`
+			} `json:"diagnostics"`
+		} `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+
+	assert.False(t, decoded.Valid)
+	assert.Positive(t, decoded.Errors)
+	require.Len(t, decoded.Messages, 2)
+	assert.True(t, decoded.Messages[0].Valid)
+	assert.NotNil(t, decoded.Messages[0].Diagnostics, "diagnostics are an empty list, not null")
+	assert.Equal(t, "wip: added login.", decoded.Messages[1].Subject)
+
+	rules := make(map[string]string)
+	for _, diagnostic := range decoded.Messages[1].Diagnostics {
+		rules[diagnostic.Rule] = diagnostic.RuleID
+		assert.Equal(t, 2, diagnostic.Line, "positions refer to the raw message")
+	}
+	assert.Equal(t, "GG402", rules["invalid_type"])
+	assert.Equal(t, "GG103", rules["trailing_period"])
+}
+
+func TestReportSARIF(t *testing.T) {
+	report := lintReport(t)
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.Write(&buffer, FormatSARIF))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	driver := log.Runs[0].Tool.Driver
+	assert.Equal(t, "git-generator", driver.Name)
+	assert.Equal(t, "1.2.3", driver.Version)
+	assert.Len(t, driver.Rules, len(Rules))
+
+	results := log.Runs[0].Results
+	require.NotEmpty(t, results)
+	for _, result := range results {
+		require.NotNil(t, result.RuleIndex)
+		assert.Equal(t, result.RuleID, driver.Rules[*result.RuleIndex].ID)
+		require.Len(t, result.Locations, 1)
+
+		location := result.Locations[0].PhysicalLocation
+		require.NotNil(t, location)
+		assert.Equal(t, ".git/COMMIT_EDITMSG", location.ArtifactLocation.URI)
+		assert.Equal(t, 2, location.Region.StartLine)
+	}
+}
+
+func TestReportSARIFCommitLocation(t *testing.T) {
+	report := NewReport("dev")
+	report.Add("abcdef0", "abcdef0123456789", "feat: add login", []Diagnostic{
+		{Rule: "body_required", RuleID: "GG203", Severity: SeverityError, Message: "body is required", Line: 3, Column: 1},
+	})
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.Write(&buffer, FormatSARIF))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &log))
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, "error", result.Level)
+	require.NotNil(t, result.Locations[0].PhysicalLocation)
+	assert.Equal(t, ".git/COMMIT_EDITMSG", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, sarifRegion{StartLine: 3, StartColumn: 1}, result.Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, []sarifLogicalLocation{{Name: "abcdef0123456789", Kind: "commit"}}, result.Locations[0].LogicalLocations)
+	assert.Equal(t, map[string]string{"commitSha/v1": "abcdef0123456789"}, result.PartialFingerprints)
+	assert.Equal(t, "abcdef0123456789", result.Properties["commit"])
+}
+
+func TestReportText(t *testing.T) {
+	report := NewReport("dev")
+	report.Add("stdin", "", "Added login", []Diagnostic{
+		{Rule: "imperative_mood", RuleID: "GG106", Severity: SeverityWarning, Message: "use the imperative mood", Line: 1, Column: 1, Suggestion: "Add login"},
+	})
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.Write(&buffer, FormatText))
+	assert.Equal(t, "stdin:1:1: warning: use the imperative mood [imperative_mood]\n    → Add login\n", buffer.String())
+	assert.Equal(t, 1, report.Warnings)
+	assert.True(t, report.Valid)
+}
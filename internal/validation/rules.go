@@ -0,0 +1,273 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// RuleInfo describes a validation rule. IDs are stable across releases so that
+// reports can be tracked by code-scanning dashboards and editor plugins; rule
+// types may be renamed but an ID is never reused.
+type RuleInfo struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	Description     string `json:"description"`
+	DefaultSeverity string `json:"default_severity"`
+}
+
+// Rules lists the built-in rules: GG1xx check the subject, GG2xx the body,
+// GG3xx the footers, GG4xx the Conventional Commits header and GG5xx the
+// content of the whole message
+var Rules = []RuleInfo{
+	{ID: "GG101", Type: "subject_length", Description: "Subject line must not exceed the maximum length", DefaultSeverity: SeverityError},
+	{ID: "GG102", Type: "empty_subject", Description: "Subject line must not be empty", DefaultSeverity: SeverityError},
+	{ID: "GG103", Type: "trailing_period", Description: "Subject line should not end with a period", DefaultSeverity: SeverityWarning},
+	{ID: "GG104", Type: "capitalization", Description: "Subject line should start with a capital letter", DefaultSeverity: SeverityWarning},
+	{ID: "GG105", Type: "subject_case", Description: "Description must follow the configured case", DefaultSeverity: SeverityWarning},
+	{ID: "GG106", Type: "imperative_mood", Description: "Subject line should use the imperative mood", DefaultSeverity: SeverityWarning},
+	{ID: "GG107", Type: "atomic_commit", Description: "Commit should describe a single change", DefaultSeverity: SeverityWarning},
+	{ID: "GG201", Type: "body_separation", Description: "Body must be separated from the subject by a blank line", DefaultSeverity: SeverityWarning},
+	{ID: "GG202", Type: "body_line_length", Description: "Body lines must not exceed the maximum length", DefaultSeverity: SeverityWarning},
+	{ID: "GG203", Type: "body_required", Description: "Body is required, for every commit or for the configured types", DefaultSeverity: SeverityError},
+	{ID: "GG301", Type: "footer_line_length", Description: "Footer lines must not exceed the maximum length", DefaultSeverity: SeverityWarning},
+	{ID: "GG302", Type: "breaking_change_footer", Description: "Breaking changes should be described in a BREAKING CHANGE footer", DefaultSeverity: SeverityWarning},
+	{ID: "GG401", Type: "conventional_format", Description: "Header must follow the Conventional Commits format", DefaultSeverity: SeverityError},
+	{ID: "GG402", Type: "invalid_type", Description: "Commit type must be one of the allowed types", DefaultSeverity: SeverityError},
+	{ID: "GG403", Type: "invalid_scope", Description: "Scope must be one of the allowed scopes", DefaultSeverity: SeverityError},
+	{ID: "GG501", Type: "forbidden_word", Description: "Message must not contain forbidden words", DefaultSeverity: SeverityError},
+	{ID: "GG502", Type: "ticket_reference", Description: "Message must reference a ticket", DefaultSeverity: SeverityError},
+}
+
+// LookupRule returns the built-in rule with the given type or ID
+func LookupRule(rule string) (RuleInfo, bool) {
+	for _, info := range Rules {
+		if info.Type == rule || strings.EqualFold(info.ID, rule) {
+			return info, true
+		}
+	}
+	return RuleInfo{}, false
+}
+
+// RuleID returns the stable ID of a rule type, or the type itself for a rule
+// that is not in the catalog, such as a custom rule
+func RuleID(ruleType string) string {
+	if info, ok := LookupRule(ruleType); ok {
+		return info.ID
+	}
+	return ruleType
+}
+
+// Rule is a check registered with a validator
+type Rule interface {
+	// Info describes the rule; its type is also the key of its severity
+	Info() RuleInfo
+	// Check inspects a message and reports its violations on the check
+	Check(check *Check)
+}
+
+// Check is the message a rule inspects. Rules report violations on it with
+// the severity configured for the rule.
+type Check struct {
+	Message  *types.CommitMessage
+	Subject  string   // Subject line, or the description of a message without one
+	Language Language // Language the message is written in
+	Config   ValidationConfig
+
+	raw       string // Cleaned raw message when linting, empty for parsed messages
+	validator *Validator
+	rule      RuleInfo
+	severity  string
+	result    *ValidationResult
+}
+
+// Report records a violation at a position of the message. Positions follow
+// types.CommitMessage.String(): the subject on line 1 and the body from line 3.
+func (c *Check) Report(message, suggestion string, line, column int) {
+	switch c.severity {
+	case SeverityError:
+		c.result.Errors = append(c.result.Errors, ValidationError{
+			Type:     c.rule.Type,
+			RuleID:   c.rule.ID,
+			Message:  message,
+			Line:     line,
+			Column:   column,
+			Severity: SeverityError,
+		})
+	case SeverityWarning:
+		c.result.Warnings = append(c.result.Warnings, ValidationWarning{
+			Type:       c.rule.Type,
+			RuleID:     c.rule.ID,
+			Message:    message,
+			Suggestion: suggestion,
+			Line:       line,
+			Column:     column,
+		})
+	}
+}
+
+// Suggest records a suggestion to improve the message
+func (c *Check) Suggest(suggestion ValidationSuggestion) {
+	c.result.Suggestions = append(c.result.Suggestions, suggestion)
+}
+
+// Message fields a custom rule can match
+const (
+	fieldSubject = "subject"
+	fieldBody    = "body"
+	fieldFooter  = "footer"
+	fieldMessage = "message"
+)
+
+// field returns the text of a message field and the line it starts on. The
+// whole message is rendered with the subject, body and footer separated by
+// blank lines, so that positions match those of the other rules.
+func (c *Check) field(name string) (string, int) {
+	switch name {
+	case fieldSubject:
+		return c.Subject, 1
+	case fieldBody:
+		return c.Message.Body, bodyFirstLine
+	case fieldFooter:
+		return c.Message.Footer, footerFirstLine(c.Message)
+	}
+
+	text := c.Subject
+	for _, part := range []string{c.Message.Body, c.Message.Footer} {
+		if part != "" {
+			text += "\n\n" + part
+		}
+	}
+	return text, 1
+}
+
+// position returns the line and column of a byte offset in a text starting on line first
+func position(text string, offset, first int) (int, int) {
+	before := text[:offset]
+	lineStart := strings.LastIndex(before, "\n") + 1
+	return first + strings.Count(before, "\n"), utf8.RuneCountInString(before[lineStart:]) + 1
+}
+
+// patternRule is a custom rule matching a regular expression in a message field
+type patternRule struct {
+	info    RuleInfo
+	pattern *regexp.Regexp
+	field   string
+	require bool
+	message string
+}
+
+// newPatternRule compiles a custom rule declared in configuration
+func newPatternRule(rule types.CustomRule) (*patternRule, error) {
+	if rule.ID == "" {
+		return nil, fmt.Errorf("custom rule id is required")
+	}
+	pattern, err := regexp.Compile(rule.Pattern)
+	if err != nil || rule.Pattern == "" {
+		return nil, fmt.Errorf("invalid pattern for custom rule %s: %q", rule.ID, rule.Pattern)
+	}
+
+	field := rule.Field
+	switch field {
+	case "":
+		field = fieldMessage
+	case fieldSubject, fieldBody, fieldFooter, fieldMessage:
+	default:
+		return nil, fmt.Errorf("invalid field for custom rule %s: %s", rule.ID, rule.Field)
+	}
+
+	severity := rule.Severity
+	switch severity {
+	case "":
+		severity = SeverityError
+	case SeverityError, SeverityWarning:
+	default:
+		return nil, fmt.Errorf("invalid severity for custom rule %s: %s", rule.ID, rule.Severity)
+	}
+
+	description := rule.Message
+	if description == "" {
+		description = fmt.Sprintf("%s must not match %s", field, rule.Pattern)
+		if rule.Require {
+			description = fmt.Sprintf("%s must match %s", field, rule.Pattern)
+		}
+	}
+
+	return &patternRule{
+		info:    RuleInfo{ID: rule.ID, Type: rule.ID, Description: description, DefaultSeverity: severity},
+		pattern: pattern,
+		field:   field,
+		require: rule.Require,
+		message: rule.Message,
+	}, nil
+}
+
+// Info describes the custom rule
+func (r *patternRule) Info() RuleInfo { return r.info }
+
+// Check reports a match of a forbidding rule, or a missing match of a
+// requiring rule
+func (r *patternRule) Check(c *Check) {
+	text, first := c.field(r.field)
+	location := r.pattern.FindStringIndex(text)
+
+	message := r.message
+	switch {
+	case r.require && location == nil:
+		if message == "" {
+			message = c.validator.getLocalizedMessage("custom_rule_required", r.pattern)
+		}
+		c.Report(message, "", first, 1)
+	case !r.require && location != nil:
+		if message == "" {
+			message = c.validator.getLocalizedMessage("custom_rule_forbidden", r.pattern)
+		}
+		line, column := position(text, location[0], first)
+		c.Report(message, "", line, column)
+	}
+}
+
+// Registry holds the rules of a validator in the order they run
+type Registry struct {
+	rules []Rule
+}
+
+// NewRegistry creates an empty rule registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a rule. Rule types and IDs must be unique.
+func (r *Registry) Register(rule Rule) error {
+	info := rule.Info()
+	if info.Type == "" || info.ID == "" {
+		return fmt.Errorf("rule must have a type and an ID")
+	}
+	for _, existing := range r.rules {
+		if existing := existing.Info(); existing.Type == info.Type || strings.EqualFold(existing.ID, info.ID) {
+			return fmt.Errorf("rule %s (%s) is already registered", info.Type, info.ID)
+		}
+	}
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Rules returns the registered rules
+func (r *Registry) Rules() []Rule {
+	return r.rules
+}
+
+// ruleFunc is a rule implemented by a function
+type ruleFunc struct {
+	info  RuleInfo
+	check func(*Check)
+}
+
+// Info describes the rule
+func (r ruleFunc) Info() RuleInfo { return r.info }
+
+// Check runs the rule function
+func (r ruleFunc) Check(check *Check) { r.check(check) }
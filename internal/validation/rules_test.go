@@ -0,0 +1,205 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// lengthRule is a rule registered by a test
+type lengthRule struct{}
+
+func (lengthRule) Info() RuleInfo {
+	return RuleInfo{ID: "TEST1", Type: "long_body", Description: "Body must be short", DefaultSeverity: SeverityWarning}
+}
+
+func (lengthRule) Check(check *Check) {
+	if len(check.Message.Body) > 10 {
+		check.Report("body is too long", "", bodyFirstLine, 1)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	validator := NewValidator(DefaultConfig())
+	assert.Len(t, validator.Rules(), len(Rules))
+
+	require.NoError(t, validator.Register(lengthRule{}))
+	assert.ErrorContains(t, validator.Register(lengthRule{}), "already registered")
+	assert.Error(t, validator.Register(ruleFunc{info: RuleInfo{ID: "GG101", Type: "other"}}))
+
+	result := validator.ValidateCommitMessage(&types.CommitMessage{Subject: "feat: add login", Body: "A long explanation"})
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "long_body", result.Warnings[0].Type)
+	assert.Equal(t, "TEST1", result.Warnings[0].RuleID)
+}
+
+func TestRuleSeverities(t *testing.T) {
+	message := &types.CommitMessage{Subject: "feat: added login."}
+
+	config := DefaultConfig()
+	config.Language = "en"
+	result := NewValidator(config).ValidateCommitMessage(message)
+	assert.True(t, result.IsValid)
+	assert.Len(t, result.Warnings, 2)
+
+	// Severities are set by rule type or ID
+	config.Severities = map[string]string{"GG103": SeverityError, "imperative_mood": SeverityOff}
+	result = NewValidator(config).ValidateCommitMessage(message)
+	assert.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "trailing_period", result.Errors[0].Type)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestContentRules(t *testing.T) {
+	config := DefaultConfig()
+	config.Language = "en"
+	config.RequireBodyFor = []string{"feat", "fix"}
+	config.ForbiddenWords = []string{"WIP", "hack"}
+	config.TicketPattern = `[A-Z]+-\d+`
+	validator := NewValidator(config)
+
+	tests := []struct {
+		name     string
+		message  string
+		expected []string
+	}{
+		{
+			name:    "valid",
+			message: "feat: add login\n\nUsers can sign in.\n\nRefs: AUTH-12",
+		},
+		{
+			name:     "missing body for feat",
+			message:  "feat: add login\n\nRefs: AUTH-12",
+			expected: []string{"2:1: error: Commits of type 'feat' need a body explaining what changed and why [body_required]"},
+		},
+		{
+			name:    "no body needed for docs",
+			message: "docs: describe login AUTH-12",
+		},
+		{
+			name:     "forbidden words",
+			message:  "fix: remove wip flag\n\nTemporary HACK for AUTH-3.",
+			expected: []string{"1:13: error: Remove the forbidden word 'WIP' [forbidden_word]", "3:11: error: Remove the forbidden word 'hack' [forbidden_word]"},
+		},
+		{
+			name:    "words inside other words are allowed",
+			message: "docs: explain the shackle AUTH-3",
+		},
+		{
+			name:     "missing ticket",
+			message:  "docs: describe login",
+			expected: []string{"1:1: error: Reference a ticket matching [A-Z]+-\\d+ [ticket_reference]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, diagnostic := range validator.Lint(tt.message) {
+				got = append(got, diagnostic.String())
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	config := DefaultConfig()
+	config.Language = "en"
+	config.CustomRules = []types.CustomRule{
+		{ID: "no-console", Pattern: `console\.log`, Field: "body", Message: "Do not mention console.log"},
+		{ID: "signed-off", Pattern: `(?m)^Signed-off-by: `, Field: "footer", Require: true, Severity: SeverityWarning},
+		{ID: "no-emoji-subject", Pattern: `:[a-z_]+:`, Field: "subject"},
+		{ID: "broken", Pattern: `(`}, // Invalid rules are skipped
+	}
+	validator := NewValidator(config)
+	assert.Len(t, validator.Rules(), len(Rules)+3)
+
+	diagnostics := validator.Lint("fix: handle :bug: crash\n\nRemove the\nstray console.log call.\n\nRefs: #1")
+	var got []string
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.String())
+	}
+	assert.Equal(t, []string{
+		"1:13: error: Message must not match :[a-z_]+: [no-emoji-subject]",
+		"4:7: error: Do not mention console.log [no-console]",
+		"6:1: warning: Message must match (?m)^Signed-off-by:  [signed-off]",
+	}, got)
+	assert.Equal(t, "no-console", diagnostics[1].RuleID)
+
+	// Custom rules can be disabled like built-in rules
+	config.Severities = map[string]string{"no-console": SeverityOff, "signed-off": SeverityOff}
+	diagnostics = NewValidator(config).Lint("fix: handle crash\n\nRemove console.log.")
+	assert.Empty(t, diagnostics)
+}
+
+func TestApplyRules(t *testing.T) {
+	config := DefaultConfig()
+	config.AllowedScopes = []string{"api"}
+
+	err := config.ApplyRules(types.CommitRulesConfig{
+		Rules:          map[string]string{"gg106": "off", "no-todo": "warning"},
+		RequireBodyFor: []string{"feat"},
+		ForbiddenWords: []string{"WIP"},
+		TicketPattern:  `#\d+`,
+		Scopes:         []string{"web", "api"},
+		DetectedScopes: true,
+		Custom:         []types.CustomRule{{ID: "No-Todo", Pattern: "TODO"}},
+	}, []string{"internal/validation/lint.go", "cmd/git-generator/main.go", "README.md"})
+	require.NoError(t, err)
+
+	assert.Equal(t, SeverityOff, config.Severity("imperative_mood", SeverityWarning))
+	assert.Equal(t, SeverityWarning, config.Severity("No-Todo", SeverityError))
+	assert.Equal(t, []string{"feat"}, config.RequireBodyFor)
+	assert.Equal(t, `#\d+`, config.TicketPattern)
+	assert.Len(t, config.CustomRules, 1)
+
+	// Configured scopes, then the fixed and detected scopes of the scope detection rules
+	assert.Equal(t, []string{"api", "web"}, config.AllowedScopes[:2])
+	assert.Contains(t, config.AllowedScopes, "validation")
+	assert.Contains(t, config.AllowedScopes, "cmd")
+	assert.Contains(t, config.AllowedScopes, "ui")
+	assert.NotContains(t, config.AllowedScopes, "$1")
+
+	validator := NewValidator(config)
+	result := validator.ValidateCommitMessage(&types.CommitMessage{Subject: "fix(validation): handle TODO in #12"})
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "No-Todo", result.Warnings[0].Type)
+	result = validator.ValidateCommitMessage(&types.CommitMessage{Subject: "fix(billing): handle refunds in #12"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "invalid_scope", result.Errors[0].Type)
+
+	tests := []struct {
+		name  string
+		rules types.CommitRulesConfig
+		err   string
+	}{
+		{name: "invalid ticket pattern", rules: types.CommitRulesConfig{TicketPattern: "("}, err: "invalid ticket pattern"},
+		{name: "invalid custom pattern", rules: types.CommitRulesConfig{Custom: []types.CustomRule{{ID: "x", Pattern: "("}}}, err: "invalid pattern"},
+		{name: "invalid custom field", rules: types.CommitRulesConfig{Custom: []types.CustomRule{{ID: "x", Pattern: "a", Field: "title"}}}, err: "invalid field"},
+		{name: "built-in rule id", rules: types.CommitRulesConfig{Custom: []types.CustomRule{{ID: "GG101", Pattern: "a"}}}, err: "conflicts with a built-in rule"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			assert.ErrorContains(t, config.ApplyRules(tt.rules, nil), tt.err)
+		})
+	}
+}
+
+func TestPromptGuidanceRules(t *testing.T) {
+	config := DefaultConfig()
+	config.RequireBodyFor = []string{"feat", "fix"}
+	config.ForbiddenWords = []string{"WIP"}
+	config.CustomRules = []types.CustomRule{{ID: "no-todo", Pattern: "TODO", Message: "Do not leave TODOs"}}
+
+	guidance := strings.Join(config.PromptGuidance(), "\n")
+	assert.Contains(t, guidance, "for these types: feat, fix")
+	assert.Contains(t, guidance, "Never use these words: WIP")
+	assert.Contains(t, guidance, "Do not leave TODOs")
+}
@@ -2,11 +2,15 @@ package validation
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/nguyendkn/git-generator/internal/scope"
+	"github.com/nguyendkn/git-generator/internal/textwrap"
 	"github.com/nguyendkn/git-generator/pkg/types"
 )
 
@@ -21,6 +25,7 @@ type ValidationResult struct {
 // ValidationError represents a validation error
 type ValidationError struct {
 	Type     string `json:"type"`
+	RuleID   string `json:"rule_id,omitempty"`
 	Message  string `json:"message"`
 	Line     int    `json:"line,omitempty"`
 	Column   int    `json:"column,omitempty"`
@@ -30,8 +35,11 @@ type ValidationError struct {
 // ValidationWarning represents a validation warning
 type ValidationWarning struct {
 	Type       string `json:"type"`
+	RuleID     string `json:"rule_id,omitempty"`
 	Message    string `json:"message"`
 	Suggestion string `json:"suggestion,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
 }
 
 // ValidationSuggestion represents a validation suggestion
@@ -44,7 +52,8 @@ type ValidationSuggestion struct {
 
 // Validator provides commit message validation functionality
 type Validator struct {
-	config ValidationConfig
+	config   ValidationConfig
+	registry *Registry
 }
 
 // ValidationConfig holds validation configuration
@@ -55,10 +64,188 @@ type ValidationConfig struct {
 	EnforceCapitalization bool     `json:"enforce_capitalization"`
 	AllowedTypes          []string `json:"allowed_types"`
 	RequireBody           bool     `json:"require_body"`
-	Language              string   `json:"language"` // "en", "vi"
+	RequireConventional   bool     `json:"require_conventional"` // Reject headers that are not type(scope): description
+	Language              string   `json:"language"`             // Language of the diagnostics: "en", "vi"
+	MessageLanguage       string   `json:"message_language"`     // Language of commit messages for the mood rule; empty detects it
+
+	AllowedScopes       []string          `json:"allowed_scopes,omitempty"`         // Scopes allowed in conventional headers; empty allows any
+	SubjectCase         CaseRule          `json:"subject_case,omitempty"`           // Case of the description after the type
+	MaxFooterLineLength int               `json:"max_footer_line_length,omitempty"` // 0 does not limit footer lines
+	Severities          map[string]string `json:"severities,omitempty"`             // Severity per rule type or ID: error, warning or off
+
+	RequireBodyFor []string           `json:"require_body_for,omitempty"` // Commit types that need a body
+	ForbiddenWords []string           `json:"forbidden_words,omitempty"`  // Words that must not appear in a message
+	TicketPattern  string             `json:"ticket_pattern,omitempty"`   // Regex of the ticket reference every message needs
+	CustomRules    []types.CustomRule `json:"custom_rules,omitempty"`     // Regex rules declared in configuration
+
+	OmitBreakingMarker bool `json:"omit_breaking_marker,omitempty"` // Breaking changes are only described by a footer, without "!" in the header
+}
+
+// CaseRule requires (or with Never, forbids) one of the commitlint cases:
+// lower-case, upper-case, sentence-case, start-case, pascal-case, camel-case,
+// kebab-case and snake-case. A rule without cases is disabled.
+type CaseRule struct {
+	Never bool     `json:"never,omitempty"`
+	Cases []string `json:"cases,omitempty"`
+}
+
+// Rule severities; SeverityOff disables a rule
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityOff     = "off"
+)
+
+// Positions in validation results refer to the message as rendered by
+// types.CommitMessage.String(): the subject on line 1, then a blank line and
+// the body from line 3
+const bodyFirstLine = 3
+
+var (
+	// conventionalPattern matches a Conventional Commits header
+	conventionalPattern = regexp.MustCompile(`^([a-z]+)(\([^)]+\))?(!)?: .+`)
+	// conventionalPrefix matches the type(scope): prefix of a header
+	conventionalPrefix = regexp.MustCompile(`^[a-z]+(\([^)]+\))?(!)?: `)
+)
+
+// DefaultConfig returns the rules applied to generated commit messages
+func DefaultConfig() ValidationConfig {
+	return ValidationConfig{
+		MaxSubjectLength:      50,
+		MaxBodyLineLength:     72,
+		EnforceImperative:     true,
+		EnforceCapitalization: true,
+		AllowedTypes:          []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert", "security", "deps"},
+		RequireBody:           false,
+		Language:              "vi", // Default to Vietnamese
+	}
+}
+
+// Clone returns a copy of the configuration that shares no lists or maps with it
+func (c ValidationConfig) Clone() ValidationConfig {
+	c.AllowedTypes = slices.Clone(c.AllowedTypes)
+	c.AllowedScopes = slices.Clone(c.AllowedScopes)
+	c.SubjectCase.Cases = slices.Clone(c.SubjectCase.Cases)
+	c.Severities = maps.Clone(c.Severities)
+	c.RequireBodyFor = slices.Clone(c.RequireBodyFor)
+	c.ForbiddenWords = slices.Clone(c.ForbiddenWords)
+	c.CustomRules = slices.Clone(c.CustomRules)
+	return c
+}
+
+// Severity returns the configured severity of a rule, set by type or ID, or
+// its default severity
+func (c ValidationConfig) Severity(rule, defaultSeverity string) string {
+	if severity, ok := c.Severities[rule]; ok {
+		return severity
+	}
+	if severity, ok := c.Severities[RuleID(rule)]; ok {
+		return severity
+	}
+	return defaultSeverity
+}
+
+// ApplyRules applies the commit rules of the application configuration.
+// With rules.DetectedScopes, the scopes the scope detection rules assign to
+// files are allowed as well.
+func (c *ValidationConfig) ApplyRules(rules types.CommitRulesConfig, files []string) error {
+	if len(rules.Rules) > 0 && c.Severities == nil {
+		c.Severities = make(map[string]string)
+	}
+	for rule, severity := range rules.Rules {
+		// Configuration keys may have been lowercased
+		if info, ok := LookupRule(rule); ok {
+			rule = info.Type
+		}
+		for _, custom := range rules.Custom {
+			if strings.EqualFold(custom.ID, rule) {
+				rule = custom.ID
+			}
+		}
+		c.Severities[rule] = severity
+	}
+
+	c.AllowedScopes = append(c.AllowedScopes, rules.Scopes...)
+	if rules.DetectedScopes {
+		c.AllowedScopes = append(c.AllowedScopes, scope.NewDetector().Scopes(files)...)
+	}
+	c.AllowedScopes = dedupe(c.AllowedScopes)
+
+	c.RequireBodyFor = append(c.RequireBodyFor, rules.RequireBodyFor...)
+	c.ForbiddenWords = append(c.ForbiddenWords, rules.ForbiddenWords...)
+	if rules.TicketPattern != "" {
+		if _, err := regexp.Compile(rules.TicketPattern); err != nil {
+			return fmt.Errorf("invalid ticket pattern %q: %w", rules.TicketPattern, err)
+		}
+		c.TicketPattern = rules.TicketPattern
+	}
+
+	for _, custom := range rules.Custom {
+		if _, builtin := LookupRule(custom.ID); builtin {
+			return fmt.Errorf("custom rule %s conflicts with a built-in rule", custom.ID)
+		}
+		if _, err := newPatternRule(custom); err != nil {
+			return err
+		}
+		c.CustomRules = append(c.CustomRules, custom)
+	}
+	return nil
 }
 
-// NewValidator creates a new commit message validator
+// PromptGuidance describes the enabled rules as instructions for the AI prompt
+func (c ValidationConfig) PromptGuidance() []string {
+	enabled := func(rule, defaultSeverity string) bool {
+		return c.Severity(rule, defaultSeverity) != SeverityOff
+	}
+
+	var guidance []string
+	if len(c.AllowedTypes) > 0 && enabled("invalid_type", SeverityError) {
+		guidance = append(guidance, "Use only these commit types: "+strings.Join(c.AllowedTypes, ", "))
+	}
+	if len(c.AllowedScopes) > 0 && enabled("invalid_scope", SeverityError) {
+		guidance = append(guidance, "Use only these scopes, or no scope: "+strings.Join(c.AllowedScopes, ", "))
+	}
+	if c.MaxSubjectLength > 0 && enabled("subject_length", SeverityError) {
+		guidance = append(guidance, fmt.Sprintf("Keep the whole header line (type, scope and description) at most %d characters", c.MaxSubjectLength))
+	}
+	if len(c.SubjectCase.Cases) > 0 && enabled("subject_case", SeverityWarning) {
+		if c.SubjectCase.Never {
+			guidance = append(guidance, "Do not write the description in "+strings.Join(c.SubjectCase.Cases, ", "))
+		} else {
+			guidance = append(guidance, "Write the description in "+strings.Join(c.SubjectCase.Cases, " or "))
+		}
+	}
+	if enabled("trailing_period", SeverityWarning) {
+		guidance = append(guidance, "Do not end the header with a period")
+	}
+	if c.EnforceImperative && enabled("imperative_mood", SeverityWarning) {
+		guidance = append(guidance, "Use imperative mood in the description")
+	}
+	if c.RequireBody && enabled("body_required", SeverityError) {
+		guidance = append(guidance, "Always include a body explaining what changed and why")
+	}
+	if !c.RequireBody && len(c.RequireBodyFor) > 0 && enabled("body_required", SeverityError) {
+		guidance = append(guidance, "Include a body explaining what changed and why for these types: "+strings.Join(c.RequireBodyFor, ", "))
+	}
+	if len(c.ForbiddenWords) > 0 && enabled("forbidden_word", SeverityError) {
+		guidance = append(guidance, "Never use these words: "+strings.Join(c.ForbiddenWords, ", "))
+	}
+	for _, custom := range c.CustomRules {
+		if custom.Message != "" && enabled(custom.ID, SeverityError) {
+			guidance = append(guidance, custom.Message)
+		}
+	}
+	if c.MaxBodyLineLength > 0 && enabled("body_line_length", SeverityWarning) {
+		guidance = append(guidance, fmt.Sprintf("Wrap body lines at %d characters", c.MaxBodyLineLength))
+	}
+	if c.MaxFooterLineLength > 0 && enabled("footer_line_length", SeverityWarning) {
+		guidance = append(guidance, fmt.Sprintf("Keep footer lines at most %d characters", c.MaxFooterLineLength))
+	}
+	return guidance
+}
+
+// NewValidator creates a new commit message validator with the built-in
+// rules and the custom rules of the configuration
 func NewValidator(config ValidationConfig) *Validator {
 	// Set defaults if not provided
 	if config.MaxSubjectLength == 0 {
@@ -74,11 +261,66 @@ func NewValidator(config ValidationConfig) *Validator {
 		config.Language = "en"
 	}
 
-	return &Validator{config: config}
+	v := &Validator{config: config, registry: NewRegistry()}
+	checks := v.builtinChecks()
+	for _, info := range Rules {
+		v.registry.Register(ruleFunc{info: info, check: checks[info.Type]})
+	}
+	for _, custom := range config.CustomRules {
+		rule, err := newPatternRule(custom)
+		if err != nil {
+			continue // Skip invalid custom rules, ApplyRules reports them
+		}
+		v.registry.Register(rule)
+	}
+	return v
+}
+
+// Register adds a rule to the validator
+func (v *Validator) Register(rule Rule) error {
+	return v.registry.Register(rule)
+}
+
+// Rules describes the rules of the validator in the order they run
+func (v *Validator) Rules() []RuleInfo {
+	rules := make([]RuleInfo, 0, len(v.registry.Rules()))
+	for _, rule := range v.registry.Rules() {
+		rules = append(rules, rule.Info())
+	}
+	return rules
+}
+
+// builtinChecks returns the checks of the built-in rules, keyed by rule type
+func (v *Validator) builtinChecks() map[string]func(*Check) {
+	return map[string]func(*Check){
+		"subject_length":         v.checkSubjectLength,
+		"empty_subject":          v.checkEmptySubject,
+		"trailing_period":        v.checkTrailingPeriod,
+		"capitalization":         v.checkCapitalization,
+		"subject_case":           v.checkSubjectCase,
+		"imperative_mood":        v.checkImperativeMood,
+		"atomic_commit":          v.checkAtomicCommit,
+		"body_separation":        v.checkBodySeparation,
+		"body_line_length":       v.checkBodyLineLength,
+		"body_required":          v.checkBodyRequired,
+		"footer_line_length":     v.checkFooterLineLength,
+		"breaking_change_footer": v.checkBreakingChangeFooter,
+		"conventional_format":    v.checkConventionalFormat,
+		"invalid_type":           v.checkType,
+		"invalid_scope":          v.checkScope,
+		"forbidden_word":         v.checkForbiddenWords,
+		"ticket_reference":       v.checkTicketReference,
+	}
 }
 
 // ValidateCommitMessage validates a commit message against Git best practices
 func (v *Validator) ValidateCommitMessage(message *types.CommitMessage) *ValidationResult {
+	return v.validate(message, "")
+}
+
+// validate runs the enabled rules on a message. Rules about the layout of the
+// raw message, such as the blank line after the subject, only run when raw is set.
+func (v *Validator) validate(message *types.CommitMessage, raw string) *ValidationResult {
 	result := &ValidationResult{
 		IsValid:     true,
 		Errors:      []ValidationError{},
@@ -91,149 +333,200 @@ func (v *Validator) ValidateCommitMessage(message *types.CommitMessage) *Validat
 	if subject == "" {
 		subject = message.Description
 	}
-	v.validateSubjectLine(subject, result)
+	language := v.messageLanguage(strings.Join([]string{subject, message.Body}, "\n"))
 
-	// Validate body if present
-	if message.Body != "" {
-		v.validateBody(message.Body, result)
+	for _, rule := range v.registry.Rules() {
+		info := rule.Info()
+		severity := v.config.Severity(info.Type, info.DefaultSeverity)
+		if severity == SeverityOff {
+			continue
+		}
+		rule.Check(&Check{
+			Message:   message,
+			Subject:   subject,
+			Language:  language,
+			Config:    v.config,
+			raw:       raw,
+			validator: v,
+			rule:      info,
+			severity:  severity,
+			result:    result,
+		})
 	}
 
-	// Validate conventional commits format if applicable
-	v.validateConventionalCommits(message, result)
-
-	// Check for atomic commit principles
-	v.validateAtomicCommit(message, result)
-
 	// Set overall validity
 	result.IsValid = len(result.Errors) == 0
 
 	return result
 }
 
-// validateSubjectLine validates the commit message subject line
-func (v *Validator) validateSubjectLine(subject string, result *ValidationResult) {
-	// Check length
-	if len(subject) > v.config.MaxSubjectLength {
-		result.Errors = append(result.Errors, ValidationError{
-			Type:     "subject_length",
-			Message:  v.getLocalizedMessage("subject_too_long", len(subject), v.config.MaxSubjectLength),
-			Severity: "error",
-		})
+// checkSubjectLength checks the length of the subject line and suggests a shorter one
+func (v *Validator) checkSubjectLength(c *Check) {
+	subjectWidth := textwrap.Width(c.Subject)
+	if subjectWidth <= v.config.MaxSubjectLength {
+		return
+	}
+	c.Report(v.getLocalizedMessage("subject_too_long", subjectWidth, v.config.MaxSubjectLength), "", 1, overflowColumn(c.Subject, v.config.MaxSubjectLength))
+
+	// Suggest truncation
+	c.Suggest(ValidationSuggestion{
+		Type:      "subject_truncation",
+		Message:   v.getLocalizedMessage("suggest_truncation"),
+		Original:  c.Subject,
+		Suggested: textwrap.TruncateAtWord(c.Subject, v.config.MaxSubjectLength),
+	})
+}
 
-		// Suggest truncation
-		truncated := v.truncateSubject(subject, v.config.MaxSubjectLength)
-		result.Suggestions = append(result.Suggestions, ValidationSuggestion{
-			Type:      "subject_truncation",
-			Message:   v.getLocalizedMessage("suggest_truncation"),
-			Original:  subject,
-			Suggested: truncated,
-		})
+// checkEmptySubject checks that the subject line is not empty
+func (v *Validator) checkEmptySubject(c *Check) {
+	if strings.TrimSpace(c.Subject) == "" {
+		c.Report(v.getLocalizedMessage("empty_subject"), "", 1, 1)
 	}
+}
 
-	// Check for trailing period
-	if strings.HasSuffix(subject, ".") {
-		result.Warnings = append(result.Warnings, ValidationWarning{
-			Type:       "trailing_period",
-			Message:    v.getLocalizedMessage("no_trailing_period"),
-			Suggestion: strings.TrimSuffix(subject, "."),
-		})
+// checkTrailingPeriod checks that the subject line does not end with a period
+func (v *Validator) checkTrailingPeriod(c *Check) {
+	if strings.HasSuffix(c.Subject, ".") {
+		c.Report(v.getLocalizedMessage("no_trailing_period"), strings.TrimSuffix(c.Subject, "."), 1, utf8.RuneCountInString(c.Subject))
 	}
+}
 
-	// Check capitalization
-	if v.config.EnforceCapitalization && len(subject) > 0 {
-		firstChar := rune(subject[0])
-		if !unicode.IsUpper(firstChar) {
-			result.Warnings = append(result.Warnings, ValidationWarning{
-				Type:       "capitalization",
-				Message:    v.getLocalizedMessage("capitalize_first_letter"),
-				Suggestion: strings.ToUpper(string(firstChar)) + subject[1:],
-			})
-		}
+// checkCapitalization checks that a subject starts with a capital letter;
+// conventional types and descriptions are lowercase by convention, and
+// identifiers such as "iOS" keep their case
+func (v *Validator) checkCapitalization(c *Check) {
+	subject := c.Subject
+	if !v.config.EnforceCapitalization || len(subject) == 0 || conventionalPattern.MatchString(subject) {
+		return
+	}
+	first, _, _ := strings.Cut(subject, " ")
+	if isIdentifier(first) {
+		return
+	}
+	if r, size := utf8.DecodeRuneInString(subject); unicode.IsLower(r) {
+		c.Report(v.getLocalizedMessage("capitalize_first_letter"), string(unicode.ToUpper(r))+subject[size:], 1, 1)
 	}
+}
 
-	// Check imperative mood
-	if v.config.EnforceImperative {
-		if !v.isImperativeMood(subject) {
-			result.Warnings = append(result.Warnings, ValidationWarning{
-				Type:       "imperative_mood",
-				Message:    v.getLocalizedMessage("use_imperative_mood"),
-				Suggestion: v.suggestImperativeMood(subject),
-			})
+// checkSubjectCase checks the case of the description of a conventional header
+func (v *Validator) checkSubjectCase(c *Check) {
+	subject := c.Subject
+	if len(v.config.SubjectCase.Cases) == 0 || !conventionalPattern.MatchString(subject) {
+		return
+	}
+	description := strings.TrimPrefix(subject, conventionalPrefix.FindString(subject))
+	if description != "" && v.config.SubjectCase.Never == matchesAnyCase(description, v.config.SubjectCase.Cases) {
+		key := "subject_case_always"
+		if v.config.SubjectCase.Never {
+			key = "subject_case_never"
 		}
+		c.Report(v.getLocalizedMessage(key, strings.Join(v.config.SubjectCase.Cases, ", ")), "", 1, descriptionColumn(subject))
 	}
+}
 
-	// Check for empty subject
-	if strings.TrimSpace(subject) == "" {
-		result.Errors = append(result.Errors, ValidationError{
-			Type:     "empty_subject",
-			Message:  v.getLocalizedMessage("empty_subject"),
-			Severity: "error",
-		})
+// checkImperativeMood checks that the subject uses the imperative mood
+func (v *Validator) checkImperativeMood(c *Check) {
+	if !v.config.EnforceImperative {
+		return
+	}
+	if suggestion := suggestImperativeMood(c.Subject, c.Language); suggestion != c.Subject {
+		c.Report(v.getLocalizedMessage("use_imperative_mood"), suggestion, 1, descriptionColumn(c.Subject))
 	}
 }
 
-// validateBody validates the commit message body
-func (v *Validator) validateBody(body string, result *ValidationResult) {
-	lines := strings.Split(body, "\n")
+// checkBodySeparation checks the blank line between the subject and the body
+// of a raw message
+func (v *Validator) checkBodySeparation(c *Check) {
+	if c.raw != "" && splitMessage(c.raw).bodyLine == 2 {
+		c.Report(v.getLocalizedMessage("body_needs_blank_line"), "", 2, 1)
+	}
+}
 
-	for i, line := range lines {
+// checkBodyLineLength checks the length of the body lines
+func (v *Validator) checkBodyLineLength(c *Check) {
+	for i, line := range strings.Split(c.Message.Body, "\n") {
 		// Skip empty lines
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
-		// Check line length
-		if len(line) > v.config.MaxBodyLineLength {
-			result.Warnings = append(result.Warnings, ValidationWarning{
-				Type:    "body_line_length",
-				Message: v.getLocalizedMessage("body_line_too_long", i+1, len(line), v.config.MaxBodyLineLength),
-			})
+		if lineWidth := textwrap.Width(line); lineWidth > v.config.MaxBodyLineLength {
+			c.Report(v.getLocalizedMessage("body_line_too_long", i+1, lineWidth, v.config.MaxBodyLineLength), "", bodyFirstLine+i, overflowColumn(line, v.config.MaxBodyLineLength))
 		}
 	}
+}
 
-	// Check for proper separation between subject and body
-	if !strings.HasPrefix(body, "\n") {
-		result.Warnings = append(result.Warnings, ValidationWarning{
-			Type:    "body_separation",
-			Message: v.getLocalizedMessage("body_needs_blank_line"),
-		})
+// checkBodyRequired checks that a message has a body when every commit or
+// its commit type requires one
+func (v *Validator) checkBodyRequired(c *Check) {
+	if c.Message.Body != "" {
+		return
+	}
+	switch commitType := string(c.Message.Type); {
+	case v.config.RequireBody:
+		c.Report(v.getLocalizedMessage("body_required"), "", bodyFirstLine-1, 1)
+	case commitType != "" && slices.Contains(v.config.RequireBodyFor, commitType):
+		c.Report(v.getLocalizedMessage("body_required_for_type", commitType), "", bodyFirstLine-1, 1)
 	}
 }
 
-// validateConventionalCommits validates conventional commits format
-func (v *Validator) validateConventionalCommits(message *types.CommitMessage, result *ValidationResult) {
-	// Check if subject follows conventional commits pattern
-	conventionalPattern := regexp.MustCompile(`^([a-z]+)(\([^)]+\))?(!)?: .+`)
-
-	if !conventionalPattern.MatchString(message.Subject) {
-		// This might not be a conventional commit, which is okay
+// checkFooterLineLength checks the length of footer lines
+func (v *Validator) checkFooterLineLength(c *Check) {
+	if c.Message.Footer == "" || v.config.MaxFooterLineLength <= 0 {
 		return
 	}
+	first := footerFirstLine(c.Message)
+	for i, line := range strings.Split(c.Message.Footer, "\n") {
+		if lineWidth := textwrap.Width(line); lineWidth > v.config.MaxFooterLineLength {
+			c.Report(v.getLocalizedMessage("footer_line_too_long", lineWidth, v.config.MaxFooterLineLength), "", first+i, overflowColumn(line, v.config.MaxFooterLineLength))
+		}
+	}
+}
+
+// footerFirstLine returns the line of the first footer, after the body and a blank line
+func footerFirstLine(message *types.CommitMessage) int {
+	if message.Body == "" {
+		return bodyFirstLine
+	}
+	return bodyFirstLine + strings.Count(message.Body, "\n") + 2
+}
 
-	// Extract type from subject
-	typePattern := regexp.MustCompile(`^([a-z]+)`)
-	matches := typePattern.FindStringSubmatch(message.Subject)
+// checkConventionalFormat checks that the subject follows the Conventional
+// Commits format when it is required
+func (v *Validator) checkConventionalFormat(c *Check) {
+	subject := c.Message.Subject
+	if v.config.RequireConventional && strings.TrimSpace(subject) != "" && !conventionalPattern.MatchString(subject) {
+		c.Report(v.getLocalizedMessage("not_conventional"), "", 1, 1)
+	}
+}
 
-	if len(matches) > 1 {
-		commitType := matches[1]
+// checkType checks the type of a conventional header
+func (v *Validator) checkType(c *Check) {
+	matches := conventionalPattern.FindStringSubmatch(c.Message.Subject)
+	if matches != nil && !v.isValidCommitType(matches[1]) {
+		c.Report(v.getLocalizedMessage("invalid_commit_type", matches[1], strings.Join(v.config.AllowedTypes, ", ")), "", 1, 1)
+	}
+}
 
-		// Validate type
-		if !v.isValidCommitType(commitType) {
-			result.Errors = append(result.Errors, ValidationError{
-				Type:     "invalid_type",
-				Message:  v.getLocalizedMessage("invalid_commit_type", commitType),
-				Severity: "error",
-			})
+// checkScope checks the scope of a conventional header against the allowed scopes
+func (v *Validator) checkScope(c *Check) {
+	matches := conventionalPattern.FindStringSubmatch(c.Message.Subject)
+	if matches == nil || len(v.config.AllowedScopes) == 0 {
+		return
+	}
+	if scope := strings.Trim(matches[2], "()"); scope != "" {
+		for _, part := range strings.Split(scope, ",") {
+			if !slices.Contains(v.config.AllowedScopes, strings.TrimSpace(part)) {
+				c.Report(v.getLocalizedMessage("invalid_scope", strings.TrimSpace(part), strings.Join(v.config.AllowedScopes, ", ")), "", 1, len(matches[1])+2)
+				break
+			}
 		}
 	}
-
-	// Check for breaking changes
-	v.validateBreakingChanges(message, result)
 }
 
-// validateAtomicCommit checks if the commit represents a single logical unit
-func (v *Validator) validateAtomicCommit(message *types.CommitMessage, result *ValidationResult) {
-	subject := strings.ToLower(message.Subject)
+// checkAtomicCommit checks if the commit represents a single logical unit
+func (v *Validator) checkAtomicCommit(c *Check) {
+	subject := strings.ToLower(c.Message.Subject)
 
 	// Look for indicators of multiple changes
 	multipleChangeIndicators := []string{
@@ -242,102 +535,81 @@ func (v *Validator) validateAtomicCommit(message *types.CommitMessage, result *V
 	}
 
 	for _, indicator := range multipleChangeIndicators {
-		if strings.Contains(subject, indicator) {
-			result.Warnings = append(result.Warnings, ValidationWarning{
-				Type:    "atomic_commit",
-				Message: v.getLocalizedMessage("consider_atomic_commits"),
-			})
+		if index := strings.Index(subject, indicator); index >= 0 {
+			c.Report(v.getLocalizedMessage("consider_atomic_commits"), "", 1, utf8.RuneCountInString(subject[:index])+1)
 			break
 		}
 	}
 }
 
-// Helper methods
-
-// isImperativeMood checks if the subject line uses imperative mood
-func (v *Validator) isImperativeMood(subject string) bool {
-	// Remove conventional commit prefix if present
-	cleanSubject := regexp.MustCompile(`^[a-z]+(\([^)]+\))?(!)?: `).ReplaceAllString(subject, "")
-
-	// Common non-imperative patterns
-	nonImperativePatterns := []string{
-		`^(added|adding)`,
-		`^(fixed|fixing)`,
-		`^(updated|updating)`,
-		`^(removed|removing)`,
-		`^(changed|changing)`,
-		`^(implemented|implementing)`,
-		`^(refactored|refactoring)`,
-	}
-
-	cleanSubjectLower := strings.ToLower(cleanSubject)
-	for _, pattern := range nonImperativePatterns {
-		if matched, _ := regexp.MatchString(pattern, cleanSubjectLower); matched {
-			return false
+// checkForbiddenWords reports each forbidden word found in the message
+func (v *Validator) checkForbiddenWords(c *Check) {
+	text, first := c.field(fieldMessage)
+	for _, word := range v.config.ForbiddenWords {
+		pattern, err := regexp.Compile(`(?i)(^|\W)` + regexp.QuoteMeta(word) + `($|\W)`)
+		if err != nil || word == "" {
+			continue
 		}
-	}
-
-	return true
-}
-
-// suggestImperativeMood suggests imperative mood alternatives
-func (v *Validator) suggestImperativeMood(subject string) string {
-	replacements := map[string]string{
-		"added":        "add",
-		"adding":       "add",
-		"fixed":        "fix",
-		"fixing":       "fix",
-		"updated":      "update",
-		"updating":     "update",
-		"removed":      "remove",
-		"removing":     "remove",
-		"changed":      "change",
-		"changing":     "change",
-		"implemented":  "implement",
-		"implementing": "implement",
-		"refactored":   "refactor",
-		"refactoring":  "refactor",
-	}
-
-	words := strings.Fields(subject)
-	if len(words) > 0 {
-		firstWord := strings.ToLower(words[0])
-		if replacement, exists := replacements[firstWord]; exists {
-			words[0] = strings.ToUpper(replacement[:1]) + replacement[1:]
-			return strings.Join(words, " ")
+		if location := pattern.FindStringSubmatchIndex(text); location != nil {
+			line, column := position(text, location[3], first)
+			c.Report(v.getLocalizedMessage("forbidden_word", word), "", line, column)
 		}
 	}
-
-	return subject
 }
 
-// truncateSubject truncates subject to specified length while preserving word boundaries
-func (v *Validator) truncateSubject(subject string, maxLength int) string {
-	if len(subject) <= maxLength {
-		return subject
+// checkTicketReference checks that the message references a ticket
+func (v *Validator) checkTicketReference(c *Check) {
+	if v.config.TicketPattern == "" {
+		return
+	}
+	pattern, err := regexp.Compile(v.config.TicketPattern)
+	if err != nil {
+		return // Reported when the configuration is loaded
 	}
+	if text, _ := c.field(fieldMessage); !pattern.MatchString(text) {
+		c.Report(v.getLocalizedMessage("ticket_required", v.config.TicketPattern), "", 1, 1)
+	}
+}
 
-	// Try to truncate at word boundary
-	words := strings.Fields(subject)
-	result := ""
+// Helper methods
 
-	for _, word := range words {
-		if len(result)+len(word)+1 <= maxLength {
-			if result != "" {
-				result += " "
-			}
-			result += word
-		} else {
-			break
-		}
+// descriptionColumn returns the column where the description starts, after a conventional prefix
+func descriptionColumn(subject string) int {
+	prefix := conventionalPrefix.FindString(subject)
+	return utf8.RuneCountInString(prefix) + 1
+}
+
+// suggestImperativeMood rewrites the description of a subject in the
+// imperative mood of a language, keeping the conventional prefix
+func suggestImperativeMood(subject string, language Language) string {
+	prefix := conventionalPrefix.FindString(subject)
+	return prefix + language.Imperative(strings.TrimPrefix(subject, prefix))
+}
+
+// messageLanguage returns the language of a commit message: the configured
+// message language, or the language detected from the text
+func (v *Validator) messageLanguage(text string) Language {
+	if language, ok := LookupLanguage(v.config.MessageLanguage); ok {
+		return language
 	}
+	return DetectLanguage(text)
+}
 
-	if result == "" && len(words) > 0 {
-		// If even the first word is too long, truncate it
-		result = subject[:maxLength-3] + "..."
+// isIdentifier reports whether a word is code or a name whose case must be
+// kept, such as "gRPC", "iOS", "go.mod" or "user_id"
+func isIdentifier(word string) bool {
+	for i, r := range word {
+		if (i > 0 && unicode.IsUpper(r)) || unicode.IsDigit(r) || strings.ContainsRune("_./`-()", r) {
+			return true
+		}
 	}
+	return false
+}
 
-	return result
+// overflowColumn returns the column of the first character of a line past a
+// display width
+func overflowColumn(line string, maxWidth int) int {
+	return utf8.RuneCountInString(textwrap.Truncate(line, maxWidth)) + 1
 }
 
 // isValidCommitType checks if the commit type is valid
@@ -345,16 +617,18 @@ func (v *Validator) isValidCommitType(commitType string) bool {
 	return slices.Contains(v.config.AllowedTypes, commitType)
 }
 
-// validateBreakingChanges validates breaking change indicators
-func (v *Validator) validateBreakingChanges(message *types.CommitMessage, result *ValidationResult) {
-	hasBreakingIndicator := strings.Contains(message.Subject, "!")
-	hasBreakingFooter := strings.Contains(message.Body, "BREAKING CHANGE:")
+// checkBreakingChangeFooter checks that a header marked with "!" has a
+// BREAKING CHANGE footer
+func (v *Validator) checkBreakingChangeFooter(c *Check) {
+	message := c.Message
+	if !conventionalPattern.MatchString(message.Subject) {
+		return
+	}
+	indicator := strings.Index(message.Subject, "!")
+	hasBreakingFooter := strings.Contains(message.Body, "BREAKING CHANGE:") || strings.Contains(message.Footer, "BREAKING CHANGE:")
 
-	if hasBreakingIndicator && !hasBreakingFooter {
-		result.Warnings = append(result.Warnings, ValidationWarning{
-			Type:    "breaking_change_footer",
-			Message: v.getLocalizedMessage("breaking_change_needs_footer"),
-		})
+	if indicator >= 0 && !hasBreakingFooter {
+		c.Report(v.getLocalizedMessage("breaking_change_needs_footer"), "", 1, utf8.RuneCountInString(message.Subject[:indicator])+1)
 	}
 }
 
@@ -396,9 +670,20 @@ func (v *Validator) getEnglishMessages() map[string]string {
 		"empty_subject":                "Subject line cannot be empty",
 		"body_line_too_long":           "Line %d is %d characters, should be %d or fewer",
 		"body_needs_blank_line":        "Add blank line between subject and body",
-		"invalid_commit_type":          "Invalid commit type '%s'. Use: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert",
+		"invalid_commit_type":          "Invalid commit type '%s'. Use: %s",
+		"invalid_scope":                "Scope '%s' is not allowed. Use: %s",
+		"subject_case_always":          "Description must be in one of these cases: %s",
+		"subject_case_never":           "Description must not be in these cases: %s",
+		"footer_line_too_long":         "Footer line is %d characters, should be %d or fewer",
 		"consider_atomic_commits":      "Consider splitting into multiple atomic commits",
 		"breaking_change_needs_footer": "Breaking changes should include 'BREAKING CHANGE:' footer",
+		"body_required":                "Add a body explaining what changed and why",
+		"not_conventional":             "Subject must follow Conventional Commits: type(scope): description",
+		"body_required_for_type":       "Commits of type '%s' need a body explaining what changed and why",
+		"forbidden_word":               "Remove the forbidden word '%s'",
+		"ticket_required":              "Reference a ticket matching %s",
+		"custom_rule_forbidden":        "Message must not match %s",
+		"custom_rule_required":         "Message must match %s",
 	}
 }
 
@@ -413,8 +698,19 @@ func (v *Validator) getVietnameseMessages() map[string]string {
 		"empty_subject":                "Dòng tiêu đề không được để trống",
 		"body_line_too_long":           "Dòng %d có %d ký tự, nên có %d ký tự hoặc ít hơn",
 		"body_needs_blank_line":        "Thêm dòng trống giữa tiêu đề và nội dung",
-		"invalid_commit_type":          "Loại commit '%s' không hợp lệ. Sử dụng: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert",
+		"invalid_commit_type":          "Loại commit '%s' không hợp lệ. Sử dụng: %s",
+		"invalid_scope":                "Scope '%s' không được phép. Sử dụng: %s",
+		"subject_case_always":          "Mô tả phải viết theo một trong các kiểu chữ: %s",
+		"subject_case_never":           "Mô tả không được viết theo các kiểu chữ: %s",
+		"footer_line_too_long":         "Dòng footer có %d ký tự, nên có %d ký tự hoặc ít hơn",
 		"consider_atomic_commits":      "Nên chia thành nhiều commit nguyên tử",
 		"breaking_change_needs_footer": "Thay đổi phá vỡ nên bao gồm footer 'BREAKING CHANGE:'",
+		"body_required":                "Thêm nội dung giải thích thay đổi gì và tại sao",
+		"not_conventional":             "Tiêu đề phải theo Conventional Commits: type(scope): mô tả",
+		"body_required_for_type":       "Commit loại '%s' cần có nội dung giải thích thay đổi gì và tại sao",
+		"forbidden_word":               "Bỏ từ bị cấm '%s'",
+		"ticket_required":              "Tham chiếu tới ticket khớp với %s",
+		"custom_rule_forbidden":        "Message không được khớp với %s",
+		"custom_rule_required":         "Message phải khớp với %s",
 	}
 }
@@ -0,0 +1,78 @@
+package version
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/nguyendkn/git-generator/internal/apicompat"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// CheckAPICompatibility compares the exported Go API of the module being
+// versioned at baseTag with HEAD. No changes are returned when there is no
+// base tag or the module has no go.mod at both revisions.
+func (s *Service) CheckAPICompatibility(baseTag string) ([]types.APIChange, error) {
+	if baseTag == "" {
+		return nil, nil
+	}
+	dir := "."
+	if s.module != nil {
+		dir = s.module.Path
+	}
+
+	var apis []apicompat.API
+	for _, rev := range []string{baseTag, "HEAD"} {
+		files, err := s.gitService.ListFilesAt(rev)
+		if err != nil {
+			return nil, err
+		}
+		if !containsFile(files, path.Join(dir, "go.mod")) {
+			return nil, nil
+		}
+		contents, err := s.gitService.ReadFilesAt(rev, apicompat.SourceFiles(files, dir))
+		if err != nil {
+			return nil, err
+		}
+		api, err := apicompat.Load(files, dir, func(file string) ([]byte, error) {
+			return contents[file], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Go API at %s: %w", rev, err)
+		}
+		apis = append(apis, api)
+	}
+	return apicompat.Compare(apis[0], apis[1]), nil
+}
+
+// ApplyAPIChanges records the API changes in an analysis. Incompatible changes
+// are breaking changes and require a major bump, or a minor bump while the
+// current version is 0.x, whatever the commit messages say.
+func ApplyAPIChanges(analysis *types.VersionAnalysis, changes []types.APIChange, current *types.SemanticVersion) {
+	analysis.APIChanges = changes
+	incompatible := apicompat.Incompatible(changes)
+	if len(incompatible) == 0 {
+		return
+	}
+
+	for _, change := range incompatible {
+		analysis.BreakingChanges = append(analysis.BreakingChanges, "API: "+change.String())
+	}
+	required := types.VersionBumpMajor
+	if current != nil && current.Major == 0 {
+		required = types.VersionBumpMinor
+	}
+	if bumpRank[required] > bumpRank[analysis.RecommendedBump] {
+		analysis.RecommendedBump = required
+	}
+	analysis.Reasoning += fmt.Sprintf("; %d incompatible Go API change(s) require a %s bump", len(incompatible), required)
+}
+
+// containsFile reports whether files contains name
+func containsFile(files []string, name string) bool {
+	for _, file := range files {
+		if file == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestCheckAPICompatibility(t *testing.T) {
+	fs := memfs.New()
+	repo, err := gogit.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(files map[string]string, message string) {
+		for path, content := range files {
+			file, err := fs.Create(path)
+			require.NoError(t, err)
+			_, err = file.Write([]byte(content))
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+			_, err = worktree.Add(path)
+			require.NoError(t, err)
+		}
+		_, err := worktree.Commit(message, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+
+	commit(map[string]string{
+		"go.mod":           "module example.com/app\n",
+		"client/client.go": "package client\n\nfunc Dial(addr string) error { return nil }\n",
+	}, "feat: add client")
+	head, err := repo.Head()
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.2.0", head.Hash(), nil)
+	require.NoError(t, err)
+
+	commit(map[string]string{
+		"client/client.go": "package client\n\nfunc Dial(addr string, timeout int) error { return nil }\n\nfunc Close() {}\n",
+	}, "fix: support dial timeouts")
+
+	service := NewService(git.NewServiceWithBackend(git.NewGoGitBackendFromRepository(repo)), nil, nil, types.Config{})
+	changes, err := service.CheckAPICompatibility("v1.2.0")
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, types.APIChangeAdded, changes[0].Kind)
+	assert.Equal(t, "client: changed func Dial", changes[1].String())
+	assert.Equal(t, "func Dial(string, int) error", changes[1].After)
+
+	noTag, err := service.CheckAPICompatibility("")
+	require.NoError(t, err)
+	assert.Empty(t, noTag)
+
+	// A JavaScript module has no Go API to compare
+	service.SetModule(types.Module{Name: "web", Path: "web", TagPrefix: "web@"})
+	jsChanges, err := service.CheckAPICompatibility("v1.2.0")
+	require.NoError(t, err)
+	assert.Empty(t, jsChanges)
+}
+
+func TestApplyAPIChanges(t *testing.T) {
+	changes := []types.APIChange{
+		{Package: "client", Name: "func Close", Kind: types.APIChangeAdded, Compatible: true},
+		{Package: "client", Name: "func Dial", Kind: types.APIChangeChanged},
+	}
+
+	analysis := &types.VersionAnalysis{RecommendedBump: types.VersionBumpPatch, Reasoning: "1 of 1 commits follow Conventional Commits: 1 fix(es)"}
+	ApplyAPIChanges(analysis, changes, &types.SemanticVersion{Major: 1, Minor: 2})
+	assert.Equal(t, types.VersionBumpMajor, analysis.RecommendedBump)
+	assert.Equal(t, []string{"API: client: changed func Dial"}, analysis.BreakingChanges)
+	assert.Equal(t, changes, analysis.APIChanges)
+	assert.Contains(t, analysis.Reasoning, "1 incompatible Go API change(s) require a major bump")
+
+	preStable := &types.VersionAnalysis{RecommendedBump: types.VersionBumpPatch}
+	ApplyAPIChanges(preStable, changes, &types.SemanticVersion{Minor: 4})
+	assert.Equal(t, types.VersionBumpMinor, preStable.RecommendedBump)
+
+	compatible := &types.VersionAnalysis{RecommendedBump: types.VersionBumpPatch}
+	ApplyAPIChanges(compatible, changes[:1], &types.SemanticVersion{Major: 1})
+	assert.Equal(t, types.VersionBumpPatch, compatible.RecommendedBump)
+	assert.Empty(t, compatible.BreakingChanges)
+}
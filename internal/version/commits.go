@@ -0,0 +1,134 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/internal/conventional"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Analysis sources reported in types.VersionAnalysis.Source
+const (
+	SourceConventional = "conventional"
+	SourceAI           = "ai"
+)
+
+// bumpRank orders bump types so the largest required bump wins
+var bumpRank = map[types.VersionBumpType]int{
+	types.VersionBumpNone:  0,
+	types.VersionBumpPatch: 1,
+	types.VersionBumpMinor: 2,
+	types.VersionBumpMajor: 3,
+}
+
+// AnalyzeCommits determines the version bump from conventional commits, newest
+// first as returned by git log: breaking changes require a major bump, feat a
+// minor bump and fix or perf a patch bump. Other types and non-conventional
+// commits do not trigger a release.
+//
+// While the current version is 0.x, breaking changes bump the minor version
+// instead, as the public API is not considered stable; 1.0.0 has to be
+// released explicitly.
+func AnalyzeCommits(commits []*types.CommitInfo, current *types.SemanticVersion) *types.VersionAnalysis {
+	analysis := &types.VersionAnalysis{
+		RecommendedBump: types.VersionBumpNone,
+		Source:          SourceConventional,
+	}
+
+	conventionalCount := 0
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		parsed := conventional.Parse(commit.Subject + "\n\n" + commit.Body)
+		if !parsed.IsConventional {
+			continue
+		}
+		conventionalCount++
+
+		bump, reason := commitBump(parsed)
+		switch {
+		case parsed.Breaking:
+			analysis.BreakingChanges = append(analysis.BreakingChanges, parsed.BreakingChanges()...)
+		case parsed.Type == "feat":
+			analysis.NewFeatures = append(analysis.NewFeatures, parsed.Header)
+		case parsed.Type == "fix" || parsed.Type == "perf":
+			analysis.BugFixes = append(analysis.BugFixes, parsed.Header)
+		case parsed.Type == "docs":
+			analysis.Documentation = append(analysis.Documentation, parsed.Header)
+		case parsed.Type == "build" && (parsed.Scope == "deps" || parsed.Scope == "dependencies"):
+			analysis.Dependencies = append(analysis.Dependencies, parsed.Header)
+		}
+
+		if bump == types.VersionBumpNone {
+			continue
+		}
+		analysis.Commits = append(analysis.Commits, types.VersionBumpCommit{
+			Hash:    commit.Hash,
+			Subject: commit.Subject,
+			Bump:    bump,
+			Reason:  reason,
+		})
+		if bumpRank[bump] > bumpRank[analysis.RecommendedBump] {
+			analysis.RecommendedBump = bump
+		}
+	}
+
+	if len(commits) > 0 {
+		analysis.Confidence = float64(conventionalCount) / float64(len(commits))
+	}
+
+	preStable := current != nil && current.Major == 0
+	if preStable && analysis.RecommendedBump == types.VersionBumpMajor {
+		analysis.RecommendedBump = types.VersionBumpMinor
+	}
+
+	analysis.Reasoning = describeAnalysis(analysis, len(commits), conventionalCount, preStable)
+	return analysis
+}
+
+// commitBump returns the bump a single conventional commit requires and why
+func commitBump(commit *conventional.Commit) (types.VersionBumpType, string) {
+	switch {
+	case commit.Breaking:
+		for _, footer := range commit.Footers {
+			if footer.IsBreakingChange() {
+				return types.VersionBumpMajor, conventional.BreakingChangeToken
+			}
+		}
+		return types.VersionBumpMajor, commit.Type + "!"
+	case commit.Type == "feat":
+		return types.VersionBumpMinor, commit.Type
+	case commit.Type == "fix" || commit.Type == "perf":
+		return types.VersionBumpPatch, commit.Type
+	}
+	return types.VersionBumpNone, ""
+}
+
+// describeAnalysis summarizes how the recommendation was reached
+func describeAnalysis(analysis *types.VersionAnalysis, total, conventionalCount int, preStable bool) string {
+	if total == 0 {
+		return "No commits since the last release"
+	}
+
+	var parts []string
+	if n := len(analysis.BreakingChanges); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d breaking", n))
+	}
+	if n := len(analysis.NewFeatures); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d feature(s)", n))
+	}
+	if n := len(analysis.BugFixes); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d fix(es)", n))
+	}
+
+	reasoning := fmt.Sprintf("%d of %d commits follow Conventional Commits", conventionalCount, total)
+	if len(parts) > 0 {
+		reasoning += ": " + strings.Join(parts, ", ")
+	} else {
+		reasoning += "; none require a release"
+	}
+	if preStable && len(analysis.BreakingChanges) > 0 {
+		reasoning += "; breaking changes bump the minor version while the major version is 0"
+	}
+	return reasoning
+}
@@ -0,0 +1,118 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestAnalyzeCommits(t *testing.T) {
+	stable := &types.SemanticVersion{Major: 1, Minor: 2, Patch: 3}
+	preStable := &types.SemanticVersion{Minor: 4}
+
+	tests := []struct {
+		name     string
+		commits  []*types.CommitInfo
+		current  *types.SemanticVersion
+		expected types.VersionBumpType
+		drivers  int
+	}{
+		{
+			name:     "no commits",
+			current:  stable,
+			expected: types.VersionBumpNone,
+		},
+		{
+			name: "only chores and docs",
+			commits: []*types.CommitInfo{
+				{Hash: "a", Subject: "docs: update readme"},
+				{Hash: "b", Subject: "chore: bump tooling"},
+				{Hash: "c", Subject: "random change"},
+			},
+			current:  stable,
+			expected: types.VersionBumpNone,
+		},
+		{
+			name: "fix and perf",
+			commits: []*types.CommitInfo{
+				{Hash: "a", Subject: "perf: cache diff results"},
+				{Hash: "b", Subject: "fix(git): handle empty repo"},
+			},
+			current:  stable,
+			expected: types.VersionBumpPatch,
+			drivers:  2,
+		},
+		{
+			name: "feature wins over fix",
+			commits: []*types.CommitInfo{
+				{Hash: "a", Subject: "fix: typo"},
+				{Hash: "b", Subject: "feat: add tag command"},
+			},
+			current:  stable,
+			expected: types.VersionBumpMinor,
+			drivers:  2,
+		},
+		{
+			name: "breaking header",
+			commits: []*types.CommitInfo{
+				{Hash: "a", Subject: "feat(api)!: drop v1 endpoints"},
+			},
+			current:  stable,
+			expected: types.VersionBumpMajor,
+			drivers:  1,
+		},
+		{
+			name: "breaking footer",
+			commits: []*types.CommitInfo{
+				{Hash: "a", Subject: "refactor: rename config keys", Body: "BREAKING CHANGE: ai.model is now ai.name"},
+			},
+			current:  stable,
+			expected: types.VersionBumpMajor,
+			drivers:  1,
+		},
+		{
+			name: "breaking while 0.x bumps minor",
+			commits: []*types.CommitInfo{
+				{Hash: "a", Subject: "feat!: new output format"},
+			},
+			current:  preStable,
+			expected: types.VersionBumpMinor,
+			drivers:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := AnalyzeCommits(tt.commits, tt.current)
+			assert.Equal(t, tt.expected, analysis.RecommendedBump)
+			assert.Len(t, analysis.Commits, tt.drivers)
+			assert.Equal(t, SourceConventional, analysis.Source)
+			assert.NotEmpty(t, analysis.Reasoning)
+		})
+	}
+}
+
+func TestAnalyzeCommitsDetails(t *testing.T) {
+	commits := []*types.CommitInfo{
+		{Hash: "c3", Subject: "build(deps): bump cobra", Body: ""},
+		{Hash: "b2", Subject: "fix: handle nil", Body: "BREAKING CHANGE: nil input now errors"},
+		{Hash: "a1", Subject: "feat: add export"},
+		{Hash: "z0", Subject: "wip"},
+	}
+
+	analysis := AnalyzeCommits(commits, &types.SemanticVersion{Major: 2})
+
+	assert.Equal(t, types.VersionBumpMajor, analysis.RecommendedBump)
+	assert.Equal(t, 0.75, analysis.Confidence)
+	assert.Equal(t, []string{"nil input now errors"}, analysis.BreakingChanges)
+	assert.Equal(t, []string{"feat: add export"}, analysis.NewFeatures)
+	assert.Equal(t, []string{"build(deps): bump cobra"}, analysis.Dependencies)
+
+	// Oldest first, only commits that require a release
+	assert.Equal(t, []types.VersionBumpCommit{
+		{Hash: "a1", Subject: "feat: add export", Bump: types.VersionBumpMinor, Reason: "feat"},
+		{Hash: "b2", Subject: "fix: handle nil", Bump: types.VersionBumpMajor, Reason: "BREAKING CHANGE"},
+	}, analysis.Commits)
+}
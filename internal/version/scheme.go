@@ -0,0 +1,207 @@
+package version
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Supported versioning schemes for the versioning.scheme configuration option
+const (
+	SchemeSemVer = "semver" // MAJOR.MINOR.PATCH driven by the kind of changes
+	SchemeCalVer = "calver" // Date-based versions such as YYYY.0M.MICRO
+)
+
+// Scheme parses versions from tags and calculates the next version to release
+type Scheme interface {
+	// Parse parses a version from a tag name without its prefix
+	Parse(version string) (*types.SemanticVersion, error)
+	// Next returns the version released after current
+	Next(current *types.SemanticVersion, analysis *types.VersionAnalysis, options types.TaggingOptions) (*types.SemanticVersion, error)
+}
+
+// NewScheme creates the versioning scheme selected in the configuration;
+// now is the clock used by calendar versioning
+func NewScheme(config types.VersioningConfig, now func() time.Time) (Scheme, error) {
+	switch config.Scheme {
+	case "", SchemeSemVer:
+		return semverScheme{}, nil
+	case SchemeCalVer:
+		layout, err := types.ParseCalendarLayout(config.CalVerFormat)
+		if err != nil {
+			return nil, err
+		}
+		return calverScheme{layout: layout, now: now}, nil
+	}
+	return nil, fmt.Errorf("unknown versioning scheme: %s (must be one of: %s, %s)", config.Scheme, SchemeSemVer, SchemeCalVer)
+}
+
+// semverScheme implements Semantic Versioning 2.0
+type semverScheme struct{}
+
+// Parse implements Scheme
+func (semverScheme) Parse(version string) (*types.SemanticVersion, error) {
+	return types.ParseSemanticVersion(version)
+}
+
+// Next implements Scheme.
+//
+// From a release, the core version is bumped and a requested pre-release
+// starts at .1 (1.2.0 + minor + beta -> 1.3.0-beta.1). From a pre-release, the
+// core version is only bumped when it does not cover the required bump yet:
+// the same pre-release is incremented (1.3.0-beta.1 -> 1.3.0-beta.2), a later
+// one starts at .1 (1.3.0-beta.2 -> 1.3.0-rc.1) and no pre-release releases
+// the core version (1.3.0-rc.1 -> 1.3.0). With Promote, a pre-release becomes
+// its release regardless of the bump.
+func (semverScheme) Next(currentVersion *types.SemanticVersion, analysis *types.VersionAnalysis, options types.TaggingOptions) (*types.SemanticVersion, error) {
+	nextVersion := &types.SemanticVersion{
+		Major: currentVersion.Major,
+		Minor: currentVersion.Minor,
+		Patch: currentVersion.Patch,
+		Build: options.Build,
+	}
+
+	if options.Promote {
+		return promote(currentVersion, nextVersion, options)
+	}
+
+	// Determine bump type
+	bumpType := analysis.RecommendedBump
+	if options.ForceBump != "" {
+		bumpType = options.ForceBump
+	}
+
+	// Continue the current pre-release line when its core version already covers the bump
+	if currentVersion.IsPreRelease() && coreCovers(currentVersion, bumpType) {
+		if continuePreRelease(currentVersion, nextVersion, options) {
+			return nextVersion, nil
+		}
+	}
+
+	// The next version must be higher than the current one
+	if bumpType == types.VersionBumpNone && (currentVersion.IsPreRelease() || options.PreRelease != "") {
+		bumpType = types.VersionBumpPatch
+	}
+
+	// Apply version bump
+	switch bumpType {
+	case types.VersionBumpMajor:
+		nextVersion.Major++
+		nextVersion.Minor = 0
+		nextVersion.Patch = 0
+	case types.VersionBumpMinor:
+		nextVersion.Minor++
+		nextVersion.Patch = 0
+	case types.VersionBumpPatch:
+		nextVersion.Patch++
+	}
+
+	// Handle pre-release
+	if options.PreRelease != "" {
+		nextVersion.PreRelease = options.PreRelease
+		nextVersion.PreNumber = 1 // Start with .1 for pre-releases
+	}
+
+	nextVersion.Raw = nextVersion.String()
+	return nextVersion, nil
+}
+
+// coreCovers reports whether the core version of a pre-release already includes
+// a bump: 2.0.0-rc.1 covers a major bump, 2.1.0-rc.1 only a minor one
+func coreCovers(version *types.SemanticVersion, bump types.VersionBumpType) bool {
+	switch bump {
+	case types.VersionBumpMajor:
+		return version.Minor == 0 && version.Patch == 0
+	case types.VersionBumpMinor:
+		return version.Patch == 0
+	}
+	return true
+}
+
+// calverScheme implements calendar versioning: the date segments come from
+// the release date and MICRO counts the releases within the same period
+type calverScheme struct {
+	layout types.CalendarLayout
+	now    func() time.Time
+}
+
+// Parse implements Scheme
+func (c calverScheme) Parse(version string) (*types.SemanticVersion, error) {
+	return c.layout.Parse(version)
+}
+
+// Next implements Scheme.
+//
+// The version of the current period is released, with MICRO incremented when
+// the period already has a release (2024.05.0 -> 2024.05.1) and reset in a
+// new period (2024.05.1 -> 2024.06.0). The kind of changes does not affect
+// the version. Pre-releases and promotion work as with SemVer: a pre-release
+// of the current period is continued (2024.05.0-rc.1 -> 2024.05.0-rc.2) or
+// released (2024.05.0-rc.2 -> 2024.05.0).
+func (c calverScheme) Next(currentVersion *types.SemanticVersion, analysis *types.VersionAnalysis, options types.TaggingOptions) (*types.SemanticVersion, error) {
+	period := c.layout.Version(c.now(), 0)
+	period.Build = options.Build
+
+	if options.Promote {
+		return promote(currentVersion, period, options)
+	}
+
+	nextVersion := period
+	if c.layout.SamePeriod(currentVersion, period) {
+		nextVersion.Major, nextVersion.Minor, nextVersion.Patch = currentVersion.Major, currentVersion.Minor, currentVersion.Patch
+		if currentVersion.IsPreRelease() && continuePreRelease(currentVersion, nextVersion, options) {
+			return nextVersion, nil
+		}
+		micro := c.layout.Micro(nextVersion)
+		if micro == nil {
+			return nil, fmt.Errorf("version %s is already released in this period; add MICRO to the CalVer format %s to release more often", currentVersion.String(), c.layout)
+		}
+		*micro++
+	}
+
+	if options.PreRelease != "" {
+		nextVersion.PreRelease = options.PreRelease
+		nextVersion.PreNumber = 1
+	}
+	if currentVersion.Layout == c.layout.String() && nextVersion.Compare(currentVersion) <= 0 {
+		return nil, fmt.Errorf("next version %s is not newer than %s; check the system clock", nextVersion.String(), currentVersion.String())
+	}
+
+	nextVersion.Raw = nextVersion.String()
+	return nextVersion, nil
+}
+
+// promote releases the pre-release current as next, its core version
+func promote(current, next *types.SemanticVersion, options types.TaggingOptions) (*types.SemanticVersion, error) {
+	if !current.IsPreRelease() {
+		return nil, fmt.Errorf("cannot promote %s: not a pre-release", current.String())
+	}
+	if options.PreRelease != "" {
+		return nil, fmt.Errorf("cannot promote %s to another pre-release", current.String())
+	}
+	next.Major, next.Minor, next.Patch = current.Major, current.Minor, current.Patch
+	next.Raw = next.String()
+	return next, nil
+}
+
+// continuePreRelease sets next, which has the core version of the pre-release
+// current, to the following pre-release or to the release of the core version.
+// It returns false when that would not be newer than current (rc -> alpha),
+// leaving next without a pre-release so a new core version can be chosen.
+func continuePreRelease(current, next *types.SemanticVersion, options types.TaggingOptions) bool {
+	if options.PreRelease != "" {
+		next.PreRelease = options.PreRelease
+		next.PreNumber = 1
+		if options.PreRelease == current.PreRelease {
+			next.PreNumber = current.PreNumber + 1
+		}
+	}
+	if next.Compare(current) > 0 {
+		next.Raw = next.String()
+		return true
+	}
+	next.PreRelease = ""
+	next.PreNumber = 0
+	return false
+}
@@ -0,0 +1,64 @@
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestCalVerScheme(t *testing.T) {
+	today := time.Date(2024, time.May, 20, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   string
+		current  string
+		options  types.TaggingOptions
+		expected string
+	}{
+		{"first release of the period", "YYYY.0M.MICRO", "2024.04.3", types.TaggingOptions{}, "2024.05.0"},
+		{"next release in the period", "YYYY.0M.MICRO", "2024.05.1", types.TaggingOptions{}, "2024.05.2"},
+		{"pre-release in a new period", "YYYY.0M.MICRO", "2024.04.3", types.TaggingOptions{PreRelease: "rc"}, "2024.05.0-rc.1"},
+		{"continue pre-release", "YYYY.0M.MICRO", "2024.05.0-rc.1", types.TaggingOptions{PreRelease: "rc"}, "2024.05.0-rc.2"},
+		{"release pre-release", "YYYY.0M.MICRO", "2024.05.0-rc.2", types.TaggingOptions{}, "2024.05.0"},
+		{"earlier pre-release needs a new micro", "YYYY.0M.MICRO", "2024.05.0-rc.1", types.TaggingOptions{PreRelease: "alpha"}, "2024.05.1-alpha.1"},
+		{"promote", "YYYY.0M.MICRO", "2024.04.2-rc.3", types.TaggingOptions{Promote: true}, "2024.04.2"},
+		{"build metadata", "YY.0M.MICRO", "24.04.0", types.TaggingOptions{Build: "ci.42"}, "24.05.0+ci.42"},
+		{"daily release", "YYYY.0M.0D", "2024.05.19", types.TaggingOptions{}, "2024.05.20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, err := NewScheme(types.VersioningConfig{Scheme: SchemeCalVer, CalVerFormat: tt.format}, func() time.Time { return today })
+			require.NoError(t, err)
+			current, err := scheme.Parse(tt.current)
+			require.NoError(t, err)
+
+			next, err := scheme.Next(current, &types.VersionAnalysis{RecommendedBump: types.VersionBumpPatch}, tt.options)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, next.String())
+			assert.Equal(t, 1, next.Compare(current))
+		})
+	}
+
+	daily, err := NewScheme(types.VersioningConfig{Scheme: SchemeCalVer, CalVerFormat: "YYYY.0M.0D"}, func() time.Time { return today })
+	require.NoError(t, err)
+	released, err := daily.Parse("2024.05.20")
+	require.NoError(t, err)
+	_, err = daily.Next(released, &types.VersionAnalysis{}, types.TaggingOptions{})
+	assert.ErrorContains(t, err, "add MICRO")
+
+	future, err := NewScheme(types.VersioningConfig{Scheme: SchemeCalVer, CalVerFormat: "YYYY.0M.MICRO"}, func() time.Time { return today })
+	require.NoError(t, err)
+	ahead, err := future.Parse("2024.06.0")
+	require.NoError(t, err)
+	_, err = future.Next(ahead, &types.VersionAnalysis{}, types.TaggingOptions{})
+	assert.ErrorContains(t, err, "not newer")
+
+	_, err = NewScheme(types.VersioningConfig{Scheme: "romver"}, time.Now)
+	assert.Error(t, err)
+}
@@ -3,9 +3,9 @@ package version
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/nguyendkn/git-generator/internal/ai"
 	"github.com/nguyendkn/git-generator/internal/diff"
@@ -19,18 +19,39 @@ type Service struct {
 	diffProcessor *diff.Processor
 	aiClient      *ai.GeminiClient
 	config        types.Config
+	module        *types.Module // Module being versioned in a monorepo, nil for the whole repository
+	scheme        Scheme        // Versioning scheme selected in the configuration
+	schemeErr     error         // Why the configured scheme could not be created
 }
 
 // NewService creates a new version service
 func NewService(gitService *git.Service, diffProcessor *diff.Processor, aiClient *ai.GeminiClient, config types.Config) *Service {
+	scheme, err := NewScheme(config.Versioning, time.Now)
 	return &Service{
 		gitService:    gitService,
 		diffProcessor: diffProcessor,
 		aiClient:      aiClient,
 		config:        config,
+		scheme:        scheme,
+		schemeErr:     err,
 	}
 }
 
+// SetModule limits versioning to a monorepo module: only its tags are
+// considered, only commits touching its directory are analyzed and new tags
+// use its tag prefix
+func (s *Service) SetModule(module types.Module) {
+	s.module = &module
+}
+
+// TagName returns the tag name of a version, using the module's tag prefix if set
+func (s *Service) TagName(version *types.SemanticVersion) string {
+	if s.module == nil {
+		return version.TagName()
+	}
+	return s.module.TagPrefix + version.String()
+}
+
 // AnalyzeChangesForVersioning analyzes git changes to determine semantic version bump
 func (s *Service) AnalyzeChangesForVersioning(ctx context.Context, includeStaged bool) (*types.VersionAnalysis, error) {
 	// Validate that we're in a Git repository
@@ -79,169 +100,218 @@ func (s *Service) AnalyzeChangesForVersioning(ctx context.Context, includeStaged
 	return analysis, nil
 }
 
-// GetLatestVersion retrieves the latest semantic version tag from the repository
-func (s *Service) GetLatestVersion() (*types.SemanticVersion, error) {
-	tags, err := s.gitService.GetTags()
+// AnalyzeCommitsSinceLatestTag determines the version bump from the conventional
+// commits since the latest semantic version tag, without using AI
+func (s *Service) AnalyzeCommitsSinceLatestTag() (*types.VersionAnalysis, error) {
+	if !s.gitService.IsGitRepository() {
+		return nil, fmt.Errorf("not in a Git repository")
+	}
+
+	latestTag, err := s.GetLatestVersionTag()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tags: %w", err)
+		return nil, err
 	}
 
-	var latestVersion *types.SemanticVersion
-	for _, tag := range tags {
-		if tag.Version != nil {
-			if latestVersion == nil || s.isVersionNewer(tag.Version, latestVersion) {
-				latestVersion = tag.Version
-			}
-		}
+	from, baseTag := "", ""
+	current := &types.SemanticVersion{}
+	if latestTag != nil {
+		from, baseTag, current = latestTag.Hash, latestTag.Name, latestTag.Version
 	}
 
-	// If no version tags found, start with 0.0.0
-	if latestVersion == nil {
-		latestVersion = &types.SemanticVersion{
-			Major: 0,
-			Minor: 0,
-			Patch: 0,
-			Raw:   "0.0.0",
-		}
+	commits, err := s.gitService.GetCommitsInRangeForPaths(from, "HEAD", s.Paths())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits since %s: %w", baseTag, err)
 	}
 
-	return latestVersion, nil
+	analysis := AnalyzeCommits(commits, current)
+	analysis.BaseTag = baseTag
+	return analysis, nil
 }
 
-// CalculateNextVersion calculates the next version based on analysis and options
-func (s *Service) CalculateNextVersion(currentVersion *types.SemanticVersion, analysis *types.VersionAnalysis, options types.TaggingOptions) *types.SemanticVersion {
-	nextVersion := &types.SemanticVersion{
-		Major: currentVersion.Major,
-		Minor: currentVersion.Minor,
-		Patch: currentVersion.Patch,
+// AnalyzeWithAI asks the AI for a second opinion on the changes since baseTag,
+// or on the latest commit when there is no tag yet
+func (s *Service) AnalyzeWithAI(ctx context.Context, baseTag string) (*types.VersionAnalysis, error) {
+	if s.aiClient == nil {
+		return nil, fmt.Errorf("AI client is not configured")
 	}
 
-	// Determine bump type
-	bumpType := analysis.RecommendedBump
-	if options.ForceBump != "" {
-		bumpType = options.ForceBump
+	var diffSummary *types.DiffSummary
+	var commits []*types.CommitInfo
+	var err error
+	if baseTag != "" {
+		diffSummary, err = s.gitService.GetDiffSummaryFor(git.DiffOptions{Range: baseTag + "..HEAD"})
+		if err == nil {
+			commits, err = s.gitService.GetCommitsInRange(baseTag, "HEAD")
+		}
+	} else {
+		diffSummary, err = s.gitService.GetCommitDiffSummary("HEAD")
+		if err == nil {
+			commits, err = s.gitService.GetRecentCommits(10)
+		}
 	}
-
-	// Apply version bump
-	switch bumpType {
-	case types.VersionBumpMajor:
-		nextVersion.Major++
-		nextVersion.Minor = 0
-		nextVersion.Patch = 0
-	case types.VersionBumpMinor:
-		nextVersion.Minor++
-		nextVersion.Patch = 0
-	case types.VersionBumpPatch:
-		nextVersion.Patch++
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect changes: %w", err)
+	}
+	if len(diffSummary.Files) == 0 {
+		return nil, fmt.Errorf("no changes detected since %s", baseTag)
 	}
 
-	// Handle pre-release
-	if options.PreRelease != "" {
-		nextVersion.PreRelease = options.PreRelease
-		nextVersion.PreNumber = 1 // Start with .1 for pre-releases
+	processedDiff, err := s.diffProcessor.ProcessDiff(diffSummary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process diff: %w", err)
 	}
 
-	nextVersion.Raw = nextVersion.String()
-	return nextVersion
+	analysis, err := s.aiClient.AnalyzeChangesForVersioning(ctx, processedDiff, commits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze changes: %w", err)
+	}
+	analysis.Source = SourceAI
+	analysis.BaseTag = baseTag
+	return analysis, nil
 }
 
-// CreateTag creates a new Git tag with the specified version
-func (s *Service) CreateTag(ctx context.Context, version *types.SemanticVersion, options types.TaggingOptions) error {
-	if options.DryRun {
-		return nil // Don't actually create tag in dry-run mode
+// Paths returns the paths commits must touch to count for the module being
+// versioned, or nil for the whole repository
+func (s *Service) Paths() []string {
+	if s.module == nil || s.module.Path == "." {
+		return nil
 	}
+	return []string{s.module.Path}
+}
 
-	tagName := version.TagName()
-	message := options.Message
-	if message == "" {
-		message = fmt.Sprintf("Release %s", version.String())
+// GetLatestVersionTag returns the tag with the highest semantic version, or nil if there is none
+func (s *Service) GetLatestVersionTag() (*types.GitTag, error) {
+	tags, err := s.GetVersionTags()
+	if err != nil {
+		return nil, err
 	}
-
-	return s.gitService.CreateTag(tagName, message, options.Annotated)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags[len(tags)-1], nil
 }
 
-// ParseVersion parses a version string into SemanticVersion
-func (s *Service) ParseVersion(versionStr string) (*types.SemanticVersion, error) {
-	// Remove 'v' prefix if present
-	versionStr = strings.TrimPrefix(versionStr, "v")
-
-	// Regex for semantic version with optional pre-release
-	re := regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([a-zA-Z]+)(?:\.(\d+))?)?$`)
-	matches := re.FindStringSubmatch(versionStr)
-
-	if len(matches) < 4 {
-		return nil, fmt.Errorf("invalid semantic version format: %s", versionStr)
+// GetVersionTags returns the version tags of the configured scheme, oldest
+// version first. With a module set, only tags with its prefix are returned,
+// parsed without it.
+func (s *Service) GetVersionTags() ([]*types.GitTag, error) {
+	if s.schemeErr != nil {
+		return nil, s.schemeErr
 	}
-
-	major, err := strconv.Atoi(matches[1])
+	tags, err := s.gitService.GetTags()
 	if err != nil {
-		return nil, fmt.Errorf("invalid major version: %s", matches[1])
+		return nil, fmt.Errorf("failed to get tags: %w", err)
 	}
 
-	minor, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return nil, fmt.Errorf("invalid minor version: %s", matches[2])
+	var versionTags []*types.GitTag
+	for _, tag := range tags {
+		if tag = s.versionTag(tag); tag != nil {
+			versionTags = append(versionTags, tag)
+		}
 	}
+	sort.SliceStable(versionTags, func(i, j int) bool {
+		return versionTags[i].Version.Compare(versionTags[j].Version) < 0
+	})
+	return versionTags, nil
+}
 
-	patch, err := strconv.Atoi(matches[3])
+// versionTag returns a copy of tag with the version parsed after the "v" or
+// module tag prefix, or nil if the tag is not a version of the module
+func (s *Service) versionTag(tag *types.GitTag) *types.GitTag {
+	rest := strings.TrimPrefix(tag.Name, "v")
+	if s.module != nil {
+		var found bool
+		rest, found = strings.CutPrefix(tag.Name, s.module.TagPrefix)
+		if !found || strings.HasPrefix(rest, "v") {
+			return nil
+		}
+	}
+	version, err := s.scheme.Parse(rest)
 	if err != nil {
-		return nil, fmt.Errorf("invalid patch version: %s", matches[3])
+		return nil
 	}
 
-	version := &types.SemanticVersion{
-		Major: major,
-		Minor: minor,
-		Patch: patch,
-		Raw:   versionStr,
+	versionTag := *tag
+	versionTag.Version = version
+	return &versionTag
+}
+
+// GetLatestVersion retrieves the latest semantic version tag from the repository
+func (s *Service) GetLatestVersion() (*types.SemanticVersion, error) {
+	latestTag, err := s.GetLatestVersionTag()
+	if err != nil {
+		return nil, err
 	}
 
-	// Handle pre-release
-	if len(matches) > 4 && matches[4] != "" {
-		version.PreRelease = types.PreReleaseType(matches[4])
-		if len(matches) > 5 && matches[5] != "" {
-			preNumber, err := strconv.Atoi(matches[5])
-			if err == nil {
-				version.PreNumber = preNumber
-			}
-		}
+	// If no version tags found, start with 0.0.0
+	if latestTag == nil {
+		return &types.SemanticVersion{
+			Major: 0,
+			Minor: 0,
+			Patch: 0,
+			Raw:   "0.0.0",
+		}, nil
 	}
 
-	return version, nil
+	return latestTag.Version, nil
 }
 
-// isVersionNewer compares two semantic versions
-func (s *Service) isVersionNewer(v1, v2 *types.SemanticVersion) bool {
-	if v1.Major != v2.Major {
-		return v1.Major > v2.Major
+// CalculateNextVersion calculates the next version based on analysis and
+// options, following the configured versioning scheme
+func (s *Service) CalculateNextVersion(currentVersion *types.SemanticVersion, analysis *types.VersionAnalysis, options types.TaggingOptions) (*types.SemanticVersion, error) {
+	if s.schemeErr != nil {
+		return nil, s.schemeErr
 	}
-	if v1.Minor != v2.Minor {
-		return v1.Minor > v2.Minor
+	return s.scheme.Next(currentVersion, analysis, options)
+}
+
+// CreateTag creates a new Git tag with the specified version
+func (s *Service) CreateTag(ctx context.Context, version *types.SemanticVersion, options types.TaggingOptions) error {
+	if options.DryRun {
+		return nil // Don't actually create tag in dry-run mode
 	}
-	if v1.Patch != v2.Patch {
-		return v1.Patch > v2.Patch
+
+	tagName := s.TagName(version)
+	message := options.Message
+	if message == "" {
+		message = fmt.Sprintf("Release %s", version.String())
 	}
+	if len(options.APIChanges) > 0 {
+		message += "\n\nIncompatible API changes:"
+		for _, change := range options.APIChanges {
+			message += "\n- " + change.String()
+		}
+	}
+
+	return s.gitService.CreateTag(tagName, message, options.Annotated)
+}
 
-	// Handle pre-release comparison
-	if v1.PreRelease == "" && v2.PreRelease != "" {
-		return true // Release version is newer than pre-release
+// PushTag pushes a created tag to the remote, together with the current branch
+// when requested. If the push fails the local tag is deleted so the tag can be
+// recreated once the problem is fixed.
+func (s *Service) PushTag(ctx context.Context, version *types.SemanticVersion, options types.TaggingOptions) error {
+	if options.DryRun {
+		return nil
 	}
-	if v1.PreRelease != "" && v2.PreRelease == "" {
-		return false // Pre-release is older than release
+
+	tagName := s.TagName(version)
+	remote := options.Remote
+	if remote == "" {
+		remote = "origin"
 	}
-	if v1.PreRelease != "" && v2.PreRelease != "" {
-		if v1.PreRelease != v2.PreRelease {
-			// Compare pre-release types: alpha < beta < rc
-			order := map[types.PreReleaseType]int{
-				types.PreReleaseAlpha: 1,
-				types.PreReleaseBeta:  2,
-				types.PreReleaseRC:    3,
-			}
-			return order[v1.PreRelease] > order[v2.PreRelease]
+
+	if err := s.gitService.PushTag(remote, tagName, options.PushBranch); err != nil {
+		if deleteErr := s.gitService.DeleteTag(tagName); deleteErr != nil {
+			return fmt.Errorf("failed to push tag %s (rollback failed: %v): %w", tagName, deleteErr, err)
 		}
-		return v1.PreNumber > v2.PreNumber
+		return fmt.Errorf("failed to push tag %s, local tag deleted: %w", tagName, err)
 	}
+	return nil
+}
 
-	return false // Versions are equal
+// ParseVersion parses a version string into SemanticVersion
+func (s *Service) ParseVersion(versionStr string) (*types.SemanticVersion, error) {
+	return types.ParseSemanticVersion(versionStr)
 }
 
 // ValidateRepositoryState validates that the repository is ready for tagging
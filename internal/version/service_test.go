@@ -0,0 +1,164 @@
+package version
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/internal/git"
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestPushTagRollsBackOnFailure(t *testing.T) {
+	fs := memfs.New()
+	repo, err := gogit.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.User.Name = "Test User"
+	cfg.User.Email = "test@example.com"
+	require.NoError(t, repo.SetConfig(cfg))
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{filepath.Join(t.TempDir(), "missing.git")},
+	})
+	require.NoError(t, err)
+
+	file, err := fs.Create("README.md")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("# Test\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("README.md")
+	require.NoError(t, err)
+	_, err = worktree.Commit("feat: initial", &gogit.CommitOptions{})
+	require.NoError(t, err)
+
+	service := NewService(git.NewServiceWithBackend(git.NewGoGitBackendFromRepository(repo)), nil, nil, types.Config{})
+	version := &types.SemanticVersion{Major: 1}
+	options := types.TaggingOptions{Push: true, Remote: "origin", PushBranch: true, Annotated: true}
+
+	require.NoError(t, service.CreateTag(context.Background(), version, options))
+	_, err = repo.Reference(plumbing.NewTagReferenceName("v1.0.0"), false)
+	require.NoError(t, err)
+
+	err = service.PushTag(context.Background(), version, options)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local tag deleted")
+
+	_, err = repo.Reference(plumbing.NewTagReferenceName("v1.0.0"), false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
+
+func TestCalculateNextVersion(t *testing.T) {
+	service := NewService(nil, nil, nil, types.Config{})
+
+	tests := []struct {
+		name     string
+		current  string
+		bump     types.VersionBumpType
+		options  types.TaggingOptions
+		expected string
+	}{
+		{"release patch", "1.2.3", types.VersionBumpPatch, types.TaggingOptions{}, "1.2.4"},
+		{"release minor", "1.2.3", types.VersionBumpMinor, types.TaggingOptions{}, "1.3.0"},
+		{"release to pre-release", "1.2.3", types.VersionBumpMinor, types.TaggingOptions{PreRelease: "beta"}, "1.3.0-beta.1"},
+		{"increment same pre-release", "1.2.0-beta.1", types.VersionBumpPatch, types.TaggingOptions{PreRelease: "beta"}, "1.2.0-beta.2"},
+		{"increment pre-release covered by core", "1.2.0-beta.3", types.VersionBumpMinor, types.TaggingOptions{PreRelease: "beta"}, "1.2.0-beta.4"},
+		{"increment pre-release without counter", "1.2.0-beta", types.VersionBumpPatch, types.TaggingOptions{PreRelease: "beta"}, "1.2.0-beta.1"},
+		{"alpha to beta", "1.2.0-alpha.4", types.VersionBumpPatch, types.TaggingOptions{PreRelease: "beta"}, "1.2.0-beta.1"},
+		{"beta to rc", "1.2.0-beta.2", types.VersionBumpNone, types.TaggingOptions{PreRelease: "rc"}, "1.2.0-rc.1"},
+		{"rc back to alpha bumps core", "1.2.0-rc.1", types.VersionBumpPatch, types.TaggingOptions{PreRelease: "alpha"}, "1.2.1-alpha.1"},
+		{"breaking change outgrows pre-release core", "1.2.0-beta.1", types.VersionBumpMajor, types.TaggingOptions{PreRelease: "beta"}, "2.0.0-beta.1"},
+		{"release from pre-release", "1.2.0-rc.2", types.VersionBumpPatch, types.TaggingOptions{}, "1.2.0"},
+		{"release outgrows pre-release core", "1.2.1-rc.1", types.VersionBumpMinor, types.TaggingOptions{}, "1.3.0"},
+		{"promote", "2.0.0-rc.3", types.VersionBumpNone, types.TaggingOptions{Promote: true}, "2.0.0"},
+		{"arbitrary identifiers", "1.0.0", types.VersionBumpPatch, types.TaggingOptions{PreRelease: "preview"}, "1.0.1-preview.1"},
+		{"build metadata", "1.0.0", types.VersionBumpPatch, types.TaggingOptions{Build: "build.5"}, "1.0.1+build.5"},
+		{"forced bump", "1.0.0", types.VersionBumpPatch, types.TaggingOptions{ForceBump: types.VersionBumpMajor}, "2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := types.ParseSemanticVersion(tt.current)
+			require.NoError(t, err)
+
+			next, err := service.CalculateNextVersion(current, &types.VersionAnalysis{RecommendedBump: tt.bump}, tt.options)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, next.String())
+			assert.Equal(t, 1, next.Compare(current))
+		})
+	}
+
+	_, err := service.CalculateNextVersion(&types.SemanticVersion{Major: 1}, &types.VersionAnalysis{}, types.TaggingOptions{Promote: true})
+	assert.Error(t, err)
+}
+
+func TestModuleVersioning(t *testing.T) {
+	fs := memfs.New()
+	repo, err := gogit.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(path, message string) plumbing.Hash {
+		file, err := fs.Create(path)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(message))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		_, err = worktree.Add(path)
+		require.NoError(t, err)
+		hash, err := worktree.Commit(message, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	initial := commit("README.md", "feat: initial")
+	for _, tag := range []string{"v1.0.0", "api/v1.0.0", "api/v1.1.0-rc.1", "@acme/ui@2.0.0"} {
+		_, err := repo.CreateTag(tag, initial, nil)
+		require.NoError(t, err)
+	}
+	commit("api/handler.go", "fix(api): handle timeouts")
+	commit("README.md", "feat: document modules")
+
+	service := NewService(git.NewServiceWithBackend(git.NewGoGitBackendFromRepository(repo)), nil, nil, types.Config{})
+	service.SetModule(types.Module{Name: "api", Path: "api", TagPrefix: "api/v"})
+
+	tags, err := service.GetVersionTags()
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "api/v1.1.0-rc.1", tags[1].Name)
+	assert.Equal(t, "1.1.0-rc.1", tags[1].Version.String())
+
+	analysis, err := service.AnalyzeCommitsSinceLatestTag()
+	require.NoError(t, err)
+	assert.Equal(t, "api/v1.1.0-rc.1", analysis.BaseTag)
+	assert.Equal(t, types.VersionBumpPatch, analysis.RecommendedBump)
+	require.Len(t, analysis.Commits, 1)
+	assert.Equal(t, "fix(api): handle timeouts", analysis.Commits[0].Subject)
+
+	assert.Equal(t, "api/v1.2.0", service.TagName(&types.SemanticVersion{Major: 1, Minor: 2}))
+
+	// Without a module, prefixed tags are ignored
+	latest, err := NewService(git.NewServiceWithBackend(git.NewGoGitBackendFromRepository(repo)), nil, nil, types.Config{}).GetLatestVersionTag()
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", latest.Name)
+}
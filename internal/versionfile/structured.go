@@ -0,0 +1,229 @@
+package versionfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// yamlKeyPattern matches a YAML mapping entry "key: value"
+var yamlKeyPattern = regexp.MustCompile(`^(\s*)(["']?)([^\s:#"'][^:#"']*?)(["']?)\s*:(\s*)(.*)$`)
+
+// tomlTablePattern matches a TOML table or array of tables header
+var tomlTablePattern = regexp.MustCompile(`^\s*\[\[?\s*([^\]]+?)\s*\]\]?\s*(?:#.*)?$`)
+
+// tomlKeyPattern matches a TOML key/value pair with a string value
+var tomlKeyPattern = regexp.MustCompile(`^(\s*)([A-Za-z0-9_.\-" ]+?)\s*=\s*(["'])([^"']*)(["'])`)
+
+// jsonUpdater replaces a string value addressed by a dotted key path
+type jsonUpdater struct {
+	path []string
+}
+
+// Update implements Updater
+func (u jsonUpdater) Update(content []byte, version string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	start, end, err := findJSONValue(decoder, content, u.path)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(version)
+	if err != nil {
+		return nil, err
+	}
+
+	result := append([]byte{}, content[:start]...)
+	result = append(result, encoded...)
+	return append(result, content[end:]...), nil
+}
+
+// findJSONValue returns the byte span of the string literal at path in the
+// object the decoder is positioned at
+func findJSONValue(decoder *json.Decoder, content []byte, path []string) (int, int, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return 0, 0, fmt.Errorf("key %q not found", strings.Join(path, "."))
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid JSON: %w", err)
+		}
+		key, _ := token.(string)
+		if key != path[0] {
+			if err := skipJSONValue(decoder); err != nil {
+				return 0, 0, err
+			}
+			continue
+		}
+
+		if len(path) > 1 {
+			return findJSONValue(decoder, content, path[1:])
+		}
+
+		offset := decoder.InputOffset()
+		value, err := decoder.Token()
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if _, ok := value.(string); !ok {
+			return 0, 0, fmt.Errorf("key %q is not a string", key)
+		}
+		end := int(decoder.InputOffset())
+		start := int(offset) + bytes.IndexByte(content[offset:end], '"')
+		return start, end, nil
+	}
+	return 0, 0, fmt.Errorf("key %q not found", strings.Join(path, "."))
+}
+
+// skipJSONValue consumes the next value, including nested objects and arrays
+func skipJSONValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return fmt.Errorf("invalid JSON: unexpected end of input")
+		}
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		if delim, ok := token.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// yamlUpdater replaces a scalar addressed by a dotted key path in block-style YAML
+type yamlUpdater struct {
+	path []string
+}
+
+// yamlKey is a mapping key on the path to the current line
+type yamlKey struct {
+	indent int
+	name   string
+}
+
+// Update implements Updater
+func (u yamlUpdater) Update(content []byte, version string) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	var stack []yamlKey
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		matches := yamlKeyPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		indent := len(matches[1])
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, yamlKey{indent: indent, name: matches[3]})
+		if !matchesPath(stack, u.path) {
+			continue
+		}
+
+		value := matches[6]
+		if value == "" {
+			return nil, fmt.Errorf("key %q is not a scalar", strings.Join(u.path, "."))
+		}
+		prefix := line[:len(line)-len(value)]
+		lines[i] = prefix + replaceScalar(value, version)
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+	return nil, fmt.Errorf("key %q not found", strings.Join(u.path, "."))
+}
+
+// matchesPath reports whether the keys on the stack equal path
+func matchesPath(stack []yamlKey, path []string) bool {
+	if len(stack) != len(path) {
+		return false
+	}
+	for i, key := range stack {
+		if key.name != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceScalar replaces a YAML scalar, keeping its quotes and trailing comment
+func replaceScalar(value, version string) string {
+	if quote := value[0]; quote == '"' || quote == '\'' {
+		if end := strings.IndexByte(value[1:], quote); end >= 0 {
+			return string(quote) + version + value[end+1:]
+		}
+	}
+
+	end := len(value)
+	if comment := strings.Index(value, " #"); comment >= 0 {
+		end = comment
+	}
+	scalar := strings.TrimRight(value[:end], " \t")
+	return version + value[len(scalar):]
+}
+
+// tomlUpdater replaces a string value addressed by its table and key
+type tomlUpdater struct {
+	path []string
+}
+
+// Update implements Updater
+func (u tomlUpdater) Update(content []byte, version string) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	target := strings.Join(u.path, ".")
+	table := ""
+
+	for i, line := range lines {
+		if matches := tomlTablePattern.FindStringSubmatch(line); matches != nil {
+			table = normalizeTOMLKey(matches[1])
+			continue
+		}
+		matches := tomlKeyPattern.FindStringSubmatchIndex(line)
+		if matches == nil {
+			continue
+		}
+
+		key := normalizeTOMLKey(line[matches[4]:matches[5]])
+		if table != "" {
+			key = table + "." + key
+		}
+		if key != target {
+			continue
+		}
+
+		// Groups: 3 is the opening quote, 4 the value
+		lines[i] = line[:matches[8]] + version + line[matches[9]:]
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+	return nil, fmt.Errorf("key %q not found", target)
+}
+
+// normalizeTOMLKey removes quotes and whitespace around the parts of a dotted key
+func normalizeTOMLKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,206 @@
+package versionfile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+// Supported version file formats
+const (
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTOML  = "toml"
+	FormatRegex = "regex"
+	FormatPlain = "plain"
+)
+
+// goVersionPattern matches Go constants and variables such as Version = "1.2.3"
+const goVersionPattern = `(?m)\bVersion\s*=\s*"v?([^"]*)"`
+
+// Updater replaces the version stored in a file's content. Updaters edit the
+// text in place so formatting, comments and key order are preserved.
+type Updater interface {
+	Update(content []byte, version string) ([]byte, error)
+}
+
+// Change is the planned edit of a version file
+type Change struct {
+	Path   string
+	Before []byte
+	After  []byte
+}
+
+// NewUpdater creates the updater for a version file, detecting the format and
+// default key from the file name when they are not configured
+func NewUpdater(file types.VersionFile) (Updater, error) {
+	format, key, pattern := file.Format, file.Key, file.Pattern
+	if format == "" {
+		format, key, pattern = detect(file.Path, key, pattern)
+	}
+
+	switch format {
+	case FormatJSON, FormatYAML, FormatTOML:
+		if key == "" {
+			key = "version"
+		}
+		path := strings.Split(key, ".")
+		switch format {
+		case FormatJSON:
+			return jsonUpdater{path: path}, nil
+		case FormatYAML:
+			return yamlUpdater{path: path}, nil
+		}
+		return tomlUpdater{path: path}, nil
+	case FormatRegex:
+		if pattern == "" {
+			return nil, fmt.Errorf("version file %s: regex format requires a pattern", file.Path)
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("version file %s: invalid pattern: %w", file.Path, err)
+		}
+		if compiled.NumSubexp() < 1 {
+			return nil, fmt.Errorf("version file %s: pattern needs a capture group for the version", file.Path)
+		}
+		return regexUpdater{pattern: compiled}, nil
+	case FormatPlain:
+		return plainUpdater{}, nil
+	case "":
+		return nil, fmt.Errorf("version file %s: cannot detect format, set format to json, yaml, toml, regex or plain", file.Path)
+	}
+	return nil, fmt.Errorf("version file %s: unsupported format %q", file.Path, format)
+}
+
+// detect returns the format, key and pattern for well-known file names
+func detect(path, key, pattern string) (string, string, string) {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasSuffix(name, ".json"):
+		return FormatJSON, key, pattern
+	case strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml"):
+		return FormatYAML, key, pattern
+	case name == "pyproject.toml" && key == "":
+		return FormatTOML, "project.version", pattern
+	case name == "cargo.toml" && key == "":
+		return FormatTOML, "package.version", pattern
+	case strings.HasSuffix(name, ".toml"):
+		return FormatTOML, key, pattern
+	case strings.HasSuffix(name, ".go"):
+		if pattern == "" {
+			pattern = goVersionPattern
+		}
+		return FormatRegex, key, pattern
+	case name == "version" || name == "version.txt":
+		return FormatPlain, key, pattern
+	}
+	return "", key, pattern
+}
+
+// Plan computes the edits of all version files without writing them.
+// Paths are relative to root; files that already contain the version are skipped.
+func Plan(root string, files []types.VersionFile, version string) ([]Change, error) {
+	var changes []Change
+	for _, file := range files {
+		updater, err := NewUpdater(file)
+		if err != nil {
+			return nil, err
+		}
+
+		before, err := os.ReadFile(filepath.Join(root, file.Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read version file: %w", err)
+		}
+		after, err := updater.Update(before, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", file.Path, err)
+		}
+		if !bytes.Equal(before, after) {
+			changes = append(changes, Change{Path: file.Path, Before: before, After: after})
+		}
+	}
+	return changes, nil
+}
+
+// Write applies planned changes to the files under root
+func Write(root string, changes []Change) error {
+	for _, change := range changes {
+		path := filepath.Join(root, change.Path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat version file: %w", err)
+		}
+		if err := os.WriteFile(path, change.After, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write version file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Diff renders a change as a unified-style diff of the edited lines
+func (c Change) Diff() string {
+	before := strings.Split(string(c.Before), "\n")
+	after := strings.Split(string(c.After), "\n")
+
+	var diff strings.Builder
+	diff.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", c.Path, c.Path))
+	if len(before) != len(after) {
+		for _, line := range before {
+			diff.WriteString("-" + line + "\n")
+		}
+		for _, line := range after {
+			diff.WriteString("+" + line + "\n")
+		}
+		return diff.String()
+	}
+
+	for i := range before {
+		if before[i] == after[i] {
+			continue
+		}
+		diff.WriteString(fmt.Sprintf("@@ -%d +%d @@\n", i+1, i+1))
+		diff.WriteString("-" + before[i] + "\n")
+		diff.WriteString("+" + after[i] + "\n")
+	}
+	return diff.String()
+}
+
+// regexUpdater replaces the first capture group of every match
+type regexUpdater struct {
+	pattern *regexp.Regexp
+}
+
+// Update implements Updater
+func (u regexUpdater) Update(content []byte, version string) ([]byte, error) {
+	matches := u.pattern.FindAllSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("pattern %q does not match", u.pattern.String())
+	}
+
+	var result []byte
+	last := 0
+	for _, match := range matches {
+		start, end := match[2], match[3]
+		if start < 0 {
+			continue
+		}
+		result = append(result, content[last:start]...)
+		result = append(result, version...)
+		last = end
+	}
+	return append(result, content[last:]...), nil
+}
+
+// plainUpdater replaces the whole content of a VERSION file, keeping its trailing newline
+type plainUpdater struct{}
+
+// Update implements Updater
+func (plainUpdater) Update(content []byte, version string) ([]byte, error) {
+	trimmed := bytes.TrimRight(content, "\r\n")
+	return append([]byte(version), content[len(trimmed):]...), nil
+}
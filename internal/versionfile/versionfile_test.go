@@ -0,0 +1,158 @@
+package versionfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nguyendkn/git-generator/pkg/types"
+)
+
+func TestUpdaters(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     types.VersionFile
+		content  string
+		expected string
+	}{
+		{
+			name:     "package.json",
+			file:     types.VersionFile{Path: "package.json"},
+			content:  "{\n  \"name\": \"app\",\n  \"dependencies\": {\"version\": \"^1.0.0\"},\n  \"version\" : \"1.2.3\",\n  \"private\": true\n}\n",
+			expected: "{\n  \"name\": \"app\",\n  \"dependencies\": {\"version\": \"^1.0.0\"},\n  \"version\" : \"1.3.0\",\n  \"private\": true\n}\n",
+		},
+		{
+			name:     "nested json key",
+			file:     types.VersionFile{Path: "manifest.json", Key: "app.version"},
+			content:  `{"version": "9.9.9", "app": {"tags": [1, {"a": 2}], "version": "1.2.3"}}`,
+			expected: `{"version": "9.9.9", "app": {"tags": [1, {"a": 2}], "version": "1.3.0"}}`,
+		},
+		{
+			name:     "Chart.yaml version",
+			file:     types.VersionFile{Path: "Chart.yaml"},
+			content:  "apiVersion: v2\nname: app\nversion: 1.2.3 # chart version\nappVersion: \"1.2.3\"\n",
+			expected: "apiVersion: v2\nname: app\nversion: 1.3.0 # chart version\nappVersion: \"1.2.3\"\n",
+		},
+		{
+			name:     "Chart.yaml quoted appVersion",
+			file:     types.VersionFile{Path: "Chart.yaml", Key: "appVersion"},
+			content:  "version: 1.2.3\nappVersion: \"1.2.3\"\n",
+			expected: "version: 1.2.3\nappVersion: \"1.3.0\"\n",
+		},
+		{
+			name:     "nested yaml key",
+			file:     types.VersionFile{Path: "values.yml", Key: "image.tag"},
+			content:  "service:\n  tag: keep\nimage:\n  repository: app\n  tag: '1.2.3'\n",
+			expected: "service:\n  tag: keep\nimage:\n  repository: app\n  tag: '1.3.0'\n",
+		},
+		{
+			name:     "pyproject.toml",
+			file:     types.VersionFile{Path: "pyproject.toml"},
+			content:  "[build-system]\nrequires = [\"hatchling\"]\n\n[project]\nname = \"app\"\nversion = \"1.2.3\" # bumped on release\n",
+			expected: "[build-system]\nrequires = [\"hatchling\"]\n\n[project]\nname = \"app\"\nversion = \"1.3.0\" # bumped on release\n",
+		},
+		{
+			name:     "poetry toml key",
+			file:     types.VersionFile{Path: "pyproject.toml", Key: "tool.poetry.version"},
+			content:  "[tool.poetry]\nversion = '1.2.3'\n",
+			expected: "[tool.poetry]\nversion = '1.3.0'\n",
+		},
+		{
+			name:     "Cargo.toml",
+			file:     types.VersionFile{Path: "Cargo.toml"},
+			content:  "[package]\nname = \"app\"\nversion = \"1.2.3\"\n\n[dependencies]\nserde = { version = \"1.0\" }\n",
+			expected: "[package]\nname = \"app\"\nversion = \"1.3.0\"\n\n[dependencies]\nserde = { version = \"1.0\" }\n",
+		},
+		{
+			name:     "VERSION file",
+			file:     types.VersionFile{Path: "VERSION"},
+			content:  "1.2.3\n",
+			expected: "1.3.0\n",
+		},
+		{
+			name:     "VERSION.txt file",
+			file:     types.VersionFile{Path: "packages/api/VERSION.txt"},
+			content:  "1.2.3",
+			expected: "1.3.0",
+		},
+		{
+			name:     "go constant",
+			file:     types.VersionFile{Path: "internal/version.go"},
+			content:  "package internal\n\nconst Version = \"v1.2.3\"\n",
+			expected: "package internal\n\nconst Version = \"v1.3.0\"\n",
+		},
+		{
+			name:     "custom regex",
+			file:     types.VersionFile{Path: "Dockerfile", Format: FormatRegex, Pattern: `LABEL version="([^"]+)"`},
+			content:  "FROM alpine\nLABEL version=\"1.2.3\"\n",
+			expected: "FROM alpine\nLABEL version=\"1.3.0\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updater, err := NewUpdater(tt.file)
+			require.NoError(t, err)
+
+			updated, err := updater.Update([]byte(tt.content), "1.3.0")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(updated))
+		})
+	}
+}
+
+func TestUpdaterErrors(t *testing.T) {
+	// Only VERSION files are replaced whole; other files must declare a format
+	for _, path := range []string{"Makefile", "requirements.txt", "NOTES.txt", "version.md"} {
+		_, err := NewUpdater(types.VersionFile{Path: path})
+		assert.Error(t, err, path)
+	}
+
+	_, err := NewUpdater(types.VersionFile{Path: "requirements.txt", Format: FormatPlain})
+	assert.NoError(t, err)
+
+	_, err = NewUpdater(types.VersionFile{Path: "x", Format: FormatRegex, Pattern: "version"})
+	assert.Error(t, err)
+
+	missing := []struct {
+		file    types.VersionFile
+		content string
+	}{
+		{types.VersionFile{Path: "package.json"}, `{"name": "app"}`},
+		{types.VersionFile{Path: "package.json"}, `{"version": 1}`},
+		{types.VersionFile{Path: "Chart.yaml", Key: "image.tag"}, "tag: 1.2.3\n"},
+		{types.VersionFile{Path: "pyproject.toml"}, "[tool.poetry]\nversion = \"1.2.3\"\n"},
+		{types.VersionFile{Path: "main.go"}, "package main\n"},
+	}
+	for _, tt := range missing {
+		updater, err := NewUpdater(tt.file)
+		require.NoError(t, err)
+		_, err = updater.Update([]byte(tt.content), "1.3.0")
+		assert.Error(t, err, tt.content)
+	}
+}
+
+func TestPlanAndWrite(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "VERSION"), []byte("1.2.3\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"version": "1.3.0"}`), 0644))
+
+	files := []types.VersionFile{{Path: "VERSION"}, {Path: "package.json"}}
+	changes, err := Plan(root, files, "1.3.0")
+	require.NoError(t, err)
+
+	// package.json already has the version
+	require.Len(t, changes, 1)
+	assert.Equal(t, "--- a/VERSION\n+++ b/VERSION\n@@ -1 +1 @@\n-1.2.3\n+1.3.0\n", changes[0].Diff())
+
+	require.NoError(t, Write(root, changes))
+	content, err := os.ReadFile(filepath.Join(root, "VERSION"))
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0\n", string(content))
+
+	_, err = Plan(root, []types.VersionFile{{Path: "missing/VERSION"}}, "1.3.0")
+	assert.Error(t, err)
+}
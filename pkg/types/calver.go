@@ -0,0 +1,223 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar version tokens, see https://calver.org
+const (
+	CalVerFullYear    = "YYYY"  // 2006
+	CalVerShortYear   = "YY"    // 6, years since 2000
+	CalVerPaddedYear  = "0Y"    // 06
+	CalVerMonth       = "MM"    // 1
+	CalVerPaddedMonth = "0M"    // 01
+	CalVerWeek        = "WW"    // 2, ISO week of the year
+	CalVerPaddedWeek  = "0W"    // 02
+	CalVerDay         = "DD"    // 2
+	CalVerPaddedDay   = "0D"    // 02
+	CalVerMicro       = "MICRO" // Release counter within the period, starting at 0
+)
+
+// calendarRanks orders the tokens from the most to the least significant
+var calendarRanks = map[string]int{
+	CalVerFullYear: 0, CalVerShortYear: 0, CalVerPaddedYear: 0,
+	CalVerMonth: 1, CalVerPaddedMonth: 1, CalVerWeek: 1, CalVerPaddedWeek: 1,
+	CalVerDay: 2, CalVerPaddedDay: 2,
+	CalVerMicro: 3,
+}
+
+// CalendarLayout is a parsed CalVer format such as "YYYY.0M.MICRO". Its
+// segments are stored in the Major, Minor and Patch fields of a
+// SemanticVersion, so versions of the same layout are ordered by Compare.
+type CalendarLayout struct {
+	format string
+	tokens []string
+}
+
+// ParseCalendarLayout parses a CalVer format of up to three dot-separated
+// tokens, starting with the year and ordered from the most significant
+func ParseCalendarLayout(format string) (CalendarLayout, error) {
+	tokens := strings.Split(format, ".")
+	if len(tokens) < 2 || len(tokens) > 3 {
+		return CalendarLayout{}, fmt.Errorf("invalid CalVer format %q: expected 2 or 3 dot-separated segments", format)
+	}
+
+	previous := -1
+	for i, token := range tokens {
+		rank, ok := calendarRanks[token]
+		if !ok {
+			return CalendarLayout{}, fmt.Errorf("invalid CalVer format %q: unknown segment %q", format, token)
+		}
+		if i == 0 && rank != 0 {
+			return CalendarLayout{}, fmt.Errorf("invalid CalVer format %q: must start with the year", format)
+		}
+		if rank <= previous || (rank == 2 && previous == 1 && isWeekToken(tokens[i-1])) {
+			return CalendarLayout{}, fmt.Errorf("invalid CalVer format %q: segments must go from year to day or week, then MICRO", format)
+		}
+		previous = rank
+	}
+	return CalendarLayout{format: format, tokens: tokens}, nil
+}
+
+// String returns the format of the layout
+func (l CalendarLayout) String() string {
+	return l.format
+}
+
+// HasMicro reports whether the layout has a MICRO counter
+func (l CalendarLayout) HasMicro() bool {
+	return l.tokens[len(l.tokens)-1] == CalVerMicro
+}
+
+// Version returns the version of the period containing date with the given MICRO counter
+func (l CalendarLayout) Version(date time.Time, micro int) *SemanticVersion {
+	year, week := date.Year(), 0
+	for _, token := range l.tokens {
+		if isWeekToken(token) {
+			year, week = date.ISOWeek() // The ISO week belongs to the ISO year
+		}
+	}
+
+	sv := &SemanticVersion{Layout: l.format}
+	segments := sv.segments()
+	for i, token := range l.tokens {
+		switch {
+		case calendarRanks[token] == 0:
+			*segments[i] = year
+		case isWeekToken(token):
+			*segments[i] = week
+		case calendarRanks[token] == 1:
+			*segments[i] = int(date.Month())
+		case calendarRanks[token] == 2:
+			*segments[i] = date.Day()
+		default:
+			*segments[i] = micro
+		}
+	}
+	sv.Raw = sv.String()
+	return sv
+}
+
+// Parse parses a calendar version of the layout, with an optional pre-release
+// and build metadata as in SemVer (2024.05.1-rc.1+build.5)
+func (l CalendarLayout) Parse(version string) (*SemanticVersion, error) {
+	core, build, _ := strings.Cut(version, "+")
+	core, preRelease, hasPreRelease := strings.Cut(core, "-")
+	if hasPreRelease && ValidatePreRelease(preRelease) != nil {
+		return nil, fmt.Errorf("invalid calendar version %s: bad pre-release", version)
+	}
+	if build != "" && ValidateBuild(build) != nil {
+		return nil, fmt.Errorf("invalid calendar version %s: bad build metadata", version)
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != len(l.tokens) {
+		return nil, fmt.Errorf("invalid calendar version %s: expected format %s", version, l.format)
+	}
+
+	sv := &SemanticVersion{Layout: l.format, Build: build, Raw: version}
+	segments := sv.segments()
+	for i, token := range l.tokens {
+		value, err := parseCalendarSegment(token, parts[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid calendar version %s: %w", version, err)
+		}
+		*segments[i] = value
+	}
+	sv.SetPreRelease(preRelease)
+	return sv, nil
+}
+
+// SamePeriod reports whether two versions of the layout belong to the same
+// calendar period, i.e. differ at most in MICRO and pre-release
+func (l CalendarLayout) SamePeriod(a, b *SemanticVersion) bool {
+	if a.Layout != l.format || b.Layout != l.format {
+		return false
+	}
+	first, second := a.segments(), b.segments()
+	for i, token := range l.tokens {
+		if token != CalVerMicro && *first[i] != *second[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Micro returns a pointer to the MICRO segment of a version of the layout, or nil without MICRO
+func (l CalendarLayout) Micro(sv *SemanticVersion) *int {
+	if !l.HasMicro() {
+		return nil
+	}
+	return sv.segments()[len(l.tokens)-1]
+}
+
+// formatCore renders the segments of a version following the layout
+func (l CalendarLayout) formatCore(sv *SemanticVersion) string {
+	segments := sv.segments()
+	parts := make([]string, len(l.tokens))
+	for i, token := range l.tokens {
+		value := *segments[i]
+		switch token {
+		case CalVerShortYear:
+			parts[i] = strconv.Itoa(value - 2000)
+		case CalVerPaddedYear:
+			parts[i] = fmt.Sprintf("%02d", value-2000)
+		case CalVerPaddedMonth, CalVerPaddedWeek, CalVerPaddedDay:
+			parts[i] = fmt.Sprintf("%02d", value)
+		default:
+			parts[i] = strconv.Itoa(value)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// parseCalendarSegment parses one segment of a calendar version
+func parseCalendarSegment(token, part string) (int, error) {
+	if part == "" || !isNumericIdentifier(part) {
+		return 0, fmt.Errorf("segment %s must be numeric, got %q", token, part)
+	}
+	padded := token == CalVerPaddedYear || token == CalVerPaddedMonth || token == CalVerPaddedWeek || token == CalVerPaddedDay
+	if padded && len(part) != 2 {
+		return 0, fmt.Errorf("segment %s must have two digits, got %q", token, part)
+	}
+	if !padded && len(part) > 1 && part[0] == '0' {
+		return 0, fmt.Errorf("segment %s must not have leading zeros, got %q", token, part)
+	}
+
+	value, err := strconv.Atoi(part)
+	if err != nil {
+		return 0, err
+	}
+	switch calendarRanks[token] {
+	case 0:
+		if token == CalVerFullYear {
+			if len(part) != 4 {
+				return 0, fmt.Errorf("segment %s must have four digits, got %q", token, part)
+			}
+			return value, nil
+		}
+		return value + 2000, nil
+	case 1:
+		if (isWeekToken(token) && (value < 1 || value > 53)) || (!isWeekToken(token) && (value < 1 || value > 12)) {
+			return 0, fmt.Errorf("segment %s out of range: %d", token, value)
+		}
+	case 2:
+		if value < 1 || value > 31 {
+			return 0, fmt.Errorf("segment %s out of range: %d", token, value)
+		}
+	}
+	return value, nil
+}
+
+// isWeekToken reports whether a token is a week of the year
+func isWeekToken(token string) bool {
+	return token == CalVerWeek || token == CalVerPaddedWeek
+}
+
+// segments returns pointers to the numeric fields of a version, most significant first
+func (sv *SemanticVersion) segments() []*int {
+	return []*int{&sv.Major, &sv.Minor, &sv.Patch}
+}
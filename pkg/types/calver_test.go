@@ -0,0 +1,80 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCalendarLayout(t *testing.T) {
+	for _, format := range []string{"YYYY.0M.MICRO", "YY.0M.MICRO", "YYYY.0M.0D", "0Y.WW.MICRO", "YYYY.MICRO"} {
+		_, err := ParseCalendarLayout(format)
+		assert.NoError(t, err, format)
+	}
+	for _, format := range []string{"YYYY", "MM.YYYY.MICRO", "YYYY.0M.0D.MICRO", "YYYY.0W.0D", "YYYY.0M.0M", "YYYY-0M", "YYYY.Q.MICRO"} {
+		_, err := ParseCalendarLayout(format)
+		assert.Error(t, err, format)
+	}
+}
+
+func TestCalendarLayout_Parse(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		expected [3]int
+	}{
+		{"YYYY.0M.MICRO", "2024.05.3", [3]int{2024, 5, 3}},
+		{"YYYY.MM.MICRO", "2024.11.0-rc.2+build.7", [3]int{2024, 11, 0}},
+		{"YY.0M.MICRO", "24.01.12", [3]int{2024, 1, 12}},
+		{"0Y.0M", "09.12", [3]int{2009, 12, 0}},
+		{"YYYY.0M.0D", "2024.02.29", [3]int{2024, 2, 29}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			layout, err := ParseCalendarLayout(tt.format)
+			require.NoError(t, err)
+			version, err := layout.Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, [3]int{version.Major, version.Minor, version.Patch})
+			assert.Equal(t, tt.input, version.String())
+		})
+	}
+
+	layout, err := ParseCalendarLayout("YYYY.0M.MICRO")
+	require.NoError(t, err)
+	for _, invalid := range []string{"2024.5.1", "2024.13.0", "24.05.1", "2024.05.01", "2024.05", "1.2.3", "2024.05.1-"} {
+		_, err := layout.Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCalendarLayout_Version(t *testing.T) {
+	date := time.Date(2025, time.December, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"YYYY.0M.MICRO", "2025.12.4"},
+		{"YY.MM.MICRO", "25.12.4"},
+		{"YYYY.0M.0D", "2025.12.30"},
+		{"YYYY.0W.MICRO", "2026.01.4"}, // 2025-12-30 is in ISO week 1 of 2026
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			layout, err := ParseCalendarLayout(tt.format)
+			require.NoError(t, err)
+			version := layout.Version(date, 4)
+			assert.Equal(t, tt.expected, version.String())
+
+			parsed, err := layout.Parse(tt.expected)
+			require.NoError(t, err)
+			assert.Zero(t, parsed.Compare(version))
+			assert.True(t, layout.SamePeriod(parsed, layout.Version(date, 0)))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverPattern matches a SemVer 2.0 version without the "v" prefix
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// identifierPattern matches dot-separated pre-release or build identifiers
+var identifierPattern = regexp.MustCompile(`^[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*$`)
+
+// ParseSemanticVersion parses a SemVer 2.0 version, with or without a "v" prefix
+func ParseSemanticVersion(version string) (*SemanticVersion, error) {
+	raw := strings.TrimPrefix(version, "v")
+	matches := semverPattern.FindStringSubmatch(raw)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid semantic version format: %s", version)
+	}
+
+	sv := &SemanticVersion{Build: matches[5], Raw: raw}
+	var err error
+	if sv.Major, err = strconv.Atoi(matches[1]); err != nil {
+		return nil, fmt.Errorf("invalid major version: %s", matches[1])
+	}
+	if sv.Minor, err = strconv.Atoi(matches[2]); err != nil {
+		return nil, fmt.Errorf("invalid minor version: %s", matches[2])
+	}
+	if sv.Patch, err = strconv.Atoi(matches[3]); err != nil {
+		return nil, fmt.Errorf("invalid patch version: %s", matches[3])
+	}
+	sv.SetPreRelease(matches[4])
+
+	return sv, nil
+}
+
+// ValidatePreRelease checks that a pre-release label is made of valid SemVer identifiers
+func ValidatePreRelease(label string) error {
+	if !identifierPattern.MatchString(label) {
+		return fmt.Errorf("invalid pre-release %q: expected dot-separated identifiers [0-9A-Za-z-]", label)
+	}
+	for _, identifier := range strings.Split(label, ".") {
+		if isNumericIdentifier(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("invalid pre-release %q: numeric identifiers must not have leading zeros", label)
+		}
+	}
+	return nil
+}
+
+// ValidateBuild checks that build metadata is made of valid SemVer identifiers
+func ValidateBuild(build string) error {
+	if !identifierPattern.MatchString(build) {
+		return fmt.Errorf("invalid build metadata %q: expected dot-separated identifiers [0-9A-Za-z-]", build)
+	}
+	return nil
+}
+
+// PreReleaseString returns the full pre-release, e.g. "beta.2", or "" for a release
+func (sv *SemanticVersion) PreReleaseString() string {
+	if sv.PreRelease == "" {
+		return ""
+	}
+	if sv.PreNumber > 0 {
+		return fmt.Sprintf("%s.%d", sv.PreRelease, sv.PreNumber)
+	}
+	return string(sv.PreRelease)
+}
+
+// SetPreRelease sets the pre-release from its string form, splitting off a
+// positive trailing counter when it follows another identifier
+func (sv *SemanticVersion) SetPreRelease(preRelease string) {
+	sv.PreRelease = PreReleaseType(preRelease)
+	sv.PreNumber = 0
+
+	separator := strings.LastIndex(preRelease, ".")
+	if separator <= 0 {
+		return
+	}
+	counter := preRelease[separator+1:]
+	if !isNumericIdentifier(counter) || counter[0] == '0' {
+		return
+	}
+	if number, err := strconv.Atoi(counter); err == nil {
+		sv.PreRelease = PreReleaseType(preRelease[:separator])
+		sv.PreNumber = number
+	}
+}
+
+// IsPreRelease reports whether the version is a pre-release
+func (sv *SemanticVersion) IsPreRelease() bool {
+	return sv.PreRelease != ""
+}
+
+// Compare returns -1, 0 or 1 when sv has lower, equal or higher precedence
+// than other following SemVer 2.0: build metadata is ignored and a pre-release
+// has lower precedence than the release of the same core version
+func (sv *SemanticVersion) Compare(other *SemanticVersion) int {
+	for _, pair := range [][2]int{{sv.Major, other.Major}, {sv.Minor, other.Minor}, {sv.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return compareInts(pair[0], pair[1])
+		}
+	}
+
+	first, second := sv.PreReleaseString(), other.PreReleaseString()
+	switch {
+	case first == second:
+		return 0
+	case first == "":
+		return 1
+	case second == "":
+		return -1
+	}
+	return comparePreRelease(strings.Split(first, "."), strings.Split(second, "."))
+}
+
+// comparePreRelease compares pre-release identifiers: numeric identifiers
+// numerically, alphanumeric ones in ASCII order, numeric below alphanumeric,
+// and a shorter set below a longer one it is a prefix of
+func comparePreRelease(first, second []string) int {
+	for i := 0; i < len(first) && i < len(second); i++ {
+		a, b := first[i], second[i]
+		if a == b {
+			continue
+		}
+
+		aNumeric, bNumeric := isNumericIdentifier(a), isNumericIdentifier(b)
+		switch {
+		case aNumeric && bNumeric:
+			if len(a) != len(b) {
+				return compareInts(len(a), len(b))
+			}
+			return strings.Compare(a, b)
+		case aNumeric:
+			return -1
+		case bNumeric:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	}
+	return compareInts(len(first), len(second))
+}
+
+// isNumericIdentifier reports whether an identifier consists only of ASCII digits
+func isNumericIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+	for _, r := range identifier {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareInts returns -1, 0 or 1 when a is lower, equal or higher than b
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
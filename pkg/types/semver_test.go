@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	tests := []struct {
+		input      string
+		preRelease PreReleaseType
+		preNumber  int
+		build      string
+		expected   string
+	}{
+		{"v1.2.3", "", 0, "", "1.2.3"},
+		{"1.2.3-beta.2", "beta", 2, "", "1.2.3-beta.2"},
+		{"1.2.3-rc", "rc", 0, "", "1.2.3-rc"},
+		{"1.2.3-alpha.0", "alpha.0", 0, "", "1.2.3-alpha.0"},
+		{"1.2.3-x.7.z.92", "x.7.z", 92, "", "1.2.3-x.7.z.92"},
+		{"1.2.3-1", "1", 0, "", "1.2.3-1"},
+		{"1.2.3+build.5", "", 0, "build.5", "1.2.3+build.5"},
+		{"v1.0.0-rc.1+sha.5114f85", "rc", 1, "sha.5114f85", "1.0.0-rc.1+sha.5114f85"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			version, err := ParseSemanticVersion(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.preRelease, version.PreRelease)
+			assert.Equal(t, tt.preNumber, version.PreNumber)
+			assert.Equal(t, tt.build, version.Build)
+			assert.Equal(t, tt.expected, version.String())
+		})
+	}
+
+	for _, invalid := range []string{"1.2", "01.2.3", "1.2.3-", "1.2.3-01", "1.2.3+", "1.2.3-beta..1", "latest"} {
+		_, err := ParseSemanticVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSemanticVersion_Compare(t *testing.T) {
+	// Precedence example from the SemVer 2.0 specification, lowest first
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, err := ParseSemanticVersion(ordered[i])
+		require.NoError(t, err)
+		higher, err := ParseSemanticVersion(ordered[i+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	withBuild, _ := ParseSemanticVersion("1.0.0+build.1")
+	withoutBuild, _ := ParseSemanticVersion("1.0.0")
+	assert.Equal(t, 0, withBuild.Compare(withoutBuild))
+}
+
+func TestValidatePreRelease(t *testing.T) {
+	for _, valid := range []string{"alpha", "beta", "rc", "preview.1", "x-y"} {
+		assert.NoError(t, ValidatePreRelease(valid), valid)
+	}
+	for _, invalid := range []string{"", "beta..1", "beta_1", "beta.01"} {
+		assert.Error(t, ValidatePreRelease(invalid), invalid)
+	}
+}
@@ -40,8 +40,30 @@ type FileChange struct {
 	ChangeType   ChangeType `json:"change_type"`
 	LinesAdded   int        `json:"lines_added"`
 	LinesDeleted int        `json:"lines_deleted"`
-	Content      string     `json:"content,omitempty"`  // Actual diff content
-	Language     string     `json:"language,omitempty"` // Programming language detected
+	Content      string     `json:"content,omitempty"`    // Actual diff content
+	Language     string     `json:"language,omitempty"`   // Programming language detected
+	OldMode      string     `json:"old_mode,omitempty"`   // File mode before the change, e.g. 100644
+	NewMode      string     `json:"new_mode,omitempty"`   // File mode after the change, e.g. 100755
+	Similarity   int        `json:"similarity,omitempty"` // Similarity percentage for renames/copies
+	IsBinary     bool       `json:"is_binary,omitempty"`
+	Hunks        []DiffHunk `json:"hunks,omitempty"`
+}
+
+// IsModeChange reports whether the file mode changed
+func (fc *FileChange) IsModeChange() bool {
+	return fc.OldMode != "" && fc.NewMode != "" && fc.OldMode != fc.NewMode
+}
+
+// DiffHunk represents a single hunk of a unified diff
+type DiffHunk struct {
+	OldStart     int      `json:"old_start"`
+	OldLines     int      `json:"old_lines"`
+	NewStart     int      `json:"new_start"`
+	NewLines     int      `json:"new_lines"`
+	Section      string   `json:"section,omitempty"` // Function context after the @@ header
+	LinesAdded   int      `json:"lines_added"`
+	LinesDeleted int      `json:"lines_deleted"`
+	Lines        []string `json:"lines,omitempty"` // Hunk body including +, - and context prefixes
 }
 
 // DiffSummary represents a summary of all changes
@@ -59,12 +81,14 @@ type DiffSummary struct {
 
 // CommitInfo represents information about a git commit
 type CommitInfo struct {
-	Hash    string    `json:"hash"`
-	Subject string    `json:"subject"`
-	Author  string    `json:"author"`
-	Date    time.Time `json:"date"`
-	Slug    string    `json:"slug"`
-	Files   []string  `json:"files,omitempty"`
+	Hash        string    `json:"hash"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body,omitempty"` // Message after the subject line, including footers
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"author_email,omitempty"`
+	Date        time.Time `json:"date"`
+	Slug        string    `json:"slug"`
+	Files       []string  `json:"files,omitempty"`
 }
 
 // ChangeContext represents context about changes for enhanced commit message generation
@@ -85,6 +109,9 @@ const (
 	StyleTraditional  CommitMessageStyle = "traditional"
 	StyleDetailed     CommitMessageStyle = "detailed"
 	StyleMinimal      CommitMessageStyle = "minimal"
+	StyleGitmoji      CommitMessageStyle = "gitmoji"
+	StyleAngular      CommitMessageStyle = "angular"
+	StyleTemplate     CommitMessageStyle = "template"
 )
 
 // ScopeDetectionRule represents a rule for automatic scope detection
@@ -132,6 +159,7 @@ type CommitMessage struct {
 	Breaking         bool                    `json:"breaking"`
 	Language         string                  `json:"language,omitempty"` // en, vi
 	Metadata         map[string]string       `json:"metadata,omitempty"` // additional metadata
+	Trailers         []Trailer               `json:"trailers,omitempty"` // git trailers merged into the footer
 	FormattedMessage string                  `json:"formatted_message,omitempty"`
 	ValidationResult *CommitValidationResult `json:"validation_result,omitempty"`
 }
@@ -147,6 +175,7 @@ type CommitValidationResult struct {
 // ValidationError represents a validation error
 type ValidationError struct {
 	Type     string `json:"type"`
+	RuleID   string `json:"rule_id,omitempty"` // Stable rule ID, such as GG101
 	Message  string `json:"message"`
 	Line     int    `json:"line,omitempty"`
 	Column   int    `json:"column,omitempty"`
@@ -156,8 +185,11 @@ type ValidationError struct {
 // ValidationWarning represents a validation warning
 type ValidationWarning struct {
 	Type       string `json:"type"`
+	RuleID     string `json:"rule_id,omitempty"` // Stable rule ID, such as GG101
 	Message    string `json:"message"`
 	Suggestion string `json:"suggestion,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
 }
 
 // ValidationSuggestion represents a validation suggestion
@@ -202,9 +234,15 @@ func (cm *CommitMessage) String() string {
 
 // Config represents the application configuration
 type Config struct {
-	Gemini GeminiConfig `mapstructure:"gemini"`
-	Git    GitConfig    `mapstructure:"git"`
-	Output OutputConfig `mapstructure:"output"`
+	Gemini       GeminiConfig      `mapstructure:"gemini"`
+	Git          GitConfig         `mapstructure:"git"`
+	Output       OutputConfig      `mapstructure:"output"`
+	Trailers     TrailerConfig     `mapstructure:"trailers"`
+	Changelog    ChangelogConfig   `mapstructure:"changelog"`
+	VersionFiles []VersionFile     `mapstructure:"version_files"` // Project files bumped by the tag command
+	Modules      []Module          `mapstructure:"modules"`       // Modules released separately, in addition to discovered ones
+	Versioning   VersioningConfig  `mapstructure:"versioning"`
+	Validation   CommitRulesConfig `mapstructure:"validation"`
 }
 
 // GeminiConfig represents Gemini API configuration
@@ -220,15 +258,103 @@ type GitConfig struct {
 	MaxDiffSize   int      `mapstructure:"max_diff_size"`
 	IgnoreFiles   []string `mapstructure:"ignore_files"`
 	IncludeStaged bool     `mapstructure:"include_staged"`
+	Backend       string   `mapstructure:"backend"` // exec, go-git
+	Remote        string   `mapstructure:"remote"`  // Remote used when pushing tags
 }
 
 // OutputConfig represents output formatting configuration
 type OutputConfig struct {
-	Style            string `mapstructure:"style"` // conventional, simple, detailed
-	MaxLines         int    `mapstructure:"max_lines"`
-	DryRun           bool   `mapstructure:"dry_run"`
-	Language         string `mapstructure:"language"`           // vi, en
-	MaxSubjectLength int    `mapstructure:"max_subject_length"` // Max subject line length
+	Style            string         `mapstructure:"style"` // conventional, gitmoji, angular, traditional, minimal or template
+	MaxLines         int            `mapstructure:"max_lines"`
+	DryRun           bool           `mapstructure:"dry_run"`
+	Language         string         `mapstructure:"language"`           // vi, en
+	MaxSubjectLength int            `mapstructure:"max_subject_length"` // Max subject line length
+	RepairAttempts   int            `mapstructure:"repair_attempts"`    // Times the model is asked to fix validation errors, 0 disables
+	Template         HeaderTemplate `mapstructure:"template"`           // Header of the "template" style
+}
+
+// HeaderTemplate defines the header of the "template" message style
+type HeaderTemplate struct {
+	Header      string   `mapstructure:"header"`       // Go template with .Type, .Scope, .Description, .Breaking and .Emoji
+	Pattern     string   `mapstructure:"pattern"`      // Regex with named groups parsing a header; derived from simple headers
+	Guidance    []string `mapstructure:"guidance"`     // Instructions added to the prompt
+	SubjectOnly bool     `mapstructure:"subject_only"` // Messages have no body
+}
+
+// TrailerConfig represents configuration for git trailers added to generated commits
+type TrailerConfig struct {
+	IssuePatterns []string          `mapstructure:"issue_patterns"` // Regexes extracting issue keys from the branch name
+	IssueToken    string            `mapstructure:"issue_token"`    // Trailer for issue keys (Refs, Closes)
+	SignOff       bool              `mapstructure:"sign_off"`       // Add Signed-off-by with the git user identity
+	Team          map[string]string `mapstructure:"team"`           // Co-author roster: alias -> "Name <email>"
+	Custom        []string          `mapstructure:"custom"`         // Trailers added to every commit ("Token: value")
+}
+
+// CommitRulesConfig configures the rules checking commit messages, on top of
+// a commitlint configuration found in the repository
+type CommitRulesConfig struct {
+	Rules          map[string]string `mapstructure:"rules"`            // Severity per rule type or ID: error, warning or off
+	RequireBodyFor []string          `mapstructure:"require_body_for"` // Commit types that need a body, e.g. feat and fix
+	ForbiddenWords []string          `mapstructure:"forbidden_words"`  // Words that must not appear in a message, such as WIP
+	TicketPattern  string            `mapstructure:"ticket_pattern"`   // Regex of the ticket reference every message needs
+	Scopes         []string          `mapstructure:"scopes"`           // Allowed scopes; empty allows any
+	DetectedScopes bool              `mapstructure:"detected_scopes"`  // Also allow the scopes assigned by the scope detection rules
+	Custom         []CustomRule      `mapstructure:"custom"`           // Regex rules declared by the team
+}
+
+// CustomRule is a regular expression rule declared in configuration
+type CustomRule struct {
+	ID       string `mapstructure:"id"`       // Rule name used in reports and severities
+	Pattern  string `mapstructure:"pattern"`  // Regex searched in the field
+	Field    string `mapstructure:"field"`    // subject, body, footer or message (default)
+	Require  bool   `mapstructure:"require"`  // Report when the pattern does not match instead of when it does
+	Severity string `mapstructure:"severity"` // error (default) or warning
+	Message  string `mapstructure:"message"`  // Shown when the rule fails
+}
+
+// VersioningConfig selects how release versions are numbered
+type VersioningConfig struct {
+	Scheme       string `mapstructure:"scheme"`        // "semver" or "calver"
+	CalVerFormat string `mapstructure:"calver_format"` // CalVer format, e.g. "YYYY.0M.MICRO"
+}
+
+// ChangelogConfig represents configuration for changelog generation
+type ChangelogConfig struct {
+	File       string `mapstructure:"file"`        // Changelog path, relative to the repository root
+	Format     string `mapstructure:"format"`      // keepachangelog, conventional
+	CommitURL  string `mapstructure:"commit_url"`  // Commit link template with {hash} and {short}
+	CompareURL string `mapstructure:"compare_url"` // Version link template with {from} and {to}
+	Authors    bool   `mapstructure:"authors"`     // Credit commit authors in entries
+}
+
+// Module is a separately released part of a monorepo, such as a Go module in a
+// workspace or a JS workspace package
+type Module struct {
+	Name      string `mapstructure:"name" json:"name"`             // Name used with tag --module
+	Path      string `mapstructure:"path" json:"path"`             // Directory relative to the repository root
+	TagPrefix string `mapstructure:"tag_prefix" json:"tag_prefix"` // Prefix before the version, e.g. "api/v" or "@scope/pkg@"
+
+	VersionFiles []VersionFile `mapstructure:"version_files" json:"version_files,omitempty"` // Files bumped when the module is tagged, relative to its directory
+	Changelog    string        `mapstructure:"changelog" json:"changelog,omitempty"`         // Changelog file relative to its directory, named like changelog.file by default
+}
+
+// VersionFile describes where a project file stores its version
+type VersionFile struct {
+	Path    string `mapstructure:"path"`    // File path, relative to the repository root
+	Format  string `mapstructure:"format"`  // json, yaml, toml, regex, plain; detected from the file name when empty
+	Key     string `mapstructure:"key"`     // Dotted key for json, yaml and toml, e.g. "tool.poetry.version"
+	Pattern string `mapstructure:"pattern"` // Regex for the regex format; the first capture group is replaced
+}
+
+// Trailer represents a git trailer such as "Signed-off-by: Name <email>"
+type Trailer struct {
+	Token string `json:"token"`
+	Value string `json:"value"`
+}
+
+// String formats the trailer as it appears in a commit message
+func (t Trailer) String() string {
+	return t.Token + ": " + t.Value
 }
 
 // VersionBumpType represents the type of semantic version bump
@@ -238,6 +364,7 @@ const (
 	VersionBumpPatch VersionBumpType = "patch" // Bug fixes, documentation updates
 	VersionBumpMinor VersionBumpType = "minor" // New features, backwards-compatible changes
 	VersionBumpMajor VersionBumpType = "major" // Breaking changes, API changes
+	VersionBumpNone  VersionBumpType = "none"  // No release-worthy changes
 )
 
 // PreReleaseType represents pre-release version types
@@ -249,25 +376,36 @@ const (
 	PreReleaseRC    PreReleaseType = "rc"
 )
 
-// SemanticVersion represents a semantic version
+// SemanticVersion represents a semantic version as defined by SemVer 2.0.
+// A pre-release such as "beta.2" is split into its label (PreRelease "beta")
+// and trailing counter (PreNumber 2); identifiers without a counter, such as
+// "x.7.z" or "alpha.0", are kept entirely in PreRelease.
 type SemanticVersion struct {
 	Major      int            `json:"major"`
 	Minor      int            `json:"minor"`
 	Patch      int            `json:"patch"`
 	PreRelease PreReleaseType `json:"pre_release,omitempty"`
 	PreNumber  int            `json:"pre_number,omitempty"`
-	Raw        string         `json:"raw"` // Original version string
+	Build      string         `json:"build,omitempty"`  // Build metadata after "+", ignored for precedence
+	Layout     string         `json:"layout,omitempty"` // CalVer format of a calendar version, empty for SemVer
+	Raw        string         `json:"raw"`              // Original version string
 }
 
-// String returns the formatted semantic version
+// String returns the formatted semantic version, or the calendar version
+// following its layout
 func (sv *SemanticVersion) String() string {
 	version := fmt.Sprintf("%d.%d.%d", sv.Major, sv.Minor, sv.Patch)
-	if sv.PreRelease != "" {
-		version += fmt.Sprintf("-%s", sv.PreRelease)
-		if sv.PreNumber > 0 {
-			version += fmt.Sprintf(".%d", sv.PreNumber)
+	if sv.Layout != "" {
+		if layout, err := ParseCalendarLayout(sv.Layout); err == nil {
+			version = layout.formatCore(sv)
 		}
 	}
+	if preRelease := sv.PreReleaseString(); preRelease != "" {
+		version += "-" + preRelease
+	}
+	if sv.Build != "" {
+		version += "+" + sv.Build
+	}
 	return version
 }
 
@@ -287,6 +425,43 @@ type VersionAnalysis struct {
 	Documentation   []string        `json:"documentation,omitempty"`
 	Dependencies    []string        `json:"dependencies,omitempty"`
 	Metadata        map[string]any  `json:"metadata,omitempty"`
+
+	Source     string              `json:"source,omitempty"`      // "conventional" or "ai"
+	BaseTag    string              `json:"base_tag,omitempty"`    // Tag the commits were analyzed from
+	Commits    []VersionBumpCommit `json:"commits,omitempty"`     // Commits that drove the recommendation
+	APIChanges []APIChange         `json:"api_changes,omitempty"` // Exported Go API changes since BaseTag
+}
+
+// APIChangeKind describes how an exported identifier changed
+type APIChangeKind string
+
+const (
+	APIChangeAdded   APIChangeKind = "added"
+	APIChangeRemoved APIChangeKind = "removed"
+	APIChangeChanged APIChangeKind = "changed"
+)
+
+// APIChange is a change to an exported declaration of a Go package
+type APIChange struct {
+	Package    string        `json:"package"`          // Package directory relative to the repository root
+	Name       string        `json:"name"`             // Identifier, e.g. "func Parse" or "method Client.Do"
+	Kind       APIChangeKind `json:"kind"`             // Added, removed or changed
+	Before     string        `json:"before,omitempty"` // Declaration at the base tag
+	After      string        `json:"after,omitempty"`  // Declaration at HEAD
+	Compatible bool          `json:"compatible"`       // False when existing callers may break
+}
+
+// String describes the change, e.g. "pkg/client: removed func Parse"
+func (c APIChange) String() string {
+	return fmt.Sprintf("%s: %s %s", c.Package, c.Kind, c.Name)
+}
+
+// VersionBumpCommit links a commit to the version bump it requires
+type VersionBumpCommit struct {
+	Hash    string          `json:"hash"`
+	Subject string          `json:"subject"`
+	Bump    VersionBumpType `json:"bump"`
+	Reason  string          `json:"reason"` // e.g. "feat", "fix", "BREAKING CHANGE"
 }
 
 // GitTag represents a Git tag with version information
@@ -304,7 +479,53 @@ type TaggingOptions struct {
 	DryRun     bool            `json:"dry_run"`               // Preview only, don't create tag
 	ForceBump  VersionBumpType `json:"force_bump,omitempty"`  // Force specific version type
 	PreRelease PreReleaseType  `json:"pre_release,omitempty"` // Create pre-release version
+	Promote    bool            `json:"promote,omitempty"`     // Release the current pre-release as final
+	Build      string          `json:"build,omitempty"`       // Build metadata appended after "+"
 	Message    string          `json:"message,omitempty"`     // Custom tag annotation message
 	Push       bool            `json:"push"`                  // Push tag to remote after creation
+	Remote     string          `json:"remote,omitempty"`      // Remote to push to
+	PushBranch bool            `json:"push_branch"`           // Push the current branch atomically with the tag
 	Annotated  bool            `json:"annotated"`             // Create annotated tag
+	APIChanges []APIChange     `json:"api_changes,omitempty"` // Incompatible API changes listed in the annotation
+}
+
+// PullRequest represents a generated pull request title and description
+type PullRequest struct {
+	Title           string         `json:"title"`
+	Summary         string         `json:"summary"`
+	Changes         []ScopeChanges `json:"changes"`          // Changes grouped by scope
+	Testing         []string       `json:"testing"`          // Testing notes for reviewers
+	BreakingChanges []string       `json:"breaking_changes"` // Breaking changes, if any
+	BaseBranch      string         `json:"base_branch"`
+	HeadBranch      string         `json:"head_branch,omitempty"`
+	Commits         []*CommitInfo  `json:"commits,omitempty"`
+}
+
+// ReleaseAudience selects who release notes are written for
+type ReleaseAudience string
+
+const (
+	ReleaseAudienceDevelopers ReleaseAudience = "developers" // API changes, migrations and technical detail
+	ReleaseAudienceUsers      ReleaseAudience = "users"      // Visible behaviour in plain language
+)
+
+// ReleaseNotes represents user-facing release notes for a range of commits
+type ReleaseNotes struct {
+	Title        string          `json:"title"`
+	Summary      string          `json:"summary"`
+	Highlights   []string        `json:"highlights"`              // The most important changes of the release
+	Features     []string        `json:"features"`                // New features
+	Fixes        []string        `json:"fixes"`                   // Bug fixes and performance improvements
+	Dependencies []string        `json:"dependencies,omitempty"`  // Dependency updates
+	UpgradeNotes []string        `json:"upgrade_notes,omitempty"` // Steps required by breaking changes
+	Audience     ReleaseAudience `json:"audience"`
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	CommitCount  int             `json:"commit_count"`
+}
+
+// ScopeChanges lists the changes made in a single scope
+type ScopeChanges struct {
+	Scope string   `json:"scope"`
+	Items []string `json:"items"`
 }